@@ -0,0 +1,302 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/joho/godotenv"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"wondee/finance-app-backend/internal/account"
+	"wondee/finance-app-backend/internal/api"
+	"wondee/finance-app-backend/internal/attachment"
+	"wondee/finance-app-backend/internal/audit"
+	"wondee/finance-app-backend/internal/auth/api"
+	"wondee/finance-app-backend/internal/auth/middleware"
+	"wondee/finance-app-backend/internal/budget"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/currency"
+	"wondee/finance-app-backend/internal/notification"
+	"wondee/finance-app-backend/internal/scenario"
+	"wondee/finance-app-backend/internal/settlement"
+	"wondee/finance-app-backend/internal/spend"
+	"wondee/finance-app-backend/internal/storage"
+	"wondee/finance-app-backend/internal/transaction"
+	"wondee/finance-app-backend/internal/user"
+	"wondee/finance-app-backend/internal/wealth"
+	"wondee/finance-app-backend/internal/workspace"
+)
+
+func ConnectDataBase() *gorm.DB {
+	// Try loading .env from current dir or parent dir
+	_ = godotenv.Load(".env")
+	_ = godotenv.Load("../.env")
+	_ = godotenv.Load("../../.env")
+
+	// Get database configuration from environment variables
+	dbHost := getEnv("DB_HOST", "localhost")
+	dbPort := getEnv("DB_PORT", "5432")
+	dbUser := getEnv("DB_USER", "postgres")
+	dbPassword := getEnv("DB_PASSWORD", "admin")
+	dbName := getEnv("DB_NAME", "financeapp")
+	dbSSLMode := getEnv("DB_SSLMODE", "disable")
+
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+		dbHost, dbUser, dbPassword, dbName, dbPort, dbSSLMode)
+
+	// Debug Log (Masking Password)
+	fmt.Printf("Connecting to DB: host=%s user=%s dbname=%s port=%s sslmode=%s\n",
+		dbHost, dbUser, dbName, dbPort, dbSSLMode)
+
+	database, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		panic("Failed to connect to database!")
+	}
+
+	// Step 3: AutoMigrate - now safe to add NOT NULL constraints since data is populated
+	// Order matters: Workspace must be created before tables that reference it
+	err = database.AutoMigrate(
+		&workspace.Workspace{},
+		&user.User{},
+		&cost.FixedCost{},
+		&cost.SpecialCost{},
+		&cost.Category{},
+		&cost.AmountRevision{},
+		&wealth.WealthProfile{},
+		&workspace.Invite{},
+		&spend.MonthlyPaymentStatus{},
+		&spend.OneTimePendingCost{},
+		&currency.ExchangeRate{},
+		&transaction.Transaction{},
+		&attachment.Attachment{},
+		&settlement.Entry{},
+		&settlement.Share{},
+		&audit.Entry{},
+		&account.Account{},
+		&budget.Budget{},
+		&budget.Entry{},
+		&notification.Notification{},
+		&scenario.Scenario{},
+		&scenario.Change{},
+	)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return database
+}
+
+func getEnv(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func main() {
+	router := gin.Default()
+
+	// CORS Configuration
+	frontendRegex := regexp.MustCompile(`^https://finanz-frontend-.*\.run\.app$`) // Corrected regex escaping
+
+	router.Use(cors.New(cors.Config{
+		AllowOriginFunc: func(origin string) bool {
+			return origin == "http://localhost:8080" ||
+					origin == "http://localhost:5173" ||
+					origin == "https://finance.wondee.info" ||
+					frontendRegex.MatchString(origin)
+		},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		ExposeHeaders:    []string{"Content-Length"},
+		AllowCredentials: true,
+		MaxAge:           12 * time.Hour,
+	}))
+
+	db := ConnectDataBase()
+	repo := &storage.GormRepository{DB: db}
+	server := api.NewServer(repo)
+	authHandler := auth.NewAuthHandler(repo)
+
+	// Purge deleted costs past the retention period at startup and then hourly
+	if server.TrashHandler != nil {
+		server.TrashHandler.StartPurging(time.Hour)
+	}
+
+	// Auth Routes
+	router.GET("/auth/google/login", authHandler.Login)
+	router.GET("/auth/google/callback", authHandler.Callback)
+	router.GET("/auth/me", authHandler.Me)
+	router.POST("/auth/logout", authHandler.Logout)
+
+	// Protected API Routes
+	apiGroup := router.Group("/api")
+	apiGroup.Use(middleware.AuthMiddleware())
+	{
+		apiGroup.GET("/overview/all", server.OverviewHandler.GetOverview)
+		apiGroup.GET("/overview/detail", server.OverviewHandler.GetOverviewDetail)
+		apiGroup.GET("/overview/members", server.OverviewHandler.GetMemberOverview)
+		apiGroup.GET("/overview/risk", server.OverviewHandler.GetRisk)
+		apiGroup.PUT("/overview/risk/threshold", server.OverviewHandler.UpdateThreshold)
+
+		apiGroup.GET("/costs", server.FixedCostHandler.GetFixedCosts)
+		apiGroup.DELETE("/costs/:id", server.FixedCostHandler.DeleteFixedCosts)
+		apiGroup.POST("/costs", server.FixedCostHandler.SaveFixedCost)
+		apiGroup.GET("/costs/deadlines", server.FixedCostHandler.GetCancellationDeadlines)
+		apiGroup.GET("/costs/:id/revisions", server.FixedCostHandler.GetAmountHistory)
+		apiGroup.POST("/costs/:id/revisions", server.FixedCostHandler.SaveAmountRevision)
+		apiGroup.DELETE("/costs/:id/revisions/:revisionId", server.FixedCostHandler.DeleteAmountRevision)
+		apiGroup.POST("/costs/monthly", server.FixedCostHandler.SaveMonthlyFixedCosts)
+		apiGroup.POST("/costs/halfyearly", server.FixedCostHandler.SaveHalfYearlyFixedCosts)
+		apiGroup.POST("/costs/yearly", server.FixedCostHandler.SaveYearlyFixedCosts)
+		apiGroup.POST("/costs/quaterly", server.FixedCostHandler.SaveQuaterlyFixedCosts)
+
+		apiGroup.GET("/specialcosts", server.SpecialCostHandler.GetSpecialCosts)
+		apiGroup.POST("/specialcosts", server.SpecialCostHandler.SaveSpecialCosts)
+		apiGroup.DELETE("/specialcosts/:id", server.SpecialCostHandler.DeleteSpecialCosts)
+
+		apiGroup.POST("/import/costs", server.ImportHandler.ImportCosts)
+
+		apiGroup.GET("/export/costs", server.FixedCostHandler.ExportFixedCosts)
+		apiGroup.GET("/export/specialcosts", server.SpecialCostHandler.ExportSpecialCosts)
+		apiGroup.GET("/export/overview", server.OverviewHandler.ExportOverview)
+		apiGroup.GET("/export/surplus", server.OverviewHandler.ExportSurplusHistory)
+		apiGroup.GET("/export/forecast", server.ForecastHandler.ExportWealthForecast)
+
+		apiGroup.GET("/categories", server.CategoryHandler.GetCategories)
+		apiGroup.POST("/categories", server.CategoryHandler.SaveCategory)
+		apiGroup.DELETE("/categories/:id", server.CategoryHandler.DeleteCategory)
+
+		apiGroup.PUT("/user/current-amount", server.UserHandler.UpdateCurrentAmount)
+		apiGroup.PATCH("/user/onboarding-status", server.UserHandler.UpdateOnboardingStatus)
+		apiGroup.DELETE("/user", server.UserHandler.DeleteCurrentUser)
+
+		apiGroup.GET("/wealth-profile", server.ProfileHandler.GetWealthProfile)
+		apiGroup.PUT("/wealth-profile", server.ProfileHandler.UpsertWealthProfile)
+
+		apiGroup.GET("/currency", server.CurrencyHandler.GetCurrencySettings)
+		apiGroup.PUT("/currency/base", server.CurrencyHandler.UpdateBaseCurrency)
+		apiGroup.POST("/currency/rates", server.CurrencyHandler.SaveExchangeRate)
+		apiGroup.DELETE("/currency/rates/:id", server.CurrencyHandler.DeleteExchangeRate)
+
+		apiGroup.GET("/accounts", server.AccountHandler.GetAccounts)
+		apiGroup.POST("/accounts", server.AccountHandler.SaveAccount)
+		apiGroup.DELETE("/accounts/:id", server.AccountHandler.DeleteAccount)
+
+		apiGroup.GET("/wealth/forecast", server.ForecastHandler.GetWealthForecast)
+
+		apiGroup.GET("/statistics/surplus", server.OverviewHandler.GetSurplusStatistics)
+
+		apiGroup.GET("/workspace", server.WorkspaceHandler.GetWorkspace)
+		apiGroup.POST("/workspaces/invite", server.WorkspaceHandler.InviteMember)
+		apiGroup.POST("/workspaces/join", server.WorkspaceHandler.JoinWorkspace)
+		apiGroup.POST("/workspaces/decline", server.WorkspaceHandler.DeclineInvite)
+
+		// Save-to-Spend routes
+		if server.SpendHandler != nil {
+			apiGroup.GET("/save-to-spend", server.SpendHandler.GetSaveToSpend)
+			apiGroup.PUT("/save-to-spend/balance", server.SpendHandler.UpdateBalance)
+			apiGroup.POST("/save-to-spend/fixed-costs/:id/paid", server.SpendHandler.MarkFixedCostPaid)
+			apiGroup.POST("/save-to-spend/fixed-costs/:id/pending", server.SpendHandler.MarkFixedCostPending)
+			apiGroup.POST("/save-to-spend/fixed-costs/:id/include", server.SpendHandler.IncludeFixedCost)
+			apiGroup.POST("/save-to-spend/fixed-costs/:id/exclude", server.SpendHandler.ExcludeFixedCost)
+			apiGroup.POST("/save-to-spend/one-time-costs", server.SpendHandler.CreateOneTimeCost)
+			apiGroup.DELETE("/save-to-spend/one-time-costs/:id", server.SpendHandler.DeleteOneTimeCost)
+			apiGroup.POST("/save-to-spend/one-time-costs/:id/paid", server.SpendHandler.MarkOneTimeCostPaid)
+			apiGroup.POST("/save-to-spend/one-time-costs/:id/pending", server.SpendHandler.MarkOneTimeCostPending)
+			apiGroup.GET("/save-to-spend/reconcile", server.SpendHandler.PreviewReconcile)
+			apiGroup.POST("/save-to-spend/reconcile", server.SpendHandler.ApplyReconcile)
+			apiGroup.POST("/save-to-spend/reconcile/confirm", server.SpendHandler.ConfirmMatch)
+		}
+
+		// Transaction ledger routes
+		if server.TransactionHandler != nil {
+			apiGroup.GET("/transactions", server.TransactionHandler.GetTransactions)
+			apiGroup.POST("/transactions/import", server.TransactionHandler.ImportStatement)
+			apiGroup.DELETE("/transactions/:id", server.TransactionHandler.DeleteTransaction)
+			apiGroup.GET("/transactions/recurring", server.RecurringHandler.GetRecurring)
+			apiGroup.POST("/transactions/recurring/accept", server.RecurringHandler.AcceptSuggestion)
+		}
+
+		// Attachment routes
+		if server.AttachmentHandler != nil {
+			apiGroup.GET("/costs/:id/attachments", server.AttachmentHandler.ListAttachments(attachment.OwnerFixedCost))
+			apiGroup.POST("/costs/:id/attachments", server.AttachmentHandler.UploadAttachment(attachment.OwnerFixedCost))
+			apiGroup.GET("/specialcosts/:id/attachments", server.AttachmentHandler.ListAttachments(attachment.OwnerSpecialCost))
+			apiGroup.POST("/specialcosts/:id/attachments", server.AttachmentHandler.UploadAttachment(attachment.OwnerSpecialCost))
+			apiGroup.GET("/save-to-spend/one-time-costs/:id/attachments", server.AttachmentHandler.ListAttachments(attachment.OwnerOneTimeCost))
+			apiGroup.POST("/save-to-spend/one-time-costs/:id/attachments", server.AttachmentHandler.UploadAttachment(attachment.OwnerOneTimeCost))
+			apiGroup.GET("/attachments/:id", server.AttachmentHandler.DownloadAttachment)
+			apiGroup.DELETE("/attachments/:id", server.AttachmentHandler.DeleteAttachment)
+		}
+
+		// Settlement routes
+		if server.SettlementHandler != nil {
+			apiGroup.GET("/settlements", server.SettlementHandler.GetLedger)
+			apiGroup.POST("/settlements/expenses", server.SettlementHandler.RecordExpense)
+			apiGroup.POST("/settlements/settle-up", server.SettlementHandler.SettleUp)
+			apiGroup.DELETE("/settlements/:id", server.SettlementHandler.DeleteEntry)
+		}
+
+		// Trash routes
+		if server.TrashHandler != nil {
+			apiGroup.GET("/trash", server.TrashHandler.GetTrash)
+			apiGroup.POST("/trash/:type/:id/restore", server.TrashHandler.RestoreItem)
+			apiGroup.DELETE("/trash/:type/:id", server.TrashHandler.PurgeItem)
+		}
+
+		// Audit log routes
+		if server.AuditHandler != nil {
+			apiGroup.GET("/audit", server.AuditHandler.GetAuditLog)
+		}
+
+		// Budget routes
+		if server.BudgetHandler != nil {
+			apiGroup.GET("/budgets", server.BudgetHandler.GetBudgets)
+			apiGroup.POST("/budgets", server.BudgetHandler.SaveBudget)
+			apiGroup.DELETE("/budgets/:id", server.BudgetHandler.DeleteBudget)
+			apiGroup.GET("/budgets/status", server.BudgetHandler.GetStatus)
+			apiGroup.GET("/budgets/entries", server.BudgetHandler.GetEntries)
+			apiGroup.POST("/budgets/entries", server.BudgetHandler.CreateEntry)
+			apiGroup.DELETE("/budgets/entries/:id", server.BudgetHandler.DeleteEntry)
+		}
+
+		// Report routes
+		if server.ReportHandler != nil {
+			apiGroup.GET("/reports/plan-vs-actual", server.ReportHandler.GetPlanVsActual)
+		}
+
+		// Notification routes
+		if server.NotificationHandler != nil {
+			apiGroup.GET("/notifications", server.NotificationHandler.GetNotifications)
+			apiGroup.POST("/notifications/:id/read", server.NotificationHandler.MarkRead)
+		}
+
+		// Scenario routes
+		if server.ScenarioHandler != nil {
+			apiGroup.GET("/scenarios", server.ScenarioHandler.GetScenarios)
+			apiGroup.POST("/scenarios", server.ScenarioHandler.SaveScenario)
+			apiGroup.GET("/scenarios/:id", server.ScenarioHandler.GetScenario)
+			apiGroup.DELETE("/scenarios/:id", server.ScenarioHandler.DeleteScenario)
+			apiGroup.POST("/scenarios/:id/changes", server.ScenarioHandler.AddChange)
+			apiGroup.DELETE("/scenarios/:id/changes/:changeId", server.ScenarioHandler.DeleteChange)
+			apiGroup.GET("/scenarios/:id/evaluation", server.ScenarioHandler.GetEvaluation)
+			apiGroup.GET("/scenarios/:id/comparison", server.ScenarioHandler.GetComparison)
+			apiGroup.POST("/scenarios/:id/apply", server.ScenarioHandler.ApplyScenario)
+		}
+	}
+
+	port := getEnv("PORT", "8082")
+	router.Run(":" + port)
+}
@@ -0,0 +1,72 @@
+package account
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kinds of accounts
+const (
+	TypeChecking   = "checking"
+	TypeSavings    = "savings"
+	TypeCreditCard = "creditCard"
+	TypeCash       = "cash"
+	TypeOther      = "other"
+)
+
+var Types = []string{TypeChecking, TypeSavings, TypeCreditCard, TypeCash, TypeOther}
+
+// Account is a bank account, card or cash box of a workspace. Balance is in
+// whole units of Currency; credit cards usually carry a negative balance.
+type Account struct {
+	ID          uint   `gorm:"primaryKey"`
+	WorkspaceID uint   `gorm:"not null;index"`
+	Name        string `gorm:"not null"`
+	Type        string `gorm:"size:20;not null"`
+	Balance     int    `gorm:"not null;default:0"`
+	Currency    string `gorm:"size:3"` // ISO 4217 code, empty for the workspace's base currency
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Validate checks name and type of the account.
+func (a *Account) Validate() error {
+	if strings.TrimSpace(a.Name) == "" {
+		return errors.New("name is required")
+	}
+	if !slices.Contains(Types, a.Type) {
+		return errors.New("type must be one of " + strings.Join(Types, ", "))
+	}
+	return nil
+}
+
+// Sort orders accounts by type, checking accounts first, then by name.
+func Sort(accounts []Account) {
+	sort.SliceStable(accounts, func(i, j int) bool {
+		ti, tj := slices.Index(Types, accounts[i].Type), slices.Index(Types, accounts[j].Type)
+		if ti != tj {
+			return ti < tj
+		}
+		return accounts[i].Name < accounts[j].Name
+	})
+}
+
+// Default returns the account costs without an account are booked to: the
+// oldest checking account, otherwise the oldest account. It returns nil for
+// a workspace without accounts.
+func Default(accounts []Account) *Account {
+	var result *Account
+	for i := range accounts {
+		a := &accounts[i]
+		switch {
+		case result == nil,
+			a.Type == TypeChecking && result.Type != TypeChecking,
+			(a.Type == TypeChecking) == (result.Type == TypeChecking) && a.ID < result.ID:
+			result = a
+		}
+	}
+	return result
+}
@@ -0,0 +1,49 @@
+package account
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	valid := &Account{Name: "Girokonto", Type: TypeChecking}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	for _, a := range []*Account{{Name: " ", Type: TypeChecking}, {Name: "Depot", Type: "depot"}} {
+		if err := a.Validate(); err == nil {
+			t.Errorf("Expected error for %+v", a)
+		}
+	}
+}
+
+func TestDefault(t *testing.T) {
+	if Default(nil) != nil {
+		t.Error("Expected no default account without accounts")
+	}
+
+	accounts := []Account{
+		{ID: 1, Name: "Tagesgeld", Type: TypeSavings},
+		{ID: 3, Name: "Gemeinschaftskonto", Type: TypeChecking},
+		{ID: 2, Name: "Girokonto", Type: TypeChecking},
+	}
+	if a := Default(accounts); a == nil || a.ID != 2 {
+		t.Errorf("Expected the oldest checking account, got %+v", a)
+	}
+
+	if a := Default(accounts[:1]); a == nil || a.ID != 1 {
+		t.Errorf("Expected the only account, got %+v", a)
+	}
+}
+
+func TestSort(t *testing.T) {
+	accounts := []Account{
+		{Name: "Visa", Type: TypeCreditCard},
+		{Name: "Tagesgeld", Type: TypeSavings},
+		{Name: "Girokonto", Type: TypeChecking},
+	}
+
+	Sort(accounts)
+
+	if accounts[0].Name != "Girokonto" || accounts[1].Name != "Tagesgeld" || accounts[2].Name != "Visa" {
+		t.Errorf("Unexpected order %+v", accounts)
+	}
+}
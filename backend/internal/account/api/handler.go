@@ -0,0 +1,175 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/account"
+	"wondee/finance-app-backend/internal/audit"
+	"wondee/finance-app-backend/internal/currency"
+	"wondee/finance-app-backend/internal/storage"
+)
+
+type Handler struct {
+	Repo  storage.Repository
+	Audit *audit.Log
+}
+
+type JsonAccount struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Balance  int    `json:"balance"`
+	Currency string `json:"currency"`
+}
+
+// GetAccounts returns the accounts of the workspace, checking accounts first.
+func (h *Handler) GetAccounts(c *gin.Context) {
+	accounts, err := h.Repo.GetAccounts(h.getWorkspaceID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load accounts"})
+		return
+	}
+
+	result := make([]JsonAccount, 0, len(accounts))
+	for i := range accounts {
+		result = append(result, ToJsonAccount(&accounts[i]))
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// SaveAccount creates an account or, if an id is given, updates it.
+func (h *Handler) SaveAccount(c *gin.Context) {
+	var jsonAccount JsonAccount
+	if err := c.ShouldBindJSON(&jsonAccount); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dbObject, err := ToDBAccount(&jsonAccount)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	dbObject.WorkspaceID = h.getWorkspaceID(c)
+
+	change := audit.Change{
+		WorkspaceID: dbObject.WorkspaceID,
+		Actor:       h.getUserID(c),
+		EntityType:  audit.EntityAccount,
+		Action:      audit.ActionCreate,
+	}
+	if dbObject.ID != 0 {
+		before := h.findAccount(dbObject.WorkspaceID, dbObject.ID)
+		if before == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+			return
+		}
+		change.Action = audit.ActionUpdate
+		change.Before = ToJsonAccount(before)
+	}
+
+	if err := h.Repo.SaveAccount(dbObject); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save account"})
+		return
+	}
+
+	result := ToJsonAccount(dbObject)
+	change.EntityID = dbObject.ID
+	change.After = result
+	h.Audit.Record(change)
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteAccount removes an account. Costs booked to it move to the default
+// account, transfers to it become plain payments.
+func (h *Handler) DeleteAccount(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	workspaceID := h.getWorkspaceID(c)
+	before := h.findAccount(workspaceID, uint(id))
+
+	if err := h.Repo.DeleteAccount(uint(id), workspaceID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+		return
+	}
+
+	if before != nil {
+		h.Audit.Record(audit.Change{
+			WorkspaceID: workspaceID,
+			Actor:       h.getUserID(c),
+			EntityType:  audit.EntityAccount,
+			EntityID:    before.ID,
+			Action:      audit.ActionDelete,
+			Before:      ToJsonAccount(before),
+		})
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func ToDBAccount(jsonAccount *JsonAccount) (*account.Account, error) {
+	code, err := currency.Normalize(jsonAccount.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &account.Account{
+		ID:       jsonAccount.ID,
+		Name:     strings.TrimSpace(jsonAccount.Name),
+		Type:     jsonAccount.Type,
+		Balance:  jsonAccount.Balance,
+		Currency: code,
+	}
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func ToJsonAccount(a *account.Account) JsonAccount {
+	return JsonAccount{
+		ID:       a.ID,
+		Name:     a.Name,
+		Type:     a.Type,
+		Balance:  a.Balance,
+		Currency: a.Currency,
+	}
+}
+
+func (h *Handler) findAccount(workspaceID, id uint) *account.Account {
+	accounts, err := h.Repo.GetAccounts(workspaceID)
+	if err != nil {
+		return nil
+	}
+	for i := range accounts {
+		if accounts[i].ID == id {
+			return &accounts[i]
+		}
+	}
+	return nil
+}
+
+func (h *Handler) getUserID(c *gin.Context) uint {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return 0
+	}
+	return userID.(uint)
+}
+
+func (h *Handler) getWorkspaceID(c *gin.Context) uint {
+	workspaceID, exists := c.Get("workspace_id")
+	if !exists {
+		return 0
+	}
+	return workspaceID.(uint)
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/account"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/storage"
+)
+
+func setupRouter(mockRepo *storage.MockRepository) *gin.Engine {
+	handler := &Handler{Repo: mockRepo}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+
+	r.GET("/accounts", handler.GetAccounts)
+	r.POST("/accounts", handler.SaveAccount)
+	r.DELETE("/accounts/:id", handler.DeleteAccount)
+
+	return r
+}
+
+func sendJSON(r *gin.Engine, method, path string, body map[string]interface{}) *httptest.ResponseRecorder {
+	reqBody, _ := json.Marshal(body)
+	req, _ := http.NewRequest(method, path, bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestSaveAccount_CreatesAndUpdates(t *testing.T) {
+	mockRepo := &storage.MockRepository{}
+	r := setupRouter(mockRepo)
+
+	w := sendJSON(r, http.MethodPost, "/accounts", map[string]interface{}{"name": " Girokonto ", "type": "checking", "balance": 1200, "currency": "eur"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var created JsonAccount
+	json.Unmarshal(w.Body.Bytes(), &created)
+	if created.ID == 0 || created.Name != "Girokonto" || created.Currency != "EUR" {
+		t.Errorf("Unexpected account %+v", created)
+	}
+
+	w = sendJSON(r, http.MethodPost, "/accounts", map[string]interface{}{"id": created.ID, "name": "Girokonto", "type": "checking", "balance": 900})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(mockRepo.Accounts) != 1 || mockRepo.Accounts[0].Balance != 900 {
+		t.Errorf("Expected updated balance, got %+v", mockRepo.Accounts)
+	}
+
+	w = sendJSON(r, http.MethodPost, "/accounts", map[string]interface{}{"id": 42, "name": "Unknown", "type": "checking"})
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for unknown account, got %d", w.Code)
+	}
+}
+
+func TestSaveAccount_Validation(t *testing.T) {
+	r := setupRouter(&storage.MockRepository{})
+
+	tests := []map[string]interface{}{
+		{"name": "", "type": "checking"},
+		{"name": "Depot", "type": "depot"},
+		{"name": "Konto", "type": "checking", "currency": "euro"},
+	}
+	for _, body := range tests {
+		if w := sendJSON(r, http.MethodPost, "/accounts", body); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %v, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestDeleteAccount_UnassignsCosts(t *testing.T) {
+	savingsID := uint(2)
+	mockRepo := &storage.MockRepository{
+		Accounts: []account.Account{
+			{ID: 1, WorkspaceID: 1, Name: "Girokonto", Type: account.TypeChecking},
+			{ID: savingsID, WorkspaceID: 1, Name: "Tagesgeld", Type: account.TypeSavings},
+		},
+		FixedCosts: []cost.FixedCost{
+			{ID: 1, WorkspaceID: 1, Name: "Sparplan", Amount: -100, AccountID: &savingsID},
+		},
+	}
+	r := setupRouter(mockRepo)
+
+	w := sendJSON(r, http.MethodDelete, "/accounts/2", nil)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d", w.Code)
+	}
+	if len(mockRepo.Accounts) != 1 || mockRepo.FixedCosts[0].AccountID != nil {
+		t.Errorf("Expected account removed and cost unassigned, got %+v and %+v", mockRepo.Accounts, mockRepo.FixedCosts[0])
+	}
+
+	if w := sendJSON(r, http.MethodDelete, "/accounts/2", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for deleted account, got %d", w.Code)
+	}
+}
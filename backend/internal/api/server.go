@@ -1,13 +1,43 @@
 package api
 
 import (
+	"os"
+	"strconv"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	account_api "wondee/finance-app-backend/internal/account/api"
+	attachment_api "wondee/finance-app-backend/internal/attachment/api"
+	attachment_repo "wondee/finance-app-backend/internal/attachment/repository"
+	"wondee/finance-app-backend/internal/audit"
+	audit_api "wondee/finance-app-backend/internal/audit/api"
+	audit_repo "wondee/finance-app-backend/internal/audit/repository"
+	budget_api "wondee/finance-app-backend/internal/budget/api"
+	budget_repo "wondee/finance-app-backend/internal/budget/repository"
+	budget_service "wondee/finance-app-backend/internal/budget/service"
 	cost_api "wondee/finance-app-backend/internal/cost/api"
 	cost_repo "wondee/finance-app-backend/internal/cost/repository"
+	currency_api "wondee/finance-app-backend/internal/currency/api"
+	notification_api "wondee/finance-app-backend/internal/notification/api"
+	notification_repo "wondee/finance-app-backend/internal/notification/repository"
 	overview_api "wondee/finance-app-backend/internal/overview/api"
+	"wondee/finance-app-backend/internal/platform/blob"
+	report_api "wondee/finance-app-backend/internal/report/api"
+	report_repo "wondee/finance-app-backend/internal/report/repository"
+	report_service "wondee/finance-app-backend/internal/report/service"
+	scenario_api "wondee/finance-app-backend/internal/scenario/api"
+	scenario_repo "wondee/finance-app-backend/internal/scenario/repository"
+	settlement_api "wondee/finance-app-backend/internal/settlement/api"
+	settlement_repo "wondee/finance-app-backend/internal/settlement/repository"
 	spend_api "wondee/finance-app-backend/internal/spend/api"
 	spend_repo "wondee/finance-app-backend/internal/spend/repository"
+	spend_service "wondee/finance-app-backend/internal/spend/service"
 	"wondee/finance-app-backend/internal/storage"
+	transaction_api "wondee/finance-app-backend/internal/transaction/api"
+	transaction_repo "wondee/finance-app-backend/internal/transaction/repository"
+	"wondee/finance-app-backend/internal/trash"
+	trash_api "wondee/finance-app-backend/internal/trash/api"
+	trash_repo "wondee/finance-app-backend/internal/trash/repository"
 	user_api "wondee/finance-app-backend/internal/user/api"
 	user_service "wondee/finance-app-backend/internal/user/service"
 	wealth_api "wondee/finance-app-backend/internal/wealth/api"
@@ -18,67 +48,204 @@ import (
 
 // Server holds the repository and service dependencies
 type Server struct {
-	Repo               storage.Repository
-	UserService        *user_service.UserService
-	OverviewHandler    *overview_api.Handler
-	FixedCostHandler   *cost_api.FixedCostHandler
-	SpecialCostHandler *cost_api.SpecialCostHandler
-	UserHandler        *user_api.Handler
-	ProfileHandler     *wealth_api.ProfileHandler
-	ForecastHandler    *wealth_api.ForecastHandler
-	WorkspaceHandler   *workspace_api.Handler
-	SpendHandler       *spend_api.Handler
+	Repo                storage.Repository
+	UserService         *user_service.UserService
+	OverviewHandler     *overview_api.Handler
+	FixedCostHandler    *cost_api.FixedCostHandler
+	SpecialCostHandler  *cost_api.SpecialCostHandler
+	CategoryHandler     *cost_api.CategoryHandler
+	CurrencyHandler     *currency_api.Handler
+	ImportHandler       *cost_api.ImportHandler
+	UserHandler         *user_api.Handler
+	ProfileHandler      *wealth_api.ProfileHandler
+	ForecastHandler     *wealth_api.ForecastHandler
+	WorkspaceHandler    *workspace_api.Handler
+	SpendHandler        *spend_api.Handler
+	TransactionHandler  *transaction_api.Handler
+	RecurringHandler    *transaction_api.RecurringHandler
+	AttachmentHandler   *attachment_api.Handler
+	SettlementHandler   *settlement_api.Handler
+	TrashHandler        *trash_api.Handler
+	AuditHandler        *audit_api.Handler
+	AccountHandler      *account_api.Handler
+	BudgetHandler       *budget_api.Handler
+	ReportHandler       *report_api.Handler
+	NotificationHandler *notification_api.Handler
+	ScenarioHandler     *scenario_api.Handler
+}
+
+// Deps are the repositories the server is built from. Handlers whose
+// repository is nil are not created; Repo and CostRepo are required.
+type Deps struct {
+	Repo             storage.Repository
+	CostRepo         cost_repo.Repository
+	SpendRepo        spend_repo.Repository
+	TransactionRepo  transaction_repo.Repository
+	AttachmentRepo   attachment_repo.Repository
+	AttachmentStore  blob.Store
+	SettlementRepo   settlement_repo.Repository
+	TrashRepo        trash_repo.Repository
+	AuditRepo        audit_repo.Repository
+	BudgetRepo       budget_repo.Repository
+	ReportRepo       report_repo.Repository
+	NotificationRepo notification_repo.Repository
+	ScenarioRepo     scenario_repo.Repository
 }
 
 func NewServer(repo storage.Repository) *Server {
+	deps := Deps{Repo: repo, AttachmentStore: blob.NewLocalStore(attachmentDir())}
+
 	// Create cost repository from the underlying DB connection
-	var costRepo cost_repo.Repository
-	var spendRepo spend_repo.Repository
 	if gormRepo, ok := repo.(*storage.GormRepository); ok {
-		costRepo = &cost_repo.PostgresRepository{DB: gormRepo.DB}
-		spendRepo = &spend_repo.PostgresRepository{DB: gormRepo.DB}
+		deps.CostRepo = &cost_repo.PostgresRepository{DB: gormRepo.DB}
+		deps.SpendRepo = &spend_repo.PostgresRepository{DB: gormRepo.DB}
+		deps.TransactionRepo = &transaction_repo.PostgresRepository{DB: gormRepo.DB}
+		deps.AttachmentRepo = &attachment_repo.PostgresRepository{DB: gormRepo.DB}
+		deps.SettlementRepo = &settlement_repo.PostgresRepository{DB: gormRepo.DB}
+		deps.TrashRepo = &trash_repo.PostgresRepository{DB: gormRepo.DB}
+		deps.AuditRepo = &audit_repo.PostgresRepository{DB: gormRepo.DB}
+		deps.BudgetRepo = &budget_repo.PostgresRepository{DB: gormRepo.DB}
+		deps.ReportRepo = &report_repo.PostgresRepository{DB: gormRepo.DB}
+		deps.NotificationRepo = &notification_repo.PostgresRepository{DB: gormRepo.DB}
+		deps.ScenarioRepo = &scenario_repo.PostgresRepository{DB: gormRepo.DB}
 	} else if mockRepo, ok := repo.(cost_repo.Repository); ok {
 		// MockRepository implements cost_repo.Repository
-		costRepo = mockRepo
+		deps.CostRepo = mockRepo
 	}
-	return NewServerWithDeps(repo, costRepo, spendRepo)
+	return NewServerWithDeps(deps)
 }
 
-func NewServerWithDeps(repo storage.Repository, costRepo cost_repo.Repository, spendRepo spend_repo.Repository) *Server {
-	profileService := wealth_service.NewProfileService(repo)
-	forecastService := wealth_service.NewForecastService(repo, costRepo)
+func NewServerWithDeps(deps Deps) *Server {
+	// Audit log; without a repository changes are not recorded
+	var auditLog *audit.Log
+	var auditHandler *audit_api.Handler
+	if deps.AuditRepo != nil {
+		auditLog = audit.NewLog(deps.AuditRepo)
+		auditHandler = audit_api.NewHandler(deps.AuditRepo, deps.Repo)
+	}
+
+	profileService := wealth_service.NewProfileService(deps.Repo)
+	forecastService := wealth_service.NewForecastService(deps.Repo, deps.CostRepo)
 
 	// Workspace services
 	emailService := workspace_service.NewEmailService()
-	workspaceService := workspace_service.NewWorkspaceService(repo)
-	inviteService := workspace_service.NewInviteService(repo, emailService)
-	userService := user_service.NewUserService(costRepo)
+	workspaceService := workspace_service.NewWorkspaceService(deps.Repo)
+	inviteService := workspace_service.NewInviteService(deps.Repo, emailService)
+	userService := user_service.NewUserService(deps.CostRepo)
+
+	// Budget handler; the remaining envelopes are held back in save-to-spend
+	var budgetHandler *budget_api.Handler
+	var envelopes spend_service.EnvelopeSource
+	if deps.BudgetRepo != nil {
+		budgetService := budget_service.NewBudgetService(deps.BudgetRepo, deps.CostRepo, deps.Repo)
+		budgetHandler = budget_api.NewHandler(deps.BudgetRepo, deps.CostRepo, budgetService, auditLog)
+		envelopes = budgetService
+	}
+
+	// Report handler; the plan includes the budgets
+	var reportHandler *report_api.Handler
+	if deps.ReportRepo != nil && deps.BudgetRepo != nil {
+		reportHandler = report_api.NewHandler(report_service.NewReportService(deps.ReportRepo, deps.BudgetRepo, deps.CostRepo, deps.Repo))
+	}
+
+	// Notification handler; saved costs are checked against the low balance
+	// threshold by the overview
+	var notificationHandler *notification_api.Handler
+	if deps.NotificationRepo != nil {
+		notificationHandler = notification_api.NewHandler(deps.NotificationRepo)
+	}
+	overviewHandler := &overview_api.Handler{Repo: deps.Repo, CostRepo: deps.CostRepo, Notifications: deps.NotificationRepo}
+
+	// Scenario handler
+	var scenarioHandler *scenario_api.Handler
+	if deps.ScenarioRepo != nil {
+		scenarioHandler = scenario_api.NewHandler(deps.ScenarioRepo, deps.Repo, deps.CostRepo, auditLog, overviewHandler)
+	}
 
 	// Spend handler
 	var spendHandler *spend_api.Handler
-	if spendRepo != nil {
-		spendHandler = spend_api.NewHandler(spendRepo, costRepo)
+	if deps.SpendRepo != nil {
+		spendHandler = spend_api.NewHandler(deps.SpendRepo, deps.CostRepo, envelopes, auditLog)
+	}
+
+	// Transaction handler
+	var transactionHandler *transaction_api.Handler
+	var recurringHandler *transaction_api.RecurringHandler
+	if deps.TransactionRepo != nil {
+		transactionHandler = transaction_api.NewHandler(deps.TransactionRepo)
+		recurringHandler = transaction_api.NewRecurringHandler(deps.TransactionRepo, deps.CostRepo, auditLog, overviewHandler)
+	}
+
+	// Attachment handler
+	var attachmentHandler *attachment_api.Handler
+	if deps.AttachmentRepo != nil {
+		attachmentHandler = attachment_api.NewHandler(deps.AttachmentRepo, deps.AttachmentStore)
+	}
+
+	// Settlement handler
+	var settlementHandler *settlement_api.Handler
+	if deps.SettlementRepo != nil {
+		settlementHandler = settlement_api.NewHandler(deps.SettlementRepo, deps.Repo, auditLog)
+	}
+
+	// Trash handler
+	var trashHandler *trash_api.Handler
+	if deps.TrashRepo != nil {
+		trashHandler = trash_api.NewHandler(deps.TrashRepo, deps.AttachmentStore, trashRetention(), auditLog, overviewHandler)
 	}
 
 	return &Server{
-		Repo:               repo,
+		Repo:               deps.Repo,
 		UserService:        userService,
-		OverviewHandler:    &overview_api.Handler{Repo: repo, CostRepo: costRepo},
-		FixedCostHandler:   &cost_api.FixedCostHandler{Repo: costRepo},
-		SpecialCostHandler: &cost_api.SpecialCostHandler{Repo: costRepo},
-		UserHandler:        &user_api.Handler{Repo: repo},
-		ProfileHandler:     &wealth_api.ProfileHandler{Service: profileService},
+		OverviewHandler:    overviewHandler,
+		FixedCostHandler:   &cost_api.FixedCostHandler{Repo: deps.CostRepo, Accounts: deps.Repo, Workspaces: deps.Repo, Audit: auditLog, Balance: overviewHandler},
+		SpecialCostHandler: &cost_api.SpecialCostHandler{Repo: deps.CostRepo, Accounts: deps.Repo, Workspaces: deps.Repo, Audit: auditLog, Balance: overviewHandler},
+		CategoryHandler:    &cost_api.CategoryHandler{Repo: deps.CostRepo},
+		CurrencyHandler:    &currency_api.Handler{Repo: deps.Repo},
+		ImportHandler:      &cost_api.ImportHandler{Repo: deps.CostRepo, Audit: auditLog, Balance: overviewHandler},
+		UserHandler:        &user_api.Handler{Repo: deps.Repo, Audit: auditLog},
+		ProfileHandler:     &wealth_api.ProfileHandler{Service: profileService, Audit: auditLog},
 		ForecastHandler:    &wealth_api.ForecastHandler{Service: forecastService},
 		WorkspaceHandler: &workspace_api.Handler{
-			Repo:             repo,
+			Repo:             deps.Repo,
 			WorkspaceService: workspaceService,
 			InviteService:    inviteService,
 			UserService:      userService,
+			Audit:            auditLog,
 		},
-		SpendHandler: spendHandler,
+		SpendHandler:        spendHandler,
+		TransactionHandler:  transactionHandler,
+		RecurringHandler:    recurringHandler,
+		AttachmentHandler:   attachmentHandler,
+		SettlementHandler:   settlementHandler,
+		TrashHandler:        trashHandler,
+		AuditHandler:        auditHandler,
+		AccountHandler:      &account_api.Handler{Repo: deps.Repo, Audit: auditLog},
+		BudgetHandler:       budgetHandler,
+		ReportHandler:       reportHandler,
+		NotificationHandler: notificationHandler,
+		ScenarioHandler:     scenarioHandler,
 	}
 }
 
+// attachmentDir returns the directory uploaded files are stored in, which can
+// be set via ATTACHMENT_DIR.
+func attachmentDir() string {
+	if dir := os.Getenv("ATTACHMENT_DIR"); dir != "" {
+		return dir
+	}
+	return "data/attachments"
+}
+
+// trashRetention returns how long deleted costs are kept, which can be set
+// in days via TRASH_RETENTION_DAYS.
+func trashRetention() time.Duration {
+	if days, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS")); err == nil && days > 0 {
+		return time.Duration(days) * 24 * time.Hour
+	}
+	return trash.DefaultRetention
+}
+
 func (s *Server) getUserID(c *gin.Context) uint {
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -94,4 +261,3 @@ func (s *Server) getWorkspaceID(c *gin.Context) uint {
 	}
 	return workspaceID.(uint)
 }
-
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/attachment"
+	"wondee/finance-app-backend/internal/attachment/repository"
+	"wondee/finance-app-backend/internal/platform/blob"
+)
+
+// sniffLength is the number of bytes http.DetectContentType looks at.
+const sniffLength = 512
+
+// Handler handles HTTP requests for files attached to costs
+type Handler struct {
+	repo  repository.Repository
+	store blob.Store
+}
+
+// NewHandler creates a new Handler instance
+func NewHandler(repo repository.Repository, store blob.Store) *Handler {
+	return &Handler{
+		repo:  repo,
+		store: store,
+	}
+}
+
+// Response types
+
+type AttachmentDTO struct {
+	ID          uint      `json:"id"`
+	OwnerType   string    `json:"ownerType"`
+	OwnerID     uint      `json:"ownerId"`
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func ToAttachmentDTO(a *attachment.Attachment) AttachmentDTO {
+	return AttachmentDTO{
+		ID:          a.ID,
+		OwnerType:   a.OwnerType,
+		OwnerID:     a.OwnerID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+// ListAttachments returns a handler listing the files of the cost with
+// path parameter "id" of the given owner type.
+func (h *Handler) ListAttachments(ownerType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		workspaceID := h.getWorkspaceID(c)
+
+		ownerID, ok := h.findOwner(c, workspaceID, ownerType)
+		if !ok {
+			return
+		}
+
+		attachments, err := h.repo.ListAttachments(workspaceID, ownerType, ownerID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load attachments"})
+			return
+		}
+
+		result := make([]AttachmentDTO, 0, len(attachments))
+		for i := range attachments {
+			result = append(result, ToAttachmentDTO(&attachments[i]))
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// UploadAttachment returns a handler attaching the multipart field "file"
+// to the cost with path parameter "id" of the given owner type. The content
+// type is detected from the file itself.
+func (h *Handler) UploadAttachment(ownerType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		workspaceID := h.getWorkspaceID(c)
+
+		ownerID, ok := h.findOwner(c, workspaceID, ownerType)
+		if !ok {
+			return
+		}
+
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
+			return
+		}
+		if fileHeader.Size > attachment.MaxSize {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": attachment.ErrTooLarge.Error()})
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
+			return
+		}
+		defer file.Close()
+
+		head := make([]byte, sniffLength)
+		n, err := io.ReadFull(file, head)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
+			return
+		}
+		head = head[:n]
+
+		contentType, err := attachment.DetectContentType(head)
+		if err != nil {
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+			return
+		}
+
+		key, err := attachment.NewStorageKey(workspaceID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store file"})
+			return
+		}
+		if err := h.store.Put(key, io.MultiReader(bytes.NewReader(head), file)); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store file"})
+			return
+		}
+
+		a := &attachment.Attachment{
+			WorkspaceID: workspaceID,
+			OwnerType:   ownerType,
+			OwnerID:     ownerID,
+			FileName:    attachment.CleanFileName(fileHeader.Filename),
+			ContentType: contentType,
+			Size:        fileHeader.Size,
+			StorageKey:  key,
+			UploadedBy:  h.getUserID(c),
+		}
+		if err := h.repo.CreateAttachment(a); err != nil {
+			h.store.Delete(key)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save attachment"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, ToAttachmentDTO(a))
+	}
+}
+
+// DownloadAttachment sends the file as download, never for display inline,
+// so that uploaded content cannot run in the app's origin.
+func (h *Handler) DownloadAttachment(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attachment ID"})
+		return
+	}
+
+	a, err := h.repo.GetAttachment(uint(id), workspaceID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Attachment not found"})
+		return
+	}
+
+	content, err := h.store.Get(a.StorageKey)
+	if errors.Is(err, blob.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Attachment file is missing"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, a.Size, a.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": a.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// DeleteAttachment removes the attachment and its file.
+func (h *Handler) DeleteAttachment(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attachment ID"})
+		return
+	}
+
+	a, err := h.repo.GetAttachment(uint(id), workspaceID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Attachment not found"})
+		return
+	}
+
+	if err := h.repo.DeleteAttachment(a.ID, workspaceID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Attachment not found"})
+		return
+	}
+
+	// The record is gone, so a file left behind is only wasted space.
+	h.store.Delete(a.StorageKey)
+
+	c.Status(http.StatusNoContent)
+}
+
+// Helper functions
+
+// findOwner reads the cost ID from the path and checks that the cost
+// belongs to the workspace; otherwise it responds with an error.
+func (h *Handler) findOwner(c *gin.Context, workspaceID uint, ownerType string) (uint, bool) {
+	ownerID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cost ID"})
+		return 0, false
+	}
+
+	exists, err := h.repo.OwnerExists(workspaceID, ownerType, uint(ownerID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load cost"})
+		return 0, false
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Cost not found"})
+		return 0, false
+	}
+
+	return uint(ownerID), true
+}
+
+func (h *Handler) getUserID(c *gin.Context) uint {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return 0
+	}
+	return userID.(uint)
+}
+
+func (h *Handler) getWorkspaceID(c *gin.Context) uint {
+	workspaceID, exists := c.Get("workspace_id")
+	if !exists {
+		return 0
+	}
+	return workspaceID.(uint)
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"wondee/finance-app-backend/internal/attachment"
+	"wondee/finance-app-backend/internal/platform/blob"
+)
+
+// MockAttachmentRepository implements attachment repository.Repository
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) ListAttachments(workspaceID uint, ownerType string, ownerID uint) ([]attachment.Attachment, error) {
+	args := m.Called(workspaceID, ownerType, ownerID)
+	return args.Get(0).([]attachment.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) GetAttachment(id uint, workspaceID uint) (*attachment.Attachment, error) {
+	args := m.Called(id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*attachment.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) CreateAttachment(a *attachment.Attachment) error {
+	args := m.Called(a)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) DeleteAttachment(id uint, workspaceID uint) error {
+	args := m.Called(id, workspaceID)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) OwnerExists(workspaceID uint, ownerType string, ownerID uint) (bool, error) {
+	args := m.Called(workspaceID, ownerType, ownerID)
+	return args.Bool(0), args.Error(1)
+}
+
+func setupTestRouter(handler *Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+	router.GET("/costs/:id/attachments", handler.ListAttachments(attachment.OwnerFixedCost))
+	router.POST("/costs/:id/attachments", handler.UploadAttachment(attachment.OwnerFixedCost))
+	router.GET("/attachments/:id", handler.DownloadAttachment)
+	router.DELETE("/attachments/:id", handler.DeleteAttachment)
+	return router
+}
+
+func upload(router *gin.Engine, path, fileName, content string) *httptest.ResponseRecorder {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", fileName)
+	part.Write([]byte(content))
+	writer.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, path, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestUploadAndDownloadAttachment(t *testing.T) {
+	mockRepo := new(MockAttachmentRepository)
+	store := blob.NewLocalStore(t.TempDir())
+	router := setupTestRouter(NewHandler(mockRepo, store))
+
+	var saved *attachment.Attachment
+	mockRepo.On("OwnerExists", uint(1), attachment.OwnerFixedCost, uint(5)).Return(true, nil)
+	mockRepo.On("CreateAttachment", mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(*attachment.Attachment)
+		saved.ID = 9
+	}).Return(nil)
+
+	content := "%PDF-1.7\nInsurance policy"
+	w := upload(router, "/costs/5/attachments", "Police.pdf", content)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response AttachmentDTO
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, uint(9), response.ID)
+	assert.Equal(t, "fixedCost", response.OwnerType)
+	assert.Equal(t, uint(5), response.OwnerID)
+	assert.Equal(t, "Police.pdf", response.FileName)
+	assert.Equal(t, "application/pdf", response.ContentType)
+	assert.Equal(t, int64(len(content)), response.Size)
+
+	mockRepo.On("GetAttachment", uint(9), uint(1)).Return(saved, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/attachments/9", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, content, w.Body.String())
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=Police.pdf`, w.Header().Get("Content-Disposition"))
+}
+
+func TestUploadAttachment_RejectsUnsupportedType(t *testing.T) {
+	mockRepo := new(MockAttachmentRepository)
+	router := setupTestRouter(NewHandler(mockRepo, blob.NewLocalStore(t.TempDir())))
+
+	mockRepo.On("OwnerExists", uint(1), attachment.OwnerFixedCost, uint(5)).Return(true, nil)
+
+	// The name does not matter, only the content.
+	w := upload(router, "/costs/5/attachments", "receipt.pdf", "<html><script>alert(1)</script></html>")
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	mockRepo.AssertNotCalled(t, "CreateAttachment", mock.Anything)
+}
+
+func TestUploadAttachment_RejectsLargeFiles(t *testing.T) {
+	mockRepo := new(MockAttachmentRepository)
+	router := setupTestRouter(NewHandler(mockRepo, blob.NewLocalStore(t.TempDir())))
+
+	mockRepo.On("OwnerExists", uint(1), attachment.OwnerFixedCost, uint(5)).Return(true, nil)
+
+	w := upload(router, "/costs/5/attachments", "scan.txt", strings.Repeat("x", attachment.MaxSize+1))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestUploadAttachment_CostOfOtherWorkspace(t *testing.T) {
+	mockRepo := new(MockAttachmentRepository)
+	router := setupTestRouter(NewHandler(mockRepo, blob.NewLocalStore(t.TempDir())))
+
+	mockRepo.On("OwnerExists", uint(1), attachment.OwnerFixedCost, uint(6)).Return(false, nil)
+
+	w := upload(router, "/costs/6/attachments", "Police.pdf", "%PDF-1.7\n")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestListAttachments(t *testing.T) {
+	mockRepo := new(MockAttachmentRepository)
+	router := setupTestRouter(NewHandler(mockRepo, blob.NewLocalStore(t.TempDir())))
+
+	mockRepo.On("OwnerExists", uint(1), attachment.OwnerFixedCost, uint(5)).Return(true, nil)
+	mockRepo.On("ListAttachments", uint(1), attachment.OwnerFixedCost, uint(5)).Return([]attachment.Attachment{
+		{ID: 1, OwnerType: attachment.OwnerFixedCost, OwnerID: 5, FileName: "Police.pdf"},
+		{ID: 2, OwnerType: attachment.OwnerFixedCost, OwnerID: 5, FileName: "Kündigung.pdf"},
+	}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/costs/5/attachments", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []AttachmentDTO
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 2)
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	mockRepo := new(MockAttachmentRepository)
+	store := blob.NewLocalStore(t.TempDir())
+	router := setupTestRouter(NewHandler(mockRepo, store))
+
+	assert.NoError(t, store.Put("1/abc", strings.NewReader("%PDF-1.7")))
+	mockRepo.On("GetAttachment", uint(3), uint(1)).Return(&attachment.Attachment{ID: 3, WorkspaceID: 1, StorageKey: "1/abc"}, nil)
+	mockRepo.On("DeleteAttachment", uint(3), uint(1)).Return(nil)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/attachments/3", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	_, err := store.Get("1/abc")
+	assert.ErrorIs(t, err, blob.ErrNotFound)
+}
+
+func TestDownloadAttachment_OtherWorkspace(t *testing.T) {
+	mockRepo := new(MockAttachmentRepository)
+	router := setupTestRouter(NewHandler(mockRepo, blob.NewLocalStore(t.TempDir())))
+
+	mockRepo.On("GetAttachment", uint(4), uint(1)).Return(nil, errors.New("record not found"))
+
+	req, _ := http.NewRequest(http.MethodGet, "/attachments/4", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
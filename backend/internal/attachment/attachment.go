@@ -0,0 +1,96 @@
+package attachment
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Kinds of costs files can be attached to.
+const (
+	OwnerFixedCost   = "fixedCost"
+	OwnerSpecialCost = "specialCost"
+	OwnerOneTimeCost = "oneTimeCost"
+)
+
+// MaxSize limits a single file; scanned contracts rarely exceed a few MB.
+const MaxSize = 10 << 20
+
+// AllowedTypes are the content types accepted for upload: documents and
+// photos of receipts.
+var AllowedTypes = []string{
+	"application/pdf",
+	"image/jpeg",
+	"image/png",
+	"image/gif",
+	"image/webp",
+	"text/plain",
+}
+
+var (
+	ErrTooLarge        = errors.New("file must not be larger than 10 MB")
+	ErrUnsupportedType = errors.New("file must be a PDF, an image or plain text")
+)
+
+// Attachment is a file attached to a fixed, special or one-time cost. The
+// content lives in blob storage under StorageKey.
+type Attachment struct {
+	ID          uint   `gorm:"primaryKey"`
+	WorkspaceID uint   `gorm:"not null;index:idx_attachment_owner,priority:1"`
+	OwnerType   string `gorm:"size:20;not null;index:idx_attachment_owner,priority:2"`
+	OwnerID     uint   `gorm:"not null;index:idx_attachment_owner,priority:3"`
+	FileName    string `gorm:"not null"`
+	ContentType string `gorm:"not null"`
+	Size        int64  `gorm:"not null"`
+	StorageKey  string `gorm:"not null;uniqueIndex"`
+	UploadedBy  uint
+	CreatedAt   time.Time
+}
+
+// TableName specifies the table name for GORM
+func (Attachment) TableName() string {
+	return "attachments"
+}
+
+// DetectContentType determines the type of a file from its first bytes,
+// ignoring what the client claims, and checks it against AllowedTypes.
+func DetectContentType(head []byte) (string, error) {
+	detected := http.DetectContentType(head)
+	contentType, _, err := mime.ParseMediaType(detected)
+	if err != nil || !slices.Contains(AllowedTypes, contentType) {
+		return "", ErrUnsupportedType
+	}
+	return contentType, nil
+}
+
+// CleanFileName strips directories a browser may send along and limits the
+// length; empty names become "attachment".
+func CleanFileName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "." || name == "/" || name == "" {
+		return "attachment"
+	}
+	for len(name) > 255 {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
+
+// NewStorageKey returns a random key below the workspace, so that file
+// names never end up in paths.
+func NewStorageKey(workspaceID uint) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return strconv.FormatUint(uint64(workspaceID), 10) + "/" + hex.EncodeToString(random), nil
+}
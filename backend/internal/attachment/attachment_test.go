@@ -0,0 +1,67 @@
+package attachment
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		head     string
+		expected string
+	}{
+		{"pdf", "%PDF-1.7\n", "application/pdf"},
+		{"png", "\x89PNG\r\n\x1a\n", "image/png"},
+		{"jpeg", "\xff\xd8\xff\xe0", "image/jpeg"},
+		{"text", "Policy number 4711", "text/plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType, err := DetectContentType([]byte(tt.head))
+			if err != nil || contentType != tt.expected {
+				t.Errorf("Expected %s, got %s (%v)", tt.expected, contentType, err)
+			}
+		})
+	}
+}
+
+func TestDetectContentType_RejectsOtherTypes(t *testing.T) {
+	for _, head := range []string{"<html><script>alert(1)</script>", "PK\x03\x04", "MZ\x90\x00"} {
+		if _, err := DetectContentType([]byte(head)); err != ErrUnsupportedType {
+			t.Errorf("Expected %q to be rejected, got %v", head, err)
+		}
+	}
+}
+
+func TestCleanFileName(t *testing.T) {
+	tests := map[string]string{
+		"Vertrag.pdf":                 "Vertrag.pdf",
+		`C:\Users\me\Police 2025.pdf`: "Police 2025.pdf",
+		"../../etc/passwd":            "passwd",
+		"  ":                          "attachment",
+		strings.Repeat("ä", 200):      strings.Repeat("ä", 127),
+	}
+
+	for input, expected := range tests {
+		if got := CleanFileName(input); got != expected {
+			t.Errorf("CleanFileName(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestNewStorageKey(t *testing.T) {
+	first, err := NewStorageKey(7)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second, _ := NewStorageKey(7)
+
+	if !strings.HasPrefix(first, "7/") || len(first) != 34 {
+		t.Errorf("Unexpected key %q", first)
+	}
+	if first == second {
+		t.Error("Expected random keys")
+	}
+}
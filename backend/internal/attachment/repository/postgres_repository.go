@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"fmt"
+
+	"wondee/finance-app-backend/internal/attachment"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/spend"
+
+	"gorm.io/gorm"
+)
+
+func (r *PostgresRepository) ListAttachments(workspaceID uint, ownerType string, ownerID uint) ([]attachment.Attachment, error) {
+	var attachments []attachment.Attachment
+	result := r.DB.Where("workspace_id = ? AND owner_type = ? AND owner_id = ?", workspaceID, ownerType, ownerID).
+		Order("created_at, id").
+		Find(&attachments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return attachments, nil
+}
+
+func (r *PostgresRepository) GetAttachment(id uint, workspaceID uint) (*attachment.Attachment, error) {
+	var a attachment.Attachment
+	result := r.DB.Where("id = ? AND workspace_id = ?", id, workspaceID).First(&a)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &a, nil
+}
+
+func (r *PostgresRepository) CreateAttachment(a *attachment.Attachment) error {
+	return r.DB.Create(a).Error
+}
+
+func (r *PostgresRepository) DeleteAttachment(id uint, workspaceID uint) error {
+	result := r.DB.Where("id = ? AND workspace_id = ?", id, workspaceID).Delete(&attachment.Attachment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) OwnerExists(workspaceID uint, ownerType string, ownerID uint) (bool, error) {
+	var model interface{}
+	switch ownerType {
+	case attachment.OwnerFixedCost:
+		model = &cost.FixedCost{}
+	case attachment.OwnerSpecialCost:
+		model = &cost.SpecialCost{}
+	case attachment.OwnerOneTimeCost:
+		model = &spend.OneTimePendingCost{}
+	default:
+		return false, fmt.Errorf("unknown owner type %q", ownerType)
+	}
+
+	var count int64
+	result := r.DB.Model(model).Where("id = ? AND workspace_id = ?", ownerID, workspaceID).Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"wondee/finance-app-backend/internal/attachment"
+
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for attachment data access
+type Repository interface {
+	ListAttachments(workspaceID uint, ownerType string, ownerID uint) ([]attachment.Attachment, error)
+	GetAttachment(id uint, workspaceID uint) (*attachment.Attachment, error)
+	CreateAttachment(a *attachment.Attachment) error
+	DeleteAttachment(id uint, workspaceID uint) error
+
+	// OwnerExists checks that the cost belongs to the workspace.
+	OwnerExists(workspaceID uint, ownerType string, ownerID uint) (bool, error)
+}
+
+// PostgresRepository implements Repository using GORM
+type PostgresRepository struct {
+	DB *gorm.DB
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/audit"
+	"wondee/finance-app-backend/internal/audit/repository"
+	"wondee/finance-app-backend/internal/storage"
+)
+
+const dateLayout = "2006-01-02"
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+// Handler handles HTTP requests for the audit log of a workspace
+type Handler struct {
+	repo       repository.Repository
+	workspaces storage.WorkspaceRepository
+}
+
+// NewHandler creates a new Handler instance
+func NewHandler(repo repository.Repository, workspaces storage.WorkspaceRepository) *Handler {
+	return &Handler{
+		repo:       repo,
+		workspaces: workspaces,
+	}
+}
+
+// Response types
+
+// EntryDTO is a change in the log. UserName is empty for users who have
+// left the workspace since.
+type EntryDTO struct {
+	ID         uint            `json:"id"`
+	UserID     uint            `json:"userId"`
+	UserName   string          `json:"userName"`
+	EntityType string          `json:"entityType"`
+	EntityID   uint            `json:"entityId"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+type AuditPage struct {
+	Entries  []EntryDTO `json:"entries"`
+	Total    int64      `json:"total"`
+	Page     int        `json:"page"`
+	PageSize int        `json:"pageSize"`
+}
+
+// GetAuditLog lists the changes of the workspace, newest first. The log can
+// be filtered by "userId", "entityType", "entityId", "action" and the dates
+// "from" and "to" (both inclusive) and is split into pages of "pageSize"
+// entries.
+func (h *Handler) GetAuditLog(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	filter, page, pageSize, err := parseFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries, total, err := h.repo.ListEntries(workspaceID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load audit log"})
+		return
+	}
+
+	names := make(map[uint]string)
+	if workspace, err := h.workspaces.GetWorkspaceByID(workspaceID); err == nil {
+		for _, u := range workspace.Users {
+			names[u.ID] = u.Name
+		}
+	}
+
+	result := AuditPage{
+		Entries:  make([]EntryDTO, 0, len(entries)),
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}
+	for _, entry := range entries {
+		result.Entries = append(result.Entries, EntryDTO{
+			ID:         entry.ID,
+			UserID:     entry.UserID,
+			UserName:   names[entry.UserID],
+			EntityType: entry.EntityType,
+			EntityID:   entry.EntityID,
+			Action:     entry.Action,
+			Before:     rawJSON(entry.Before),
+			After:      rawJSON(entry.After),
+			CreatedAt:  entry.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Helper functions
+
+func parseFilter(c *gin.Context) (audit.Filter, int, int, error) {
+	filter := audit.Filter{
+		EntityType: c.Query("entityType"),
+		Action:     c.Query("action"),
+	}
+
+	var err error
+	if filter.UserID, err = parseID(c.Query("userId")); err != nil {
+		return filter, 0, 0, errors.New("Invalid userId")
+	}
+	if filter.EntityID, err = parseID(c.Query("entityId")); err != nil {
+		return filter, 0, 0, errors.New("Invalid entityId")
+	}
+
+	if from := c.Query("from"); from != "" {
+		date, err := time.Parse(dateLayout, from)
+		if err != nil {
+			return filter, 0, 0, errors.New("Invalid from date, expected YYYY-MM-DD")
+		}
+		filter.From = &date
+	}
+	if to := c.Query("to"); to != "" {
+		date, err := time.Parse(dateLayout, to)
+		if err != nil {
+			return filter, 0, 0, errors.New("Invalid to date, expected YYYY-MM-DD")
+		}
+		end := date.AddDate(0, 0, 1)
+		filter.To = &end
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		return filter, 0, 0, errors.New("Invalid page")
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("pageSize", strconv.Itoa(defaultPageSize)))
+	if err != nil || pageSize < 1 || pageSize > maxPageSize {
+		return filter, 0, 0, fmt.Errorf("pageSize must be between 1 and %d", maxPageSize)
+	}
+
+	filter.Offset = (page - 1) * pageSize
+	filter.Limit = pageSize
+	return filter, page, pageSize, nil
+}
+
+func parseID(value string) (uint, error) {
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 64)
+	return uint(id), err
+}
+
+func rawJSON(value string) json.RawMessage {
+	if value == "" {
+		return nil
+	}
+	return json.RawMessage(value)
+}
+
+func (h *Handler) getWorkspaceID(c *gin.Context) uint {
+	workspaceID, exists := c.Get("workspace_id")
+	if !exists {
+		return 0
+	}
+	return workspaceID.(uint)
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"wondee/finance-app-backend/internal/audit"
+	"wondee/finance-app-backend/internal/storage"
+	"wondee/finance-app-backend/internal/user"
+	"wondee/finance-app-backend/internal/workspace"
+)
+
+// MockAuditRepository implements audit repository.Repository
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) CreateEntry(entry *audit.Entry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) ListEntries(workspaceID uint, filter audit.Filter) ([]audit.Entry, int64, error) {
+	args := m.Called(workspaceID, filter)
+	return args.Get(0).([]audit.Entry), args.Get(1).(int64), args.Error(2)
+}
+
+func setupTestRouter(mockRepo *MockAuditRepository) *gin.Engine {
+	workspaces := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{
+			ID:    1,
+			Users: []user.User{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}},
+		}},
+	}
+	handler := NewHandler(mockRepo, workspaces)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+	router.GET("/audit", handler.GetAuditLog)
+	return router
+}
+
+func get(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetAuditLog(t *testing.T) {
+	mockRepo := new(MockAuditRepository)
+	router := setupTestRouter(mockRepo)
+
+	mockRepo.On("ListEntries", uint(1), audit.Filter{Offset: 0, Limit: defaultPageSize}).Return([]audit.Entry{
+		{ID: 3, UserID: 2, EntityType: audit.EntityFixedCost, EntityID: 7, Action: audit.ActionUpdate,
+			Before: `{"amount":-900}`, After: `{"amount":-1000}`},
+		{ID: 2, UserID: 5, EntityType: audit.EntitySpecialCost, EntityID: 4, Action: audit.ActionCreate, After: `{"amount":-50}`},
+	}, int64(2), nil)
+
+	w := get(router, "/audit")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response AuditPage
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(2), response.Total)
+	assert.Equal(t, 1, response.Page)
+	assert.Len(t, response.Entries, 2)
+	assert.Equal(t, "Bob", response.Entries[0].UserName)
+	assert.JSONEq(t, `{"amount":-900}`, string(response.Entries[0].Before))
+	// Users who left the workspace have no name
+	assert.Equal(t, "", response.Entries[1].UserName)
+	assert.Equal(t, "null", string(response.Entries[1].Before))
+}
+
+func TestGetAuditLog_Filter(t *testing.T) {
+	mockRepo := new(MockAuditRepository)
+	router := setupTestRouter(mockRepo)
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("ListEntries", uint(1), audit.Filter{
+		UserID:     2,
+		EntityType: audit.EntityFixedCost,
+		EntityID:   7,
+		Action:     audit.ActionUpdate,
+		From:       &from,
+		To:         &to,
+		Offset:     20,
+		Limit:      10,
+	}).Return([]audit.Entry{}, int64(25), nil)
+
+	w := get(router, "/audit?userId=2&entityType=fixedCost&entityId=7&action=update&from=2025-03-01&to=2025-03-31&page=3&pageSize=10")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetAuditLog_InvalidQuery(t *testing.T) {
+	mockRepo := new(MockAuditRepository)
+	router := setupTestRouter(mockRepo)
+
+	for _, query := range []string{"userId=abc", "from=01.03.2025", "page=0", "pageSize=1000"} {
+		w := get(router, "/audit?"+query)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+	mockRepo.AssertNotCalled(t, "ListEntries", mock.Anything, mock.Anything)
+}
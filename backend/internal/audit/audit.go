@@ -0,0 +1,150 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Kinds of audited entities. Costs are named like the owners of
+// attachments.
+const (
+	EntityFixedCost          = "fixedCost"
+	EntitySpecialCost        = "specialCost"
+	EntityOneTimeCost        = "oneTimeCost"
+	EntityCurrentAmount      = "currentAmount"
+	EntitySaveToSpendBalance = "saveToSpendBalance"
+	EntityWealthProfile      = "wealthProfile"
+	EntityMember             = "member"
+	EntityAccount            = "account"
+	EntityBudget             = "budget"
+	EntityBudgetEntry        = "budgetEntry"
+	EntitySettlementEntry    = "settlementEntry"
+)
+
+// Actions recorded in the log
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+	ActionPurge   = "purge"
+	ActionJoin    = "join"
+	ActionRemove  = "remove"
+)
+
+// Entry is a single change in the audit log. Before and After hold the
+// entity as JSON; Before is empty for created entities, After for deleted
+// ones. Entries are never changed once written.
+type Entry struct {
+	ID          uint      `gorm:"primaryKey"`
+	WorkspaceID uint      `gorm:"not null;index"`
+	UserID      uint      `gorm:"not null;index"`
+	EntityType  string    `gorm:"size:30;not null;index"`
+	EntityID    uint      `gorm:"index"`
+	Action      string    `gorm:"size:20;not null"`
+	Before      string    `gorm:"type:text"`
+	After       string    `gorm:"type:text"`
+	CreatedAt   time.Time `gorm:"index"`
+}
+
+// TableName specifies the table name for GORM
+func (Entry) TableName() string {
+	return "audit_entries"
+}
+
+// Filter narrows down the entries of a workspace; zero values match all.
+// From is inclusive, To exclusive.
+type Filter struct {
+	UserID     uint
+	EntityType string
+	EntityID   uint
+	Action     string
+	From       *time.Time
+	To         *time.Time
+	Offset     int
+	Limit      int
+}
+
+// Change describes a change made by Actor. Before and After are the states
+// of the entity, nil if it did not exist.
+type Change struct {
+	WorkspaceID uint
+	Actor       uint
+	EntityType  string
+	EntityID    uint
+	Action      string
+	Before      interface{}
+	After       interface{}
+}
+
+// Writer appends entries to the audit log.
+type Writer interface {
+	CreateEntry(entry *Entry) error
+}
+
+// Log records changes in the audit log. A nil Log records nothing, so
+// handlers also work without one.
+type Log struct {
+	writer Writer
+}
+
+// NewLog creates a Log writing to the given writer.
+func NewLog(writer Writer) *Log {
+	return &Log{writer: writer}
+}
+
+// Record appends the change to the log. The change itself has already been
+// stored at this point, so failures are only reported.
+func (l *Log) Record(change Change) {
+	if l == nil {
+		return
+	}
+
+	entry, err := NewEntry(change)
+	if err == nil {
+		err = l.writer.CreateEntry(entry)
+	}
+	if err != nil {
+		fmt.Printf("Warning: Failed to write audit entry for %s %d: %v\n", change.EntityType, change.EntityID, err)
+	}
+}
+
+// Enabled reports whether changes are recorded; callers can skip loading
+// the previous state of an entity otherwise.
+func (l *Log) Enabled() bool {
+	return l != nil
+}
+
+// NewEntry converts a change into an entry of the log.
+func NewEntry(change Change) (*Entry, error) {
+	before, err := marshal(change.Before)
+	if err != nil {
+		return nil, err
+	}
+	after, err := marshal(change.After)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Entry{
+		WorkspaceID: change.WorkspaceID,
+		UserID:      change.Actor,
+		EntityType:  change.EntityType,
+		EntityID:    change.EntityID,
+		Action:      change.Action,
+		Before:      before,
+		After:       after,
+	}, nil
+}
+
+func marshal(state interface{}) (string, error) {
+	if state == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
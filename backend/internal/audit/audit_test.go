@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"errors"
+	"testing"
+)
+
+type recordingWriter struct {
+	entries []Entry
+	err     error
+}
+
+func (w *recordingWriter) CreateEntry(entry *Entry) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.entries = append(w.entries, *entry)
+	return nil
+}
+
+func TestRecord(t *testing.T) {
+	writer := &recordingWriter{}
+	log := NewLog(writer)
+
+	log.Record(Change{
+		WorkspaceID: 1,
+		Actor:       2,
+		EntityType:  EntityFixedCost,
+		EntityID:    7,
+		Action:      ActionUpdate,
+		Before:      map[string]int{"amount": -900},
+		After:       map[string]int{"amount": -1000},
+	})
+
+	if len(writer.entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(writer.entries))
+	}
+	entry := writer.entries[0]
+	if entry.WorkspaceID != 1 || entry.UserID != 2 || entry.EntityID != 7 || entry.Action != ActionUpdate {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	if entry.Before != `{"amount":-900}` || entry.After != `{"amount":-1000}` {
+		t.Errorf("Expected states as JSON, got %q and %q", entry.Before, entry.After)
+	}
+
+	log.Record(Change{WorkspaceID: 1, Actor: 2, EntityType: EntityFixedCost, EntityID: 7, Action: ActionDelete, Before: map[string]int{"amount": -1000}})
+	if writer.entries[1].After != "" {
+		t.Errorf("Expected no state after deletion, got %q", writer.entries[1].After)
+	}
+}
+
+func TestRecordWithoutLog(t *testing.T) {
+	var log *Log
+
+	if log.Enabled() {
+		t.Error("Expected a nil log to be disabled")
+	}
+	// Must not panic
+	log.Record(Change{EntityType: EntityMember, Action: ActionJoin})
+
+	failing := NewLog(&recordingWriter{err: errors.New("database down")})
+	failing.Record(Change{EntityType: EntityMember, Action: ActionJoin})
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"wondee/finance-app-backend/internal/audit"
+
+	"gorm.io/gorm"
+)
+
+func (r *PostgresRepository) CreateEntry(entry *audit.Entry) error {
+	return r.DB.Create(entry).Error
+}
+
+func (r *PostgresRepository) ListEntries(workspaceID uint, filter audit.Filter) ([]audit.Entry, int64, error) {
+	query := r.DB.Model(&audit.Entry{}).Where("workspace_id = ?", workspaceID)
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	// As a session, the query can be used for counting and listing
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entries []audit.Entry
+	if err := query.Order("created_at DESC, id DESC").
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"wondee/finance-app-backend/internal/audit"
+
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for audit log data access. The log is
+// append-only, so there is no way to change or delete entries.
+type Repository interface {
+	CreateEntry(entry *audit.Entry) error
+	// ListEntries returns a page of the matching entries, newest first,
+	// and the total number of matches.
+	ListEntries(workspaceID uint, filter audit.Filter) ([]audit.Entry, int64, error)
+}
+
+// PostgresRepository implements Repository using GORM
+type PostgresRepository struct {
+	DB *gorm.DB
+}
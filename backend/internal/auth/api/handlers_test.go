@@ -8,7 +8,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"wondee/finance-app-backend/internal/account"
 	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/currency"
 	"wondee/finance-app-backend/internal/user"
 	"wondee/finance-app-backend/internal/wealth"
 	"wondee/finance-app-backend/internal/workspace"
@@ -48,12 +50,12 @@ func (m *MockRepository) Update(user *user.User) error {
 // Repository interface methods (placeholders)
 func (m *MockRepository) LoadFixedCosts(workspaceID uint) *[]cost.FixedCost        { return nil }
 func (m *MockRepository) LoadFixedCostsByUser(userID uint) *[]cost.FixedCost       { return nil }
-func (m *MockRepository) SaveFixedObject(c *cost.FixedCost)                        {}
-func (m *MockRepository) DeleteFixedCost(id int, workspaceID uint)                 {}
+func (m *MockRepository) SaveFixedObject(c *cost.FixedCost) error                  { return nil }
+func (m *MockRepository) DeleteFixedCost(id int, workspaceID uint) error           { return nil }
 func (m *MockRepository) LoadSpecialCosts(workspaceID uint) *[]cost.SpecialCost    { return nil }
 func (m *MockRepository) LoadSpecialCostsByUser(userID uint) *[]cost.SpecialCost   { return nil }
-func (m *MockRepository) SaveSpecialCost(c *cost.SpecialCost)                      {}
-func (m *MockRepository) DeleteSpecialCost(id int, workspaceID uint)               {}
+func (m *MockRepository) SaveSpecialCost(c *cost.SpecialCost) error                { return nil }
+func (m *MockRepository) DeleteSpecialCost(id int, workspaceID uint) error         { return nil }
 func (m *MockRepository) GetUser() (*user.User, error)                             { return nil, nil }
 func (m *MockRepository) UpdateUserCurrentAmount(amount int) error                   { return nil }
 func (m *MockRepository) Delete(id uint) error                                       { return nil }
@@ -68,6 +70,22 @@ func (m *MockRepository) CreateWorkspace(ws *workspace.Workspace) error
 func (m *MockRepository) GetWorkspaceByID(id uint) (*workspace.Workspace, error)         { return nil, nil }
 func (m *MockRepository) UpdateWorkspace(ws *workspace.Workspace) error                  { return nil }
 func (m *MockRepository) UpdateWorkspaceCurrentAmount(workspaceID uint, amount int) error { return nil }
+func (m *MockRepository) UpdateWorkspaceBaseCurrency(workspaceID uint, code string) error { return nil }
+func (m *MockRepository) UpdateWorkspaceLowBalanceThreshold(workspaceID uint, threshold int) error {
+	return nil
+}
+
+// Exchange rate methods
+func (m *MockRepository) GetExchangeRates(workspaceID uint) ([]currency.ExchangeRate, error) {
+	return nil, nil
+}
+func (m *MockRepository) SaveExchangeRate(rate *currency.ExchangeRate) error { return nil }
+func (m *MockRepository) DeleteExchangeRate(id uint, workspaceID uint) error { return nil }
+
+// Account methods
+func (m *MockRepository) GetAccounts(workspaceID uint) ([]account.Account, error) { return nil, nil }
+func (m *MockRepository) SaveAccount(a *account.Account) error                    { return nil }
+func (m *MockRepository) DeleteAccount(id uint, workspaceID uint) error           { return nil }
 
 // Invite methods
 func (m *MockRepository) CreateInvite(invite *workspace.Invite) error             { return nil }
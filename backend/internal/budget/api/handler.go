@@ -0,0 +1,477 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"wondee/finance-app-backend/internal/audit"
+	"wondee/finance-app-backend/internal/budget"
+	"wondee/finance-app-backend/internal/budget/repository"
+	"wondee/finance-app-backend/internal/budget/service"
+	"wondee/finance-app-backend/internal/cost"
+	cost_repo "wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/currency"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+const (
+	dateLayout  = "2006-01-02"
+	monthLayout = "2006-01"
+)
+
+// Handler handles HTTP requests for category budgets and the spending
+// booked against them
+type Handler struct {
+	repo     repository.Repository
+	costRepo cost_repo.Repository
+	service  *service.BudgetService
+	audit    *audit.Log
+}
+
+// NewHandler creates a new Handler instance; changes are recorded in
+// auditLog, which may be nil.
+func NewHandler(repo repository.Repository, costRepo cost_repo.Repository, budgetService *service.BudgetService, auditLog *audit.Log) *Handler {
+	return &Handler{
+		repo:     repo,
+		costRepo: costRepo,
+		service:  budgetService,
+		audit:    auditLog,
+	}
+}
+
+// Request and response types
+
+type BudgetDTO struct {
+	ID         uint             `json:"id"`
+	CategoryID uint             `json:"categoryId"`
+	Amount     int              `json:"amount"`
+	ValidFrom  *types.YearMonth `json:"validFrom"`
+	Rollover   string           `json:"rollover"`
+}
+
+// EntryRequest books spending against an envelope. With a transactionId,
+// amount, currency, date and note default to those of the transaction.
+type EntryRequest struct {
+	CategoryID    uint   `json:"categoryId" binding:"required"`
+	Amount        int    `json:"amount"`
+	Currency      string `json:"currency"`
+	Date          string `json:"date"`
+	Note          string `json:"note"`
+	TransactionID *uint  `json:"transactionId"`
+}
+
+type EntryDTO struct {
+	ID            uint   `json:"id"`
+	CategoryID    uint   `json:"categoryId"`
+	UserID        uint   `json:"userId"`
+	Date          string `json:"date"`
+	Amount        int    `json:"amount"`
+	Currency      string `json:"currency"`
+	Note          string `json:"note"`
+	TransactionID *uint  `json:"transactionId,omitempty"`
+}
+
+// StatusResponse reports the envelopes of a month in the base currency.
+// Remaining sums what is left in envelopes that are not overspent; this is
+// the amount held back from save-to-spend.
+type StatusResponse struct {
+	Month        types.YearMonth `json:"month"`
+	Currency     string          `json:"currency"`
+	MissingRates []string        `json:"missingRates,omitempty"`
+	Envelopes    []EnvelopeDTO   `json:"envelopes"`
+	Budgeted     int             `json:"budgeted"`
+	Spent        int             `json:"spent"`
+	Remaining    int             `json:"remaining"`
+}
+
+type EnvelopeDTO struct {
+	CategoryID  uint   `json:"categoryId"`
+	Name        string `json:"name"`
+	Rollover    string `json:"rollover"`
+	Budgeted    int    `json:"budgeted"`
+	CarriedOver int    `json:"carriedOver"`
+	Spent       int    `json:"spent"`
+	Remaining   int    `json:"remaining"`
+}
+
+// GetBudgets returns all budgets of the workspace, including past ones.
+func (h *Handler) GetBudgets(c *gin.Context) {
+	budgets, err := h.repo.GetBudgets(h.getWorkspaceID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load budgets"})
+		return
+	}
+
+	result := make([]BudgetDTO, 0, len(budgets))
+	for i := range budgets {
+		result = append(result, ToBudgetDTO(&budgets[i]))
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// SaveBudget sets the envelope of a category from the given month onwards.
+// An existing budget of the same category and month is replaced.
+func (h *Handler) SaveBudget(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	var req BudgetDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.ValidFrom == nil {
+		req.ValidFrom = types.CurrentYearMonth()
+	}
+	if req.Rollover == "" {
+		req.Rollover = budget.RolloverNone
+	}
+
+	b := &budget.Budget{
+		WorkspaceID: workspaceID,
+		CategoryID:  req.CategoryID,
+		Amount:      req.Amount,
+		ValidFrom:   *req.ValidFrom,
+		Rollover:    req.Rollover,
+	}
+	if err := b.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !cost.LoadCategoryTree(h.costRepo, workspaceID).Contains(b.CategoryID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "category not found"})
+		return
+	}
+
+	change := audit.Change{
+		WorkspaceID: workspaceID,
+		Actor:       h.getUserID(c),
+		EntityType:  audit.EntityBudget,
+		Action:      audit.ActionCreate,
+	}
+	if h.audit.Enabled() {
+		if existing := h.findBudget(workspaceID, b.CategoryID, b.ValidFrom); existing != nil {
+			change.Action = audit.ActionUpdate
+			change.Before = ToBudgetDTO(existing)
+		}
+	}
+
+	if err := h.repo.SaveBudget(b); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save budget"})
+		return
+	}
+
+	result := ToBudgetDTO(b)
+	change.EntityID = b.ID
+	change.After = result
+	h.audit.Record(change)
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) DeleteBudget(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid budget ID"})
+		return
+	}
+
+	before, err := h.repo.GetBudget(uint(id), workspaceID)
+	if err == nil {
+		err = h.repo.DeleteBudget(uint(id), workspaceID)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Budget not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete budget"})
+		return
+	}
+
+	h.audit.Record(audit.Change{
+		WorkspaceID: workspaceID,
+		Actor:       h.getUserID(c),
+		EntityType:  audit.EntityBudget,
+		EntityID:    before.ID,
+		Action:      audit.ActionDelete,
+		Before:      ToBudgetDTO(before),
+	})
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetEntries returns the spending booked in a month, given as
+// ?month=YYYY-MM and defaulting to the current one.
+func (h *Handler) GetEntries(c *gin.Context) {
+	month, err := parseMonth(c.Query("month"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "month must be formatted as YYYY-MM"})
+		return
+	}
+
+	from := time.Date(month.Year, time.Month(month.Month), 1, 0, 0, 0, 0, time.UTC)
+	entries, err := h.repo.ListEntries(h.getWorkspaceID(c), from, from.AddDate(0, 1, -1))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load entries"})
+		return
+	}
+
+	result := make([]EntryDTO, 0, len(entries))
+	for i := range entries {
+		result = append(result, ToEntryDTO(&entries[i]))
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// CreateEntry books spending against the envelope of a category, either
+// entered by hand or taken over from an imported transaction. A transaction
+// can only be booked once.
+func (h *Handler) CreateEntry(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	var req EntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !cost.LoadCategoryTree(h.costRepo, workspaceID).Contains(req.CategoryID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "category not found"})
+		return
+	}
+
+	entry := &budget.Entry{
+		WorkspaceID: workspaceID,
+		CategoryID:  req.CategoryID,
+		UserID:      h.getUserID(c),
+		Note:        strings.TrimSpace(req.Note),
+	}
+
+	if req.TransactionID != nil {
+		t, err := h.repo.GetTransaction(*req.TransactionID, workspaceID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load transaction"})
+			return
+		}
+
+		booked, err := h.repo.IsTransactionBooked(workspaceID, t.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load entries"})
+			return
+		}
+		if booked {
+			c.JSON(http.StatusConflict, gin.H{"error": "Transaction already booked"})
+			return
+		}
+
+		entry.TransactionID = &t.ID
+		entry.Amount = t.Amount()
+		entry.Currency = t.Currency
+		entry.Date = t.BookingDate
+		if entry.Note == "" {
+			entry.Note = t.Counterparty
+		}
+	} else {
+		if req.Amount == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "amount is required"})
+			return
+		}
+
+		code, err := currency.Normalize(req.Currency)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		date, err := parseDate(req.Date)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "date must be formatted as YYYY-MM-DD"})
+			return
+		}
+
+		entry.Amount = req.Amount
+		entry.Currency = code
+		entry.Date = date
+	}
+
+	if err := h.repo.CreateEntry(entry); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save entry"})
+		return
+	}
+
+	result := ToEntryDTO(entry)
+	h.audit.Record(audit.Change{
+		WorkspaceID: workspaceID,
+		Actor:       entry.UserID,
+		EntityType:  audit.EntityBudgetEntry,
+		EntityID:    entry.ID,
+		Action:      audit.ActionCreate,
+		After:       result,
+	})
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func (h *Handler) DeleteEntry(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entry ID"})
+		return
+	}
+
+	before, err := h.repo.GetEntry(uint(id), workspaceID)
+	if err == nil {
+		err = h.repo.DeleteEntry(uint(id), workspaceID)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Entry not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete entry"})
+		return
+	}
+
+	h.audit.Record(audit.Change{
+		WorkspaceID: workspaceID,
+		Actor:       h.getUserID(c),
+		EntityType:  audit.EntityBudgetEntry,
+		EntityID:    before.ID,
+		Action:      audit.ActionDelete,
+		Before:      ToEntryDTO(before),
+	})
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetStatus compares the envelopes of a month, given as ?month=YYYY-MM and
+// defaulting to the current one, with the spending booked against them.
+func (h *Handler) GetStatus(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	month, err := parseMonth(c.Query("month"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "month must be formatted as YYYY-MM"})
+		return
+	}
+
+	statuses, converter, err := h.service.Status(workspaceID, *month)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate budget status"})
+		return
+	}
+
+	tree := cost.LoadCategoryTree(h.costRepo, workspaceID)
+	response := StatusResponse{
+		Month:        *month,
+		Currency:     converter.Base(),
+		MissingRates: converter.Missing(),
+		Envelopes:    make([]EnvelopeDTO, 0, len(statuses)),
+		Remaining:    budget.RemainingTotal(statuses),
+	}
+	for _, status := range statuses {
+		category, _ := tree.Get(status.CategoryID)
+		response.Envelopes = append(response.Envelopes, EnvelopeDTO{
+			CategoryID:  status.CategoryID,
+			Name:        category.Name,
+			Rollover:    status.Rollover,
+			Budgeted:    status.Budgeted,
+			CarriedOver: status.CarriedOver,
+			Spent:       status.Spent,
+			Remaining:   status.Remaining,
+		})
+		response.Budgeted += status.Budgeted
+		response.Spent += status.Spent
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func ToBudgetDTO(b *budget.Budget) BudgetDTO {
+	validFrom := b.ValidFrom
+	return BudgetDTO{
+		ID:         b.ID,
+		CategoryID: b.CategoryID,
+		Amount:     b.Amount,
+		ValidFrom:  &validFrom,
+		Rollover:   b.Rollover,
+	}
+}
+
+func ToEntryDTO(e *budget.Entry) EntryDTO {
+	return EntryDTO{
+		ID:            e.ID,
+		CategoryID:    e.CategoryID,
+		UserID:        e.UserID,
+		Date:          e.Date.Format(dateLayout),
+		Amount:        e.Amount,
+		Currency:      e.Currency,
+		Note:          e.Note,
+		TransactionID: e.TransactionID,
+	}
+}
+
+// Helper functions
+
+// findBudget returns the budget a save for the category and month replaces.
+func (h *Handler) findBudget(workspaceID, categoryID uint, validFrom types.YearMonth) *budget.Budget {
+	budgets, err := h.repo.GetBudgets(workspaceID)
+	if err != nil {
+		return nil
+	}
+	for i := range budgets {
+		if budgets[i].CategoryID == categoryID && budgets[i].ValidFrom == validFrom {
+			return &budgets[i]
+		}
+	}
+	return nil
+}
+
+func parseMonth(value string) (*types.YearMonth, error) {
+	if value == "" {
+		return types.CurrentYearMonth(), nil
+	}
+	t, err := time.Parse(monthLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	return types.New(t.Year(), int(t.Month()))
+}
+
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Parse(dateLayout, value)
+}
+
+func (h *Handler) getUserID(c *gin.Context) uint {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return 0
+	}
+	return userID.(uint)
+}
+
+func (h *Handler) getWorkspaceID(c *gin.Context) uint {
+	workspaceID, exists := c.Get("workspace_id")
+	if !exists {
+		return 0
+	}
+	return workspaceID.(uint)
+}
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"wondee/finance-app-backend/internal/budget"
+	"wondee/finance-app-backend/internal/budget/service"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/storage"
+	"wondee/finance-app-backend/internal/transaction"
+	"wondee/finance-app-backend/internal/workspace"
+)
+
+// MockBudgetRepository implements budget repository.Repository
+type MockBudgetRepository struct {
+	mock.Mock
+}
+
+func (m *MockBudgetRepository) GetBudgets(workspaceID uint) ([]budget.Budget, error) {
+	args := m.Called(workspaceID)
+	return args.Get(0).([]budget.Budget), args.Error(1)
+}
+
+func (m *MockBudgetRepository) GetBudget(id uint, workspaceID uint) (*budget.Budget, error) {
+	args := m.Called(id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*budget.Budget), args.Error(1)
+}
+
+func (m *MockBudgetRepository) SaveBudget(b *budget.Budget) error {
+	args := m.Called(b)
+	return args.Error(0)
+}
+
+func (m *MockBudgetRepository) DeleteBudget(id uint, workspaceID uint) error {
+	args := m.Called(id, workspaceID)
+	return args.Error(0)
+}
+
+func (m *MockBudgetRepository) ListEntries(workspaceID uint, from, to time.Time) ([]budget.Entry, error) {
+	args := m.Called(workspaceID, from, to)
+	return args.Get(0).([]budget.Entry), args.Error(1)
+}
+
+func (m *MockBudgetRepository) GetEntry(id uint, workspaceID uint) (*budget.Entry, error) {
+	args := m.Called(id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*budget.Entry), args.Error(1)
+}
+
+func (m *MockBudgetRepository) CreateEntry(entry *budget.Entry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockBudgetRepository) DeleteEntry(id uint, workspaceID uint) error {
+	args := m.Called(id, workspaceID)
+	return args.Error(0)
+}
+
+func (m *MockBudgetRepository) GetTransaction(id uint, workspaceID uint) (*transaction.Transaction, error) {
+	args := m.Called(id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockBudgetRepository) IsTransactionBooked(workspaceID uint, transactionID uint) (bool, error) {
+	args := m.Called(workspaceID, transactionID)
+	return args.Bool(0), args.Error(1)
+}
+
+func setupTestRouter(mockRepo *MockBudgetRepository) *gin.Engine {
+	repo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: 1, BaseCurrency: "EUR"}},
+		Categories: []cost.Category{
+			{ID: 1, WorkspaceID: 1, Name: "Groceries"},
+			{ID: 2, WorkspaceID: 1, Name: "Leisure"},
+		},
+	}
+	handler := NewHandler(mockRepo, repo, service.NewBudgetService(mockRepo, repo, repo), nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+	router.GET("/budgets", handler.GetBudgets)
+	router.POST("/budgets", handler.SaveBudget)
+	router.GET("/budgets/status", handler.GetStatus)
+	router.POST("/budgets/entries", handler.CreateEntry)
+	return router
+}
+
+func postJSON(router *gin.Engine, path string, body interface{}) *httptest.ResponseRecorder {
+	data, _ := json.Marshal(body)
+	req, _ := http.NewRequest(http.MethodPost, path, bytes.NewBuffer(data))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSaveBudget(t *testing.T) {
+	mockRepo := new(MockBudgetRepository)
+	router := setupTestRouter(mockRepo)
+
+	mockRepo.On("SaveBudget", mock.MatchedBy(func(b *budget.Budget) bool {
+		return b.WorkspaceID == 1 && b.CategoryID == 1 && b.Amount == 400 &&
+			b.ValidFrom == types.YearMonth{Year: 2025, Month: 3} && b.Rollover == budget.RolloverSurplus
+	})).Return(nil)
+
+	w := postJSON(router, "/budgets", map[string]interface{}{
+		"categoryId": 1,
+		"amount":     400,
+		"validFrom":  map[string]int{"year": 2025, "month": 3},
+		"rollover":   "surplus",
+	})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSaveBudget_UnknownCategory(t *testing.T) {
+	mockRepo := new(MockBudgetRepository)
+	router := setupTestRouter(mockRepo)
+
+	w := postJSON(router, "/budgets", map[string]interface{}{"categoryId": 9, "amount": 100})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockRepo.AssertNotCalled(t, "SaveBudget", mock.Anything)
+}
+
+func TestCreateEntry_FromTransaction(t *testing.T) {
+	mockRepo := new(MockBudgetRepository)
+	router := setupTestRouter(mockRepo)
+
+	bookingDate := time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("GetTransaction", uint(17), uint(1)).Return(&transaction.Transaction{
+		ID: 17, WorkspaceID: 1, BookingDate: bookingDate, AmountCents: -6249, Counterparty: "Wochenmarkt",
+	}, nil)
+	mockRepo.On("IsTransactionBooked", uint(1), uint(17)).Return(false, nil)
+	mockRepo.On("CreateEntry", mock.MatchedBy(func(e *budget.Entry) bool {
+		return e.CategoryID == 1 && e.UserID == 1 && e.Amount == -62 && e.Date.Equal(bookingDate) &&
+			e.Note == "Wochenmarkt" && e.TransactionID != nil && *e.TransactionID == 17
+	})).Return(nil)
+
+	w := postJSON(router, "/budgets/entries", map[string]interface{}{"categoryId": 1, "transactionId": 17})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response EntryDTO
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "2025-03-08", response.Date)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateEntry_TransactionAlreadyBooked(t *testing.T) {
+	mockRepo := new(MockBudgetRepository)
+	router := setupTestRouter(mockRepo)
+
+	mockRepo.On("GetTransaction", uint(17), uint(1)).Return(&transaction.Transaction{ID: 17, WorkspaceID: 1}, nil)
+	mockRepo.On("IsTransactionBooked", uint(1), uint(17)).Return(true, nil)
+
+	w := postJSON(router, "/budgets/entries", map[string]interface{}{"categoryId": 1, "transactionId": 17})
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockRepo.AssertNotCalled(t, "CreateEntry", mock.Anything)
+}
+
+func TestCreateEntry_ManualRequiresDate(t *testing.T) {
+	mockRepo := new(MockBudgetRepository)
+	router := setupTestRouter(mockRepo)
+
+	w := postJSON(router, "/budgets/entries", map[string]interface{}{"categoryId": 1, "amount": -20, "date": "08.03.2025"})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockRepo.AssertNotCalled(t, "CreateEntry", mock.Anything)
+}
+
+func TestGetStatus(t *testing.T) {
+	mockRepo := new(MockBudgetRepository)
+	router := setupTestRouter(mockRepo)
+
+	mockRepo.On("GetBudgets", uint(1)).Return([]budget.Budget{
+		{ID: 1, WorkspaceID: 1, CategoryID: 1, Amount: 400, ValidFrom: types.YearMonth{Year: 2025, Month: 2}, Rollover: budget.RolloverSurplus},
+		{ID: 2, WorkspaceID: 1, CategoryID: 2, Amount: 100, ValidFrom: types.YearMonth{Year: 2025, Month: 3}, Rollover: budget.RolloverNone},
+	}, nil)
+	// Entries are loaded from the first envelope on for the rollover
+	mockRepo.On("ListEntries", uint(1),
+		time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+	).Return([]budget.Entry{
+		{CategoryID: 1, Date: time.Date(2025, 2, 12, 0, 0, 0, 0, time.UTC), Amount: -300},
+		{CategoryID: 1, Date: time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC), Amount: -150},
+		{CategoryID: 2, Date: time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC), Amount: -130},
+	}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/budgets/status?month=2025-03", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response StatusResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "EUR", response.Currency)
+	assert.Equal(t, []EnvelopeDTO{
+		{CategoryID: 1, Name: "Groceries", Rollover: "surplus", Budgeted: 400, CarriedOver: 100, Spent: 150, Remaining: 350},
+		{CategoryID: 2, Name: "Leisure", Rollover: "none", Budgeted: 100, Spent: 130, Remaining: -30},
+	}, response.Envelopes)
+	assert.Equal(t, 500, response.Budgeted)
+	assert.Equal(t, 280, response.Spent)
+	// The overspent leisure envelope does not reduce what is held back
+	assert.Equal(t, 350, response.Remaining)
+}
+
+func TestGetStatus_InvalidMonth(t *testing.T) {
+	mockRepo := new(MockBudgetRepository)
+	router := setupTestRouter(mockRepo)
+
+	req, _ := http.NewRequest(http.MethodGet, "/budgets/status?month=March", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
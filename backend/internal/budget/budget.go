@@ -0,0 +1,251 @@
+package budget
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"time"
+
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/currency"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+// Rollover options: what happens to the rest of an envelope at the end of
+// a month.
+const (
+	RolloverNone    = "none"    // the rest expires
+	RolloverSurplus = "surplus" // unspent money is carried over, overspending is not
+	RolloverAll     = "all"     // unspent money and overspending are carried over
+)
+
+var Rollovers = []string{RolloverNone, RolloverSurplus, RolloverAll}
+
+// Budget is the monthly envelope of a category, starting with ValidFrom. A
+// budget stays valid until the next budget of the same category; an amount
+// of zero ends the envelope. Amounts are in the base currency.
+type Budget struct {
+	ID          uint            `gorm:"primaryKey"`
+	WorkspaceID uint            `gorm:"not null;uniqueIndex:idx_budget_month,priority:1"`
+	CategoryID  uint            `gorm:"not null;uniqueIndex:idx_budget_month,priority:2"`
+	Amount      int             `gorm:"not null"`
+	ValidFrom   types.YearMonth `gorm:"type:string;not null;uniqueIndex:idx_budget_month,priority:3"`
+	Rollover    string          `gorm:"size:10;not null;default:none"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Validate checks amount, month and rollover of the budget.
+func (b *Budget) Validate() error {
+	if b.CategoryID == 0 {
+		return errors.New("categoryId is required")
+	}
+	if b.Amount < 0 {
+		return errors.New("amount must not be negative")
+	}
+	if _, err := types.New(b.ValidFrom.Year, b.ValidFrom.Month); err != nil {
+		return err
+	}
+	if !slices.Contains(Rollovers, b.Rollover) {
+		return errors.New("rollover must be none, surplus or all")
+	}
+	return nil
+}
+
+// Entry is spending booked against the envelope of a category, entered by
+// hand or taken over from an imported bank transaction. Like costs, spending
+// is negative and refunds are positive.
+type Entry struct {
+	ID            uint      `gorm:"primaryKey"`
+	WorkspaceID   uint      `gorm:"not null;index:idx_budget_entry_ws_date,priority:1"`
+	CategoryID    uint      `gorm:"not null;index"`
+	UserID        uint      `gorm:"not null"`
+	Date          time.Time `gorm:"type:date;not null;index:idx_budget_entry_ws_date,priority:2"`
+	Amount        int       `gorm:"not null"`
+	Currency      string    `gorm:"size:3"` // ISO 4217 code, empty for the workspace's base currency
+	Note          string
+	TransactionID *uint `gorm:"index"`
+	CreatedAt     time.Time
+}
+
+// TableName specifies the table name for GORM
+func (Entry) TableName() string {
+	return "budget_entries"
+}
+
+// Month returns the month the entry counts for.
+func (e *Entry) Month() types.YearMonth {
+	return types.YearMonth{Year: e.Date.Year(), Month: int(e.Date.Month())}
+}
+
+// Status is the state of the envelope of a category in a month, in the base
+// currency. Remaining is negative if the envelope is overspent.
+type Status struct {
+	CategoryID  uint
+	Rollover    string
+	Budgeted    int
+	CarriedOver int
+	Spent       int
+	Remaining   int
+}
+
+// SortBudgets orders budgets by category and then by ValidFrom.
+func SortBudgets(budgets []Budget) {
+	sort.SliceStable(budgets, func(i, j int) bool {
+		if budgets[i].CategoryID != budgets[j].CategoryID {
+			return budgets[i].CategoryID < budgets[j].CategoryID
+		}
+		return types.MonthsBetween(&budgets[i].ValidFrom, &budgets[j].ValidFrom) > 0
+	})
+}
+
+// Start returns the first month any envelope exists, nil without budgets.
+func Start(budgets []Budget) *types.YearMonth {
+	var start *types.YearMonth
+	for i := range budgets {
+		if start == nil || types.MonthsBetween(&budgets[i].ValidFrom, start) > 0 {
+			start = &budgets[i].ValidFrom
+		}
+	}
+	return start
+}
+
+// Active returns the budget valid in month for each category that has an
+// envelope then, ordered by category.
+func Active(budgets []Budget, month types.YearMonth) []Budget {
+	sorted := append([]Budget(nil), budgets...)
+	SortBudgets(sorted)
+
+	result := make([]Budget, 0)
+	for _, b := range sorted {
+		if types.MonthsBetween(&b.ValidFrom, &month) < 0 {
+			continue
+		}
+		if n := len(result); n > 0 && result[n-1].CategoryID == b.CategoryID {
+			result[n-1] = b
+		} else {
+			result = append(result, b)
+		}
+	}
+
+	return slices.DeleteFunc(result, func(b Budget) bool { return b.Amount == 0 })
+}
+
+// Calculate returns the status of every envelope in month, ordered by
+// category. Entries count towards the budget of their category or, if it has
+// none, of the closest parent category with a budget. The rest of each month
+// is carried into the next one according to the rollover of its budget.
+// Categories spent on without any budget are reported with an empty
+// envelope.
+func Calculate(budgets []Budget, entries []Entry, tree *cost.CategoryTree, month types.YearMonth, converter *currency.Converter) []Status {
+	sorted := append([]Budget(nil), budgets...)
+	SortBudgets(sorted)
+
+	byCategory := make(map[uint][]Budget)
+	for _, b := range sorted {
+		byCategory[b.CategoryID] = append(byCategory[b.CategoryID], b)
+	}
+
+	spent := make(map[uint]map[types.YearMonth]int)
+	for i := range entries {
+		ym := entries[i].Month()
+		if types.MonthsBetween(&ym, &month) < 0 {
+			continue
+		}
+		categoryID := envelopeOf(tree, byCategory, entries[i].CategoryID)
+		if spent[categoryID] == nil {
+			spent[categoryID] = make(map[types.YearMonth]int)
+		}
+		spent[categoryID][ym] -= converter.Convert(entries[i].Amount, entries[i].Currency, &ym)
+	}
+
+	result := make([]Status, 0)
+	for categoryID, categoryBudgets := range byCategory {
+		if status, ok := calculateEnvelope(categoryBudgets, spent[categoryID], month); ok {
+			result = append(result, status)
+		}
+	}
+	for categoryID, months := range spent {
+		if _, budgeted := byCategory[categoryID]; !budgeted && months[month] != 0 {
+			result = append(result, Status{
+				CategoryID: categoryID,
+				Rollover:   RolloverNone,
+				Spent:      months[month],
+				Remaining:  -months[month],
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].CategoryID < result[j].CategoryID })
+	return result
+}
+
+// calculateEnvelope walks the months of a category from its first budget up
+// to month. budgets are sorted by ValidFrom.
+func calculateEnvelope(budgets []Budget, spent map[types.YearMonth]int, month types.YearMonth) (Status, bool) {
+	start := budgets[0].ValidFrom
+	if types.MonthsBetween(&start, &month) < 0 {
+		return Status{}, false
+	}
+
+	var status Status
+	carry, active := 0, 0
+	for ym := start; types.MonthsBetween(&ym, &month) >= 0; ym = *types.NextYearMonth(&ym) {
+		for active+1 < len(budgets) && types.MonthsBetween(&budgets[active+1].ValidFrom, &ym) >= 0 {
+			active++
+		}
+		b := budgets[active]
+
+		status = Status{
+			CategoryID:  b.CategoryID,
+			Rollover:    b.Rollover,
+			Budgeted:    b.Amount,
+			CarriedOver: carry,
+			Spent:       spent[ym],
+		}
+		status.Remaining = status.Budgeted + status.CarriedOver - status.Spent
+
+		switch b.Rollover {
+		case RolloverAll:
+			carry = status.Remaining
+		case RolloverSurplus:
+			carry = max(status.Remaining, 0)
+		default:
+			carry = 0
+		}
+	}
+
+	if status.Budgeted == 0 && status.CarriedOver == 0 && status.Spent == 0 {
+		return Status{}, false
+	}
+	return status, true
+}
+
+// envelopeOf returns the category whose envelope an entry of categoryID is
+// booked against.
+func envelopeOf(tree *cost.CategoryTree, budgets map[uint][]Budget, categoryID uint) uint {
+	visited := make(map[uint]bool)
+	for id := categoryID; !visited[id]; {
+		if _, ok := budgets[id]; ok {
+			return id
+		}
+		visited[id] = true
+
+		category, ok := tree.Get(id)
+		if !ok || category.ParentID == nil {
+			break
+		}
+		id = *category.ParentID
+	}
+	return categoryID
+}
+
+// RemainingTotal sums what is left in the envelopes; overspent envelopes do
+// not count, the money is already gone.
+func RemainingTotal(statuses []Status) int {
+	total := 0
+	for _, status := range statuses {
+		total += max(status.Remaining, 0)
+	}
+	return total
+}
@@ -0,0 +1,164 @@
+package budget
+
+import (
+	"testing"
+	"time"
+
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/currency"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+func entry(categoryID uint, year int, month time.Month, amount int) Entry {
+	return Entry{CategoryID: categoryID, Date: time.Date(year, month, 10, 0, 0, 0, 0, time.UTC), Amount: amount}
+}
+
+func TestCalculateRollover(t *testing.T) {
+	// 300 budgeted from January, 400 spent in January, 100 in February
+	entries := []Entry{
+		entry(1, 2025, time.January, -400),
+		entry(1, 2025, time.February, -100),
+	}
+	february := types.YearMonth{Year: 2025, Month: 2}
+
+	tests := []struct {
+		rollover    string
+		carriedOver int
+		remaining   int
+	}{
+		{RolloverNone, 0, 200},
+		{RolloverSurplus, 0, 200},
+		{RolloverAll, -100, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rollover, func(t *testing.T) {
+			budgets := []Budget{{CategoryID: 1, Amount: 300, ValidFrom: types.YearMonth{Year: 2025, Month: 1}, Rollover: tt.rollover}}
+
+			statuses := Calculate(budgets, entries, cost.NewCategoryTree(nil), february, currency.NewConverter("EUR", nil))
+
+			if len(statuses) != 1 {
+				t.Fatalf("Expected 1 envelope, got %d", len(statuses))
+			}
+			if statuses[0].CarriedOver != tt.carriedOver {
+				t.Errorf("Expected carried over %d, got %d", tt.carriedOver, statuses[0].CarriedOver)
+			}
+			if statuses[0].Spent != 100 {
+				t.Errorf("Expected spent 100, got %d", statuses[0].Spent)
+			}
+			if statuses[0].Remaining != tt.remaining {
+				t.Errorf("Expected remaining %d, got %d", tt.remaining, statuses[0].Remaining)
+			}
+		})
+	}
+}
+
+func TestCalculateCarriesSurplus(t *testing.T) {
+	budgets := []Budget{
+		{CategoryID: 1, Amount: 300, ValidFrom: types.YearMonth{Year: 2025, Month: 1}, Rollover: RolloverSurplus},
+		{CategoryID: 1, Amount: 250, ValidFrom: types.YearMonth{Year: 2025, Month: 3}, Rollover: RolloverSurplus},
+	}
+	entries := []Entry{
+		entry(1, 2025, time.January, -200),
+		entry(1, 2025, time.February, -250),
+		entry(1, 2025, time.March, -50),
+	}
+
+	statuses := Calculate(budgets, entries, cost.NewCategoryTree(nil), types.YearMonth{Year: 2025, Month: 3}, currency.NewConverter("EUR", nil))
+
+	// January leaves 100, February 150, March starts with the new amount
+	if len(statuses) != 1 {
+		t.Fatalf("Expected 1 envelope, got %d", len(statuses))
+	}
+	if statuses[0].Budgeted != 250 || statuses[0].CarriedOver != 150 || statuses[0].Remaining != 350 {
+		t.Errorf("Unexpected status %+v", statuses[0])
+	}
+}
+
+func TestCalculateRollsUpToParentBudget(t *testing.T) {
+	parentID := uint(1)
+	tree := cost.NewCategoryTree([]cost.Category{
+		{ID: 1, Name: "Food"},
+		{ID: 2, Name: "Groceries", ParentID: &parentID},
+		{ID: 3, Name: "Hobby"},
+	})
+	budgets := []Budget{{CategoryID: 1, Amount: 500, ValidFrom: types.YearMonth{Year: 2025, Month: 1}, Rollover: RolloverNone}}
+	entries := []Entry{
+		entry(2, 2025, time.January, -120),
+		entry(1, 2025, time.January, -30),
+		entry(3, 2025, time.January, -40),
+		{CategoryID: 2, Date: time.Date(2025, time.January, 12, 0, 0, 0, 0, time.UTC), Amount: -100, Currency: "USD"},
+	}
+	rates := []currency.ExchangeRate{{Currency: "USD", Rate: 0.5, ValidFrom: types.YearMonth{Year: 2024, Month: 1}}}
+
+	statuses := Calculate(budgets, entries, tree, types.YearMonth{Year: 2025, Month: 1}, currency.NewConverter("EUR", rates))
+
+	if len(statuses) != 2 {
+		t.Fatalf("Expected 2 envelopes, got %d", len(statuses))
+	}
+	if statuses[0].CategoryID != 1 || statuses[0].Spent != 200 || statuses[0].Remaining != 300 {
+		t.Errorf("Unexpected food envelope %+v", statuses[0])
+	}
+	// Spending without a budget shows up as overspent
+	if statuses[1].CategoryID != 3 || statuses[1].Budgeted != 0 || statuses[1].Remaining != -40 {
+		t.Errorf("Unexpected hobby envelope %+v", statuses[1])
+	}
+}
+
+func TestCalculateIgnoresLaterMonths(t *testing.T) {
+	budgets := []Budget{{CategoryID: 1, Amount: 100, ValidFrom: types.YearMonth{Year: 2025, Month: 5}, Rollover: RolloverNone}}
+	entries := []Entry{entry(1, 2025, time.June, -80)}
+
+	statuses := Calculate(budgets, entries, cost.NewCategoryTree(nil), types.YearMonth{Year: 2025, Month: 4}, currency.NewConverter("EUR", nil))
+
+	if len(statuses) != 0 {
+		t.Errorf("Expected no envelopes before the first budget, got %+v", statuses)
+	}
+}
+
+func TestRemainingTotal(t *testing.T) {
+	statuses := []Status{{Remaining: 120}, {Remaining: -50}, {Remaining: 30}}
+
+	if total := RemainingTotal(statuses); total != 150 {
+		t.Errorf("Expected 150, got %d", total)
+	}
+}
+
+func TestBudgetValidate(t *testing.T) {
+	valid := Budget{CategoryID: 1, Amount: 100, ValidFrom: types.YearMonth{Year: 2025, Month: 1}, Rollover: RolloverNone}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected valid budget, got %v", err)
+	}
+
+	invalid := []Budget{
+		{Amount: 100, ValidFrom: valid.ValidFrom, Rollover: RolloverNone},
+		{CategoryID: 1, Amount: -1, ValidFrom: valid.ValidFrom, Rollover: RolloverNone},
+		{CategoryID: 1, Amount: 100, ValidFrom: types.YearMonth{Year: 2025, Month: 13}, Rollover: RolloverNone},
+		{CategoryID: 1, Amount: 100, ValidFrom: valid.ValidFrom, Rollover: "weekly"},
+	}
+	for _, b := range invalid {
+		if err := b.Validate(); err == nil {
+			t.Errorf("Expected error for %+v", b)
+		}
+	}
+}
+
+func TestActive(t *testing.T) {
+	budgets := []Budget{
+		{CategoryID: 2, Amount: 100, ValidFrom: types.YearMonth{Year: 2025, Month: 1}},
+		{CategoryID: 1, Amount: 300, ValidFrom: types.YearMonth{Year: 2025, Month: 4}},
+		{CategoryID: 1, Amount: 200, ValidFrom: types.YearMonth{Year: 2025, Month: 1}},
+		{CategoryID: 2, Amount: 0, ValidFrom: types.YearMonth{Year: 2025, Month: 3}},
+	}
+
+	active := Active(budgets, types.YearMonth{Year: 2025, Month: 2})
+	if len(active) != 2 || active[0].Amount != 200 || active[1].Amount != 100 {
+		t.Errorf("Unexpected budgets in February: %+v", active)
+	}
+
+	// The budget of category 2 ended with an amount of zero
+	active = Active(budgets, types.YearMonth{Year: 2025, Month: 5})
+	if len(active) != 1 || active[0].CategoryID != 1 || active[0].Amount != 300 {
+		t.Errorf("Unexpected budgets in May: %+v", active)
+	}
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"time"
+
+	"wondee/finance-app-backend/internal/budget"
+	"wondee/finance-app-backend/internal/transaction"
+
+	"gorm.io/gorm"
+)
+
+func (r *PostgresRepository) GetBudgets(workspaceID uint) ([]budget.Budget, error) {
+	var budgets []budget.Budget
+	if err := r.DB.Where("workspace_id = ?", workspaceID).Find(&budgets).Error; err != nil {
+		return nil, err
+	}
+	budget.SortBudgets(budgets)
+	return budgets, nil
+}
+
+func (r *PostgresRepository) GetBudget(id uint, workspaceID uint) (*budget.Budget, error) {
+	var b budget.Budget
+	result := r.DB.Where("id = ? AND workspace_id = ?", id, workspaceID).First(&b)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &b, nil
+}
+
+func (r *PostgresRepository) SaveBudget(b *budget.Budget) error {
+	validFrom, _ := b.ValidFrom.Value()
+
+	var existing budget.Budget
+	err := r.DB.Where("workspace_id = ? AND category_id = ? AND valid_from = ?", b.WorkspaceID, b.CategoryID, validFrom).First(&existing).Error
+	if err == nil {
+		b.ID = existing.ID
+		b.CreatedAt = existing.CreatedAt
+		return r.DB.Save(b).Error
+	} else if err == gorm.ErrRecordNotFound {
+		return r.DB.Create(b).Error
+	}
+
+	return err
+}
+
+func (r *PostgresRepository) DeleteBudget(id uint, workspaceID uint) error {
+	result := r.DB.Where("id = ? AND workspace_id = ?", id, workspaceID).Delete(&budget.Budget{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) ListEntries(workspaceID uint, from, to time.Time) ([]budget.Entry, error) {
+	var entries []budget.Entry
+	result := r.DB.Where("workspace_id = ? AND date >= ? AND date <= ?", workspaceID, from, to).
+		Order("date DESC, id DESC").
+		Find(&entries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return entries, nil
+}
+
+func (r *PostgresRepository) GetEntry(id uint, workspaceID uint) (*budget.Entry, error) {
+	var entry budget.Entry
+	result := r.DB.Where("id = ? AND workspace_id = ?", id, workspaceID).First(&entry)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &entry, nil
+}
+
+func (r *PostgresRepository) CreateEntry(entry *budget.Entry) error {
+	return r.DB.Create(entry).Error
+}
+
+func (r *PostgresRepository) DeleteEntry(id uint, workspaceID uint) error {
+	result := r.DB.Where("id = ? AND workspace_id = ?", id, workspaceID).Delete(&budget.Entry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) GetTransaction(id uint, workspaceID uint) (*transaction.Transaction, error) {
+	var t transaction.Transaction
+	result := r.DB.Where("id = ? AND workspace_id = ?", id, workspaceID).First(&t)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &t, nil
+}
+
+func (r *PostgresRepository) IsTransactionBooked(workspaceID uint, transactionID uint) (bool, error) {
+	var count int64
+	err := r.DB.Model(&budget.Entry{}).
+		Where("workspace_id = ? AND transaction_id = ?", workspaceID, transactionID).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"time"
+
+	"wondee/finance-app-backend/internal/budget"
+	"wondee/finance-app-backend/internal/transaction"
+
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for budget data access
+type Repository interface {
+	GetBudgets(workspaceID uint) ([]budget.Budget, error)
+	GetBudget(id uint, workspaceID uint) (*budget.Budget, error)
+	// SaveBudget replaces an existing budget of the same category and month.
+	SaveBudget(b *budget.Budget) error
+	DeleteBudget(id uint, workspaceID uint) error
+
+	// ListEntries returns the entries booked between from and to, both
+	// inclusive, newest first.
+	ListEntries(workspaceID uint, from, to time.Time) ([]budget.Entry, error)
+	GetEntry(id uint, workspaceID uint) (*budget.Entry, error)
+	CreateEntry(entry *budget.Entry) error
+	DeleteEntry(id uint, workspaceID uint) error
+
+	// GetTransaction loads an imported transaction to be booked as entry.
+	GetTransaction(id uint, workspaceID uint) (*transaction.Transaction, error)
+	// IsTransactionBooked reports whether an entry was already created from
+	// the transaction.
+	IsTransactionBooked(workspaceID uint, transactionID uint) (bool, error)
+}
+
+// PostgresRepository implements Repository using GORM
+type PostgresRepository struct {
+	DB *gorm.DB
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"time"
+
+	"wondee/finance-app-backend/internal/budget"
+	"wondee/finance-app-backend/internal/budget/repository"
+	"wondee/finance-app-backend/internal/cost"
+	cost_repo "wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/currency"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/storage"
+)
+
+// BudgetService calculates the state of the category envelopes
+type BudgetService struct {
+	repo     repository.Repository
+	costRepo cost_repo.Repository
+	settings storage.Repository
+}
+
+// NewBudgetService creates a new BudgetService instance
+func NewBudgetService(repo repository.Repository, costRepo cost_repo.Repository, settings storage.Repository) *BudgetService {
+	return &BudgetService{
+		repo:     repo,
+		costRepo: costRepo,
+		settings: settings,
+	}
+}
+
+// Status returns the envelopes of the month and the converter their amounts
+// were converted with, which also knows the currencies it had no rate for.
+func (s *BudgetService) Status(workspaceID uint, month types.YearMonth) ([]budget.Status, *currency.Converter, error) {
+	converter, err := currency.LoadConverter(s.settings, workspaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	budgets, err := s.repo.GetBudgets(workspaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Rollovers need all entries since the first envelope
+	from := month
+	if start := budget.Start(budgets); start != nil && types.MonthsBetween(start, &month) > 0 {
+		from = *start
+	}
+
+	entries, err := s.repo.ListEntries(workspaceID, firstDay(from), lastDay(month))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return budget.Calculate(budgets, entries, cost.LoadCategoryTree(s.costRepo, workspaceID), month, converter), converter, nil
+}
+
+// RemainingTotal returns how much is left in the envelopes of the month.
+func (s *BudgetService) RemainingTotal(workspaceID uint, month types.YearMonth) (int, error) {
+	statuses, _, err := s.Status(workspaceID, month)
+	if err != nil {
+		return 0, err
+	}
+	return budget.RemainingTotal(statuses), nil
+}
+
+func firstDay(month types.YearMonth) time.Time {
+	return time.Date(month.Year, time.Month(month.Month), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func lastDay(month types.YearMonth) time.Time {
+	return firstDay(month).AddDate(0, 1, -1)
+}
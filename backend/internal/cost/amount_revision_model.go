@@ -0,0 +1,26 @@
+package cost
+
+import (
+	"sort"
+	"time"
+
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+// AmountRevision changes the amount of a fixed cost from ValidFrom onwards,
+// e.g. when the rent increases. Before its first revision a fixed cost is
+// charged with its own Amount.
+type AmountRevision struct {
+	ID          uint            `gorm:"primaryKey"`
+	FixedCostID int             `gorm:"not null;uniqueIndex:idx_revision_unique,priority:1"`
+	Amount      int             `gorm:"not null"`
+	ValidFrom   types.YearMonth `gorm:"type:string;not null;uniqueIndex:idx_revision_unique,priority:2"`
+	CreatedAt   time.Time
+}
+
+// SortRevisions orders revisions by the month they take effect.
+func SortRevisions(revisions []AmountRevision) {
+	sort.Slice(revisions, func(i, j int) bool {
+		return types.MonthsBetween(&revisions[j].ValidFrom, &revisions[i].ValidFrom) < 0
+	})
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"errors"
+
+	"wondee/finance-app-backend/internal/account"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/storage"
+)
+
+// ValidateAssignments checks the category, accounts and split of a cost that
+// is not saved through the handlers of this package.
+func ValidateAssignments(repo repository.Repository, settings storage.Repository, workspaceID uint, categoryID, accountID, targetAccountID *uint, split cost.Split) error {
+	if err := validateCategoryAssignment(repo, workspaceID, categoryID); err != nil {
+		return err
+	}
+	if err := validateAccountAssignment(settings, workspaceID, accountID, targetAccountID); err != nil {
+		return err
+	}
+	return validateSplitMembers(settings, workspaceID, split)
+}
+
+// validateAccountAssignment ensures a cost is only booked to accounts of its
+// own workspace and that a transfer connects two different accounts. Without
+// an account repository assignments are not checked.
+func validateAccountAssignment(accounts storage.AccountRepository, workspaceID uint, accountID, targetAccountID *uint) error {
+	if accountID == nil && targetAccountID == nil || accounts == nil {
+		return nil
+	}
+
+	list, err := accounts.GetAccounts(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	source := account.Default(list)
+	if accountID != nil {
+		if source = findAccount(list, *accountID); source == nil {
+			return errors.New("account not found")
+		}
+	}
+
+	if targetAccountID == nil {
+		return nil
+	}
+
+	target := findAccount(list, *targetAccountID)
+	if target == nil {
+		return errors.New("target account not found")
+	}
+	if source != nil && source.ID == target.ID {
+		return errors.New("a transfer needs two different accounts")
+	}
+
+	return nil
+}
+
+// validateTransfer rejects flags a transfer cannot carry: moving money
+// between own accounts is never a saving of the workspace.
+func validateTransfer(isSaving bool, targetAccountID *uint) error {
+	if targetAccountID != nil && isSaving {
+		return errors.New("a transfer cannot be a saving")
+	}
+	return nil
+}
+
+func findAccount(accounts []account.Account, id uint) *account.Account {
+	for i := range accounts {
+		if accounts[i].ID == id {
+			return &accounts[i]
+		}
+	}
+	return nil
+}
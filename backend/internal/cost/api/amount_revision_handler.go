@@ -0,0 +1,177 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/audit"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+type AmountHistory struct {
+	InitialAmount int                  `json:"initialAmount"`
+	From          *types.YearMonth     `json:"from"`
+	CurrentAmount int                  `json:"currentAmount"`
+	Revisions     []JsonAmountRevision `json:"revisions"`
+}
+
+type JsonAmountRevision struct {
+	ID        uint             `json:"id"`
+	Amount    int              `json:"amount"`
+	ValidFrom *types.YearMonth `json:"validFrom"`
+}
+
+// GetAmountHistory lists the amount revisions of a fixed cost.
+func (h *FixedCostHandler) GetAmountHistory(c *gin.Context) {
+	fixedCost, ok := h.loadFixedCost(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, ToAmountHistory(fixedCost))
+}
+
+// SaveAmountRevision changes the amount of a fixed cost from the given month
+// onwards. An existing revision for the same month is replaced.
+func (h *FixedCostHandler) SaveAmountRevision(c *gin.Context) {
+	fixedCost, ok := h.loadFixedCost(c)
+	if !ok {
+		return
+	}
+
+	var jsonRevision JsonAmountRevision
+	if err := c.ShouldBindJSON(&jsonRevision); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validateAmountRevision(fixedCost, &jsonRevision); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	revision := &cost.AmountRevision{
+		FixedCostID: fixedCost.ID,
+		Amount:      jsonRevision.Amount,
+		ValidFrom:   *jsonRevision.ValidFrom,
+	}
+
+	notify := WatchBalance(h.Balance, fixedCost.WorkspaceID)
+	if err := h.Repo.SaveAmountRevision(revision); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save revision"})
+		return
+	}
+	notify(h.getUserID(c), fixedCost.Name)
+
+	h.respondWithAmountHistory(c, fixedCost)
+}
+
+// DeleteAmountRevision removes a revision; the previous amount then stays
+// valid until the next revision.
+func (h *FixedCostHandler) DeleteAmountRevision(c *gin.Context) {
+	fixedCost, ok := h.loadFixedCost(c)
+	if !ok {
+		return
+	}
+
+	revisionID, err := strconv.ParseUint(c.Param("revisionId"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	notify := WatchBalance(h.Balance, fixedCost.WorkspaceID)
+	if err := h.Repo.DeleteAmountRevision(uint(revisionID), fixedCost.ID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Revision not found"})
+		return
+	}
+	notify(h.getUserID(c), fixedCost.Name)
+
+	h.respondWithAmountHistory(c, fixedCost)
+}
+
+func validateAmountRevision(fixedCost *cost.FixedCost, revision *JsonAmountRevision) error {
+	if revision.ValidFrom == nil {
+		return errors.New("validFrom is required")
+	}
+
+	if _, err := types.New(revision.ValidFrom.Year, revision.ValidFrom.Month); err != nil {
+		return err
+	}
+
+	if fixedCost.From != nil && types.MonthsBetween(fixedCost.From, revision.ValidFrom) <= 0 {
+		return errors.New("validFrom must be after the start of the fixed cost")
+	}
+
+	if fixedCost.To != nil && types.MonthsBetween(revision.ValidFrom, fixedCost.To) < 0 {
+		return errors.New("validFrom must not be after the end of the fixed cost")
+	}
+
+	return nil
+}
+
+func ToAmountHistory(fixedCost *cost.FixedCost) AmountHistory {
+	revisions := append([]cost.AmountRevision(nil), fixedCost.Revisions...)
+	cost.SortRevisions(revisions)
+
+	jsonRevisions := make([]JsonAmountRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		validFrom := revision.ValidFrom
+		jsonRevisions = append(jsonRevisions, JsonAmountRevision{
+			ID:        revision.ID,
+			Amount:    revision.Amount,
+			ValidFrom: &validFrom,
+		})
+	}
+
+	return AmountHistory{
+		InitialAmount: fixedCost.Amount,
+		From:          fixedCost.From,
+		CurrentAmount: fixedCost.AmountAt(types.CurrentYearMonth()),
+		Revisions:     jsonRevisions,
+	}
+}
+
+// respondWithAmountHistory returns the history after a revision changed and
+// records the change of the fixed cost in the audit log.
+func (h *FixedCostHandler) respondWithAmountHistory(c *gin.Context, before *cost.FixedCost) {
+	workspaceID := h.getWorkspaceID(c)
+
+	fixedCost, err := h.Repo.GetFixedCost(before.ID, workspaceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load fixed cost"})
+		return
+	}
+
+	history := ToAmountHistory(fixedCost)
+	h.Audit.Record(audit.Change{
+		WorkspaceID: workspaceID,
+		Actor:       h.getUserID(c),
+		EntityType:  audit.EntityFixedCost,
+		EntityID:    uint(fixedCost.ID),
+		Action:      audit.ActionUpdate,
+		Before:      ToAmountHistory(before),
+		After:       history,
+	})
+
+	c.JSON(http.StatusOK, history)
+}
+
+func (h *FixedCostHandler) loadFixedCost(c *gin.Context) (*cost.FixedCost, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return nil, false
+	}
+
+	fixedCost, err := h.Repo.GetFixedCost(id, h.getWorkspaceID(c))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Fixed cost not found"})
+		return nil, false
+	}
+
+	return fixedCost, true
+}
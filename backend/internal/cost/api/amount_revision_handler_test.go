@@ -0,0 +1,153 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/storage"
+)
+
+func setupRevisionRouter(mockRepo *storage.MockRepository) *gin.Engine {
+	handler := &FixedCostHandler{Repo: mockRepo}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+
+	r.GET("/costs/:id/revisions", handler.GetAmountHistory)
+	r.POST("/costs/:id/revisions", handler.SaveAmountRevision)
+	r.DELETE("/costs/:id/revisions/:revisionId", handler.DeleteAmountRevision)
+
+	return r
+}
+
+func postRevision(r *gin.Engine, path string, amount int, validFrom *types.YearMonth) *httptest.ResponseRecorder {
+	reqBody, _ := json.Marshal(JsonAmountRevision{Amount: amount, ValidFrom: validFrom})
+	req, _ := http.NewRequest(http.MethodPost, path, bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestSaveAmountRevision(t *testing.T) {
+	mockRepo := &storage.MockRepository{
+		FixedCosts: []cost.FixedCost{
+			{ID: 1, WorkspaceID: 1, Name: "Rent", Amount: -900, From: &types.YearMonth{Year: 2023, Month: 1}, DueMonth: cost.ALL_MONTHS},
+			{ID: 2, WorkspaceID: 2, Name: "Foreign", Amount: -50, DueMonth: cost.ALL_MONTHS},
+		},
+	}
+	r := setupRevisionRouter(mockRepo)
+
+	w := postRevision(r, "/costs/1/revisions", -1000, &types.YearMonth{Year: 2025, Month: 1})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	postRevision(r, "/costs/1/revisions", -950, &types.YearMonth{Year: 2024, Month: 4})
+	// Same month again replaces the earlier revision
+	w = postRevision(r, "/costs/1/revisions", -960, &types.YearMonth{Year: 2024, Month: 4})
+
+	var history AmountHistory
+	if err := json.Unmarshal(w.Body.Bytes(), &history); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if history.InitialAmount != -900 || len(history.Revisions) != 2 {
+		t.Fatalf("Unexpected history %+v", history)
+	}
+	if history.Revisions[0].Amount != -960 || history.Revisions[1].Amount != -1000 {
+		t.Errorf("Expected revisions ordered by validFrom, got %+v", history.Revisions)
+	}
+
+	if w := postRevision(r, "/costs/1/revisions", -800, &types.YearMonth{Year: 2023, Month: 1}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for revision at start month, got %d", w.Code)
+	}
+	if w := postRevision(r, "/costs/1/revisions", -800, nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 without validFrom, got %d", w.Code)
+	}
+	if w := postRevision(r, "/costs/2/revisions", -60, &types.YearMonth{Year: 2025, Month: 1}); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for cost of another workspace, got %d", w.Code)
+	}
+}
+
+func TestDeleteAmountRevision(t *testing.T) {
+	mockRepo := &storage.MockRepository{
+		FixedCosts: []cost.FixedCost{
+			{ID: 1, WorkspaceID: 1, Name: "Rent", Amount: -900, DueMonth: cost.ALL_MONTHS, Revisions: []cost.AmountRevision{
+				{ID: 7, FixedCostID: 1, Amount: -1000, ValidFrom: types.YearMonth{Year: 2025, Month: 1}},
+			}},
+		},
+	}
+	r := setupRevisionRouter(mockRepo)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/costs/1/revisions/7", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if len(mockRepo.FixedCosts[0].Revisions) != 0 {
+		t.Error("Expected revision to be removed")
+	}
+
+	req, _ = http.NewRequest(http.MethodDelete, "/costs/1/revisions/7", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown revision, got %d", w.Code)
+	}
+}
+
+// balanceRecorder implements BalanceMonitor
+type balanceRecorder struct {
+	watched  int
+	notified []string
+}
+
+func (m *balanceRecorder) Watch(workspaceID uint) func(actor uint, costName string) {
+	m.watched++
+	return func(actor uint, costName string) {
+		m.notified = append(m.notified, costName)
+	}
+}
+
+func TestAmountRevisionsAreWatched(t *testing.T) {
+	mockRepo := &storage.MockRepository{
+		FixedCosts: []cost.FixedCost{
+			{ID: 1, WorkspaceID: 1, Name: "Rent", Amount: -900, DueMonth: cost.ALL_MONTHS},
+		},
+	}
+	monitor := &balanceRecorder{}
+	handler := &FixedCostHandler{Repo: mockRepo, Balance: monitor}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+	r.POST("/costs/:id/revisions", handler.SaveAmountRevision)
+	r.DELETE("/costs/:id/revisions/:revisionId", handler.DeleteAmountRevision)
+
+	postRevision(r, "/costs/1/revisions", -1200, &types.YearMonth{Year: 2025, Month: 1})
+	revisionID := mockRepo.FixedCosts[0].Revisions[0].ID
+
+	req, _ := http.NewRequest(http.MethodDelete, "/costs/1/revisions/"+strconv.Itoa(int(revisionID)), nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if monitor.watched != 2 || len(monitor.notified) != 2 || monitor.notified[0] != "Rent" || monitor.notified[1] != "Rent" {
+		t.Errorf("Expected both revision changes to be watched, got %d watched and %v notified", monitor.watched, monitor.notified)
+	}
+}
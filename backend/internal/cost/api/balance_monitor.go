@@ -0,0 +1,18 @@
+package api
+
+// BalanceMonitor warns when a change of the costs lets the projected balance
+// of the workspace fall below its threshold.
+type BalanceMonitor interface {
+	// Watch captures the projection before costs are saved, deleted or
+	// restored; the returned function is called with the name of the
+	// changed costs once the change is stored.
+	Watch(workspaceID uint) func(actor uint, costName string)
+}
+
+// WatchBalance is Watch of monitor; without a monitor nothing is watched.
+func WatchBalance(monitor BalanceMonitor, workspaceID uint) func(actor uint, costName string) {
+	if monitor == nil {
+		return func(uint, string) {}
+	}
+	return monitor.Watch(workspaceID)
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/cost/repository"
+)
+
+type CategoryHandler struct {
+	Repo repository.Repository
+}
+
+type JsonCategory struct {
+	ID       uint           `json:"id"`
+	Name     string         `json:"name"`
+	ParentID *uint          `json:"parentId"`
+	Children []JsonCategory `json:"children"`
+}
+
+// GetCategories returns the category tree of the workspace.
+func (h *CategoryHandler) GetCategories(c *gin.Context) {
+	categories, err := h.Repo.LoadCategories(h.getWorkspaceID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load categories"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ToJsonCategoryTree(cost.NewCategoryTree(categories)))
+}
+
+// SaveCategory creates a category or, if an id is given, renames or moves it.
+func (h *CategoryHandler) SaveCategory(c *gin.Context) {
+	var jsonCategory JsonCategory
+	if err := c.ShouldBindJSON(&jsonCategory); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workspaceID := h.getWorkspaceID(c)
+	categories, err := h.Repo.LoadCategories(workspaceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load categories"})
+		return
+	}
+
+	category := &cost.Category{
+		ID:          jsonCategory.ID,
+		WorkspaceID: workspaceID,
+		ParentID:    jsonCategory.ParentID,
+		Name:        strings.TrimSpace(jsonCategory.Name),
+	}
+
+	if err := validateCategory(cost.NewCategoryTree(categories), category); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.Repo.SaveCategory(category); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save category"})
+		return
+	}
+
+	c.JSON(http.StatusOK, JsonCategory{
+		ID:       category.ID,
+		Name:     category.Name,
+		ParentID: category.ParentID,
+		Children: make([]JsonCategory, 0),
+	})
+}
+
+// DeleteCategory removes a category. Subcategories move up one level and
+// assigned costs become uncategorized.
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Repo.DeleteCategory(uint(id), h.getWorkspaceID(c)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete category"})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func validateCategory(tree *cost.CategoryTree, category *cost.Category) error {
+	if category.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if category.ID != 0 && !tree.Contains(category.ID) {
+		return errors.New("category not found")
+	}
+
+	if category.ParentID == nil {
+		return nil
+	}
+
+	if !tree.Contains(*category.ParentID) {
+		return errors.New("parent category not found")
+	}
+
+	if category.ID != 0 && tree.IsDescendant(*category.ParentID, category.ID) {
+		return errors.New("a category cannot be moved below itself")
+	}
+
+	return nil
+}
+
+// validateCategoryAssignment ensures a cost only references a category of its
+// own workspace.
+func validateCategoryAssignment(repo repository.Repository, workspaceID uint, categoryID *uint) error {
+	if categoryID == nil {
+		return nil
+	}
+
+	categories, err := repo.LoadCategories(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	if !cost.NewCategoryTree(categories).Contains(*categoryID) {
+		return errors.New("category not found")
+	}
+
+	return nil
+}
+
+func ToJsonCategoryTree(tree *cost.CategoryTree) []JsonCategory {
+	return toJsonCategories(tree, tree.Roots())
+}
+
+func toJsonCategories(tree *cost.CategoryTree, categories []cost.Category) []JsonCategory {
+	result := make([]JsonCategory, 0, len(categories))
+
+	for _, category := range categories {
+		result = append(result, JsonCategory{
+			ID:       category.ID,
+			Name:     category.Name,
+			ParentID: category.ParentID,
+			Children: toJsonCategories(tree, tree.Children(category.ID)),
+		})
+	}
+
+	return result
+}
+
+func (h *CategoryHandler) getWorkspaceID(c *gin.Context) uint {
+	workspaceID, exists := c.Get("workspace_id")
+	if !exists {
+		return 0
+	}
+	return workspaceID.(uint)
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/storage"
+)
+
+func setupCategoryRouter(mockRepo repository.Repository) *gin.Engine {
+	handler := &CategoryHandler{Repo: mockRepo}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+
+	r.GET("/categories", handler.GetCategories)
+	r.POST("/categories", handler.SaveCategory)
+	r.DELETE("/categories/:id", handler.DeleteCategory)
+
+	return r
+}
+
+func postCategory(r *gin.Engine, body map[string]interface{}) *httptest.ResponseRecorder {
+	reqBody, _ := json.Marshal(body)
+	req, _ := http.NewRequest(http.MethodPost, "/categories", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCategoryTree(t *testing.T) {
+	housing := uint(1)
+	mockRepo := &storage.MockRepository{
+		Categories: []cost.Category{
+			{ID: 1, WorkspaceID: 1, Name: "Wohnen"},
+			{ID: 2, WorkspaceID: 1, Name: "Miete", ParentID: &housing},
+			{ID: 3, WorkspaceID: 1, Name: "Versicherungen"},
+			{ID: 4, WorkspaceID: 2, Name: "Other workspace"},
+		},
+	}
+	r := setupCategoryRouter(mockRepo)
+
+	req, _ := http.NewRequest(http.MethodGet, "/categories", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var tree []JsonCategory
+	if err := json.Unmarshal(w.Body.Bytes(), &tree); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(tree) != 2 || tree[0].Name != "Versicherungen" || tree[1].Name != "Wohnen" {
+		t.Fatalf("Expected two root categories of workspace 1, got %+v", tree)
+	}
+	if len(tree[1].Children) != 1 || tree[1].Children[0].Name != "Miete" {
+		t.Errorf("Expected Miete below Wohnen, got %+v", tree[1].Children)
+	}
+}
+
+func TestSaveCategoryValidation(t *testing.T) {
+	housing := uint(1)
+	mockRepo := &storage.MockRepository{
+		Categories: []cost.Category{
+			{ID: 1, WorkspaceID: 1, Name: "Wohnen"},
+			{ID: 2, WorkspaceID: 1, Name: "Miete", ParentID: &housing},
+			{ID: 3, WorkspaceID: 2, Name: "Other workspace"},
+		},
+	}
+	r := setupCategoryRouter(mockRepo)
+
+	if w := postCategory(r, map[string]interface{}{"name": "Nebenkosten", "parentId": 1}); w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 for new subcategory, got %d", w.Code)
+	}
+	if w := postCategory(r, map[string]interface{}{"name": "  "}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for empty name, got %d", w.Code)
+	}
+	if w := postCategory(r, map[string]interface{}{"name": "Foreign", "parentId": 3}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for parent of another workspace, got %d", w.Code)
+	}
+	if w := postCategory(r, map[string]interface{}{"id": 1, "name": "Wohnen", "parentId": 2}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for cyclic parent, got %d", w.Code)
+	}
+}
+
+func TestDeleteCategoryMovesChildrenAndUnassignsCosts(t *testing.T) {
+	housing := uint(1)
+	rent := uint(2)
+	mockRepo := &storage.MockRepository{
+		Categories: []cost.Category{
+			{ID: 1, WorkspaceID: 1, Name: "Wohnen"},
+			{ID: 2, WorkspaceID: 1, Name: "Miete", ParentID: &housing},
+			{ID: 3, WorkspaceID: 1, Name: "Kaltmiete", ParentID: &rent},
+		},
+		FixedCosts: []cost.FixedCost{
+			{ID: 1, WorkspaceID: 1, Name: "Rent", Amount: -900, CategoryID: &rent},
+		},
+	}
+	r := setupCategoryRouter(mockRepo)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/categories/2", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if len(mockRepo.Categories) != 2 || *mockRepo.Categories[1].ParentID != housing {
+		t.Errorf("Expected Kaltmiete to move below Wohnen, got %+v", mockRepo.Categories)
+	}
+	if mockRepo.FixedCosts[0].CategoryID != nil {
+		t.Error("Expected cost to be uncategorized")
+	}
+}
+
+// failingCategoryRepository fails to delete categories
+type failingCategoryRepository struct {
+	*storage.MockRepository
+}
+
+func (r failingCategoryRepository) DeleteCategory(id uint, workspaceID uint) error {
+	return errors.New("connection lost")
+}
+
+func TestDeleteCategoryErrors(t *testing.T) {
+	mockRepo := &storage.MockRepository{
+		Categories: []cost.Category{{ID: 1, WorkspaceID: 1, Name: "Wohnen"}},
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, "/categories/9", nil)
+	w := httptest.NewRecorder()
+	setupCategoryRouter(mockRepo).ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown category, got %d", w.Code)
+	}
+
+	req, _ = http.NewRequest(http.MethodDelete, "/categories/1", nil)
+	w = httptest.NewRecorder()
+	setupCategoryRouter(failingCategoryRepository{mockRepo}).ServeHTTP(w, req)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500 for failed delete, got %d", w.Code)
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tags := cost.NormalizeTags([]string{" kita ", "", "kita", "kind,schule"})
+
+	if len(tags) != 2 || tags[0] != "kita" || tags[1] != "kind schule" {
+		t.Errorf("Unexpected tags %v", tags)
+	}
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+const (
+	dateLayout          = "2006-01-02"
+	defaultDeadlineDays = 90
+	maxDeadlineDays     = 3 * 365
+)
+
+type JsonCancellationDeadline struct {
+	FixedCostID    int    `json:"fixedCostId"`
+	Name           string `json:"name"`
+	Provider       string `json:"provider"`
+	ContractNumber string `json:"contractNumber"`
+	Amount         int    `json:"amount"`
+	Currency       string `json:"currency"`
+	Deadline       string `json:"deadline"`
+	TermEnd        string `json:"termEnd"`
+	DaysLeft       int    `json:"daysLeft"`
+	RenewalMonths  int    `json:"renewalMonths"`
+}
+
+// GetCancellationDeadlines lists the contracts of the workspace that have to
+// be cancelled within the next "days" days (default 90) to end with their
+// current term, the most urgent first.
+func (h *FixedCostHandler) GetCancellationDeadlines(c *gin.Context) {
+	days := defaultDeadlineDays
+	if param := c.Query("days"); param != "" {
+		value, err := strconv.Atoi(param)
+		if err != nil || value < 0 || value > maxDeadlineDays {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "days must be between 0 and " + strconv.Itoa(maxDeadlineDays)})
+			return
+		}
+		days = value
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	until := today.AddDate(0, 0, days)
+
+	result := make([]JsonCancellationDeadline, 0)
+	for _, fc := range *h.Repo.LoadFixedCosts(h.getWorkspaceID(c)) {
+		deadline, ok := fc.Contract.NextDeadline(today)
+		if !ok || deadline.Date.After(until) {
+			continue
+		}
+
+		// A cost ending with the term at the latest has been cancelled already.
+		termEnd := &types.YearMonth{Year: deadline.TermEnd.Year(), Month: int(deadline.TermEnd.Month())}
+		if fc.To != nil && types.MonthsBetween(fc.To, termEnd) >= 0 {
+			continue
+		}
+
+		result = append(result, JsonCancellationDeadline{
+			FixedCostID:    fc.ID,
+			Name:           fc.Name,
+			Provider:       fc.Contract.Provider,
+			ContractNumber: fc.Contract.Number,
+			Amount:         fc.AmountAt(termEnd),
+			Currency:       fc.Currency,
+			Deadline:       deadline.Date.Format(dateLayout),
+			TermEnd:        deadline.TermEnd.Format(dateLayout),
+			DaysLeft:       int(deadline.Date.Sub(today).Hours() / 24),
+			RenewalMonths:  fc.Contract.RenewalMonths,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Deadline < result[j].Deadline
+	})
+
+	c.JSON(http.StatusOK, result)
+}
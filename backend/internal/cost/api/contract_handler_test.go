@@ -0,0 +1,122 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/storage"
+)
+
+func getDeadlines(mockRepo *storage.MockRepository, query string) *httptest.ResponseRecorder {
+	handler := &FixedCostHandler{Repo: mockRepo}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+	r.GET("/costs/deadlines", handler.GetCancellationDeadlines)
+
+	req, _ := http.NewRequest(http.MethodGet, "/costs/deadlines"+query, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetCancellationDeadlines(t *testing.T) {
+	// Terms ending in two and ten months with one month notice.
+	soon := types.AddMonths(types.CurrentYearMonth(), 2)
+	later := types.AddMonths(types.CurrentYearMonth(), 10)
+
+	mockRepo := &storage.MockRepository{
+		FixedCosts: []cost.FixedCost{
+			{ID: 1, WorkspaceID: 1, Name: "Gym", Amount: -30, DueMonth: cost.ALL_MONTHS, Contract: cost.Contract{
+				Provider: "Fitness First", Number: "A-17", MinimumTermEnd: soon, NoticePeriod: 1, NoticeUnit: cost.NoticeMonths, RenewalMonths: 12,
+			}},
+			{ID: 2, WorkspaceID: 1, Name: "Mobile", Amount: -20, DueMonth: cost.ALL_MONTHS, Contract: cost.Contract{
+				MinimumTermEnd: later, NoticePeriod: 1, NoticeUnit: cost.NoticeMonths,
+			}},
+			{ID: 3, WorkspaceID: 1, Name: "Cancelled", Amount: -10, DueMonth: cost.ALL_MONTHS, To: soon, Contract: cost.Contract{
+				MinimumTermEnd: soon, NoticePeriod: 1, NoticeUnit: cost.NoticeMonths,
+			}},
+			{ID: 4, WorkspaceID: 1, Name: "Rent", Amount: -900, DueMonth: cost.ALL_MONTHS},
+			{ID: 5, WorkspaceID: 2, Name: "Foreign", Amount: -15, DueMonth: cost.ALL_MONTHS, Contract: cost.Contract{
+				MinimumTermEnd: soon, NoticePeriod: 1, NoticeUnit: cost.NoticeMonths,
+			}},
+		},
+	}
+
+	w := getDeadlines(mockRepo, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var deadlines []JsonCancellationDeadline
+	if err := json.Unmarshal(w.Body.Bytes(), &deadlines); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(deadlines) != 1 || deadlines[0].FixedCostID != 1 {
+		t.Fatalf("Expected only the gym contract within 90 days, got %+v", deadlines)
+	}
+
+	expected := time.Date(soon.Year, time.Month(soon.Month), 0, 0, 0, 0, 0, time.UTC).Format(dateLayout)
+	if deadlines[0].Deadline != expected || deadlines[0].Provider != "Fitness First" || deadlines[0].ContractNumber != "A-17" {
+		t.Errorf("Unexpected deadline %+v, expected %s", deadlines[0], expected)
+	}
+
+	w = getDeadlines(mockRepo, "?days=365")
+	json.Unmarshal(w.Body.Bytes(), &deadlines)
+	if len(deadlines) != 2 || deadlines[0].FixedCostID != 1 || deadlines[1].FixedCostID != 2 {
+		t.Errorf("Expected both contracts ordered by deadline, got %+v", deadlines)
+	}
+
+	if w := getDeadlines(mockRepo, "?days=abc"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid days, got %d", w.Code)
+	}
+}
+
+func TestContractIsMapped(t *testing.T) {
+	contract := &cost.Contract{
+		Provider:       "Telekom",
+		MinimumTermEnd: &types.YearMonth{Year: 2026, Month: 3},
+		NoticePeriod:   3,
+		NoticeUnit:     cost.NoticeMonths,
+		RenewalMonths:  12,
+	}
+
+	fc, err := ToDBStructWithRecurrence(&JsonFixedCost{
+		Name:       "Mobile",
+		Amount:     -25,
+		Recurrence: &cost.Recurrence{Frequency: cost.FrequencyMonthly, Interval: 1},
+		Contract:   contract,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fc.Contract.Provider != "Telekom" || fc.Contract.RenewalMonths != 12 {
+		t.Errorf("Contract not mapped: %+v", fc.Contract)
+	}
+	if jsonFC := ToJsonStruct(fc); jsonFC.Contract == nil || jsonFC.Contract.NoticePeriod != 3 {
+		t.Errorf("Contract not returned: %+v", jsonFC.Contract)
+	}
+
+	if jsonFC := ToJsonStruct(&cost.FixedCost{Name: "Rent"}); jsonFC.Contract != nil {
+		t.Errorf("Expected no contract, got %+v", jsonFC.Contract)
+	}
+
+	_, err = ToDBStructWithRecurrence(&JsonFixedCost{
+		Name:       "Mobile",
+		Amount:     -25,
+		Recurrence: &cost.Recurrence{Frequency: cost.FrequencyMonthly, Interval: 1},
+		Contract:   &cost.Contract{NoticePeriod: 3},
+	})
+	if err == nil {
+		t.Error("Expected error for notice period without unit")
+	}
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/platform/export"
+)
+
+// The export columns use the field names understood by the CSV import, so an
+// exported file can be imported again.
+
+// ExportFixedCosts downloads all fixed costs of the workspace.
+func (h *FixedCostHandler) ExportFixedCosts(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+	categoryNames := loadCategoryNames(h.Repo.LoadCategories(workspaceID))
+
+	table := &export.Table{
+		Name: "fixed-costs",
+		Columns: []string{"id", "name", "amount", "currentAmount", "currency", "frequency", "dueMonth",
+			"from", "to", "isSaving", "category", "tags", "escalationRate", "escalationMonth"},
+	}
+
+	for _, fixedCost := range *h.Repo.LoadFixedCosts(workspaceID) {
+		jsonCost := ToJsonStruct(&fixedCost)
+		table.Append(
+			jsonCost.ID,
+			jsonCost.Name,
+			jsonCost.Amount,
+			jsonCost.CurrentAmount,
+			jsonCost.Currency,
+			strings.ReplaceAll(jsonCost.RRule, "\n", " "),
+			jsonCost.DueMonth,
+			jsonCost.From,
+			jsonCost.To,
+			jsonCost.IsSaving,
+			categoryNames[categoryKey(jsonCost.CategoryID)],
+			strings.Join(jsonCost.Tags, ","),
+			jsonCost.EscalationRate,
+			jsonCost.EscalationMonth,
+		)
+	}
+
+	export.Respond(c, table)
+}
+
+// ExportSpecialCosts downloads all special costs of the workspace.
+func (h *SpecialCostHandler) ExportSpecialCosts(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+	categoryNames := loadCategoryNames(h.Repo.LoadCategories(workspaceID))
+
+	table := &export.Table{
+		Name:    "special-costs",
+		Columns: []string{"id", "name", "amount", "currency", "dueDate", "isSaving", "category", "tags", "installments", "interestRate", "fee"},
+	}
+
+	for _, jsonCost := range h.createSpecialCosts(workspaceID) {
+		table.Append(
+			jsonCost.ID,
+			jsonCost.Name,
+			jsonCost.Amount,
+			jsonCost.Currency,
+			jsonCost.DueDate,
+			jsonCost.IsSaving,
+			categoryNames[categoryKey(jsonCost.CategoryID)],
+			strings.Join(jsonCost.Tags, ","),
+			jsonCost.Installments,
+			jsonCost.InterestRate,
+			jsonCost.Fee,
+		)
+	}
+
+	export.Respond(c, table)
+}
+
+func loadCategoryNames(categories []cost.Category, err error) map[uint]string {
+	result := make(map[uint]string)
+	if err != nil {
+		return result
+	}
+	for _, category := range categories {
+		result[category.ID] = category.Name
+	}
+	return result
+}
+
+func categoryKey(categoryID *uint) uint {
+	if categoryID == nil {
+		return 0
+	}
+	return *categoryID
+}
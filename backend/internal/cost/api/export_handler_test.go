@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/storage"
+)
+
+func TestExportFixedCosts_CanBeImportedAgain(t *testing.T) {
+	categoryID := uint(5)
+	mockRepo := &storage.MockRepository{
+		Categories: []cost.Category{{ID: 5, WorkspaceID: 1, Name: "Wohnen"}},
+		FixedCosts: []cost.FixedCost{
+			{
+				ID: 1, WorkspaceID: 1, Name: "Miete", Amount: -1200,
+				From:       &types.YearMonth{Year: 2025, Month: 1},
+				Recurrence: cost.Recurrence{Frequency: cost.FrequencyMonthly, Interval: 1, Anchor: &types.YearMonth{Year: 2025, Month: 1}},
+				CategoryID: &categoryID,
+				Tags:       cost.Tags{"wohnung"},
+			},
+			{
+				ID: 2, WorkspaceID: 1, Name: "Krankenkasse", Amount: -350, Currency: "CHF",
+				Recurrence: cost.Recurrence{Frequency: cost.FrequencyMonthly, Interval: 3, Anchor: &types.YearMonth{Year: 2025, Month: 2}},
+			},
+		},
+	}
+
+	handler := &FixedCostHandler{Repo: mockRepo}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+	r.GET("/export/costs", handler.ExportFixedCosts)
+
+	req, _ := http.NewRequest(http.MethodGet, "/export/costs?format=csv", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "text/csv") {
+		t.Errorf("Unexpected content type %s", w.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(w.Header().Get("Content-Disposition"), `filename="fixed-costs.csv"`) {
+		t.Errorf("Unexpected content disposition %s", w.Header().Get("Content-Disposition"))
+	}
+
+	_, result := postImport(setupImportRouter(mockRepo), map[string]interface{}{"csv": w.Body.String(), "dryRun": true})
+
+	if result.Valid != 2 || result.Invalid != 0 {
+		t.Fatalf("Expected the export to be importable, got %+v", result)
+	}
+
+	costs := make(map[string]*JsonFixedCost)
+	for _, row := range result.Rows {
+		costs[row.FixedCost.Name] = row.FixedCost
+	}
+
+	rent := costs["Miete"]
+	if rent.Amount != -1200 || *rent.CategoryID != 5 || rent.Tags[0] != "wohnung" {
+		t.Errorf("Unexpected rent: %+v", rent)
+	}
+
+	insurance := costs["Krankenkasse"]
+	if insurance.Currency != "CHF" || insurance.Recurrence.Interval != 3 || insurance.Recurrence.Anchor.Month != 2 {
+		t.Errorf("Unexpected insurance: %+v", insurance)
+	}
+}
+
+func TestExportFixedCosts_UnsupportedFormat(t *testing.T) {
+	handler := &FixedCostHandler{Repo: &storage.MockRepository{}}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/export/costs", handler.ExportFixedCosts)
+
+	req, _ := http.NewRequest(http.MethodGet, "/export/costs?format=pdf", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", w.Code)
+	}
+}
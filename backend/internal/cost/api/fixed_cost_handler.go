@@ -3,16 +3,25 @@ package api
 import (
 	"errors"
 	"net/http"
+	"slices"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/audit"
 	"wondee/finance-app-backend/internal/cost"
 	"wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/currency"
 	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/storage"
+	"wondee/finance-app-backend/internal/user"
 )
 
 type FixedCostHandler struct {
-	Repo repository.Repository
+	Repo       repository.Repository
+	Accounts   storage.AccountRepository
+	Workspaces storage.WorkspaceRepository
+	Audit      *audit.Log
+	Balance    BalanceMonitor
 }
 
 type Response struct {
@@ -21,16 +30,35 @@ type Response struct {
 	Quarterly      []JsonFixedCost `json:"quarterly"`
 	Halfyearly     []JsonFixedCost `json:"halfyearly"`
 	Yearly         []JsonFixedCost `json:"yearly"`
+	Custom         []JsonFixedCost `json:"custom"`
 }
 
 type JsonFixedCost struct {
-	ID       int              `json:"id"`
-	Name     string           `json:"name"`
-	Amount   int              `json:"amount"`
-	From     *types.YearMonth `json:"from"`
-	To       *types.YearMonth `json:"to"`
-	DueMonth int              `json:"dueMonth"`
-	IsSaving bool             `json:"isSaving"`
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	Amount        int              `json:"amount"`
+	CurrentAmount int              `json:"currentAmount"`
+	Currency      string           `json:"currency"`
+	From          *types.YearMonth `json:"from"`
+	To            *types.YearMonth `json:"to"`
+	DueMonth      int              `json:"dueMonth"`
+	IsSaving      bool             `json:"isSaving"`
+
+	Recurrence *cost.Recurrence `json:"recurrence,omitempty"`
+	RRule      string           `json:"rrule,omitempty"`
+
+	CategoryID *uint        `json:"categoryId"`
+	AccountID  *uint        `json:"accountId"`
+	Tags       []string     `json:"tags"`
+	Split      []cost.Share `json:"split"`
+
+	// Transfers move the amount from accountId to targetAccountId.
+	TargetAccountID *uint `json:"targetAccountId"`
+
+	EscalationRate  float64 `json:"escalationRate"`
+	EscalationMonth int     `json:"escalationMonth"`
+
+	Contract *cost.Contract `json:"contract,omitempty"`
 }
 
 func (h *FixedCostHandler) GetFixedCosts(c *gin.Context) {
@@ -48,9 +76,72 @@ func (h *FixedCostHandler) DeleteFixedCosts(c *gin.Context) {
 	}
 
 	workspaceID := h.getWorkspaceID(c)
-	h.Repo.DeleteFixedCost(id, workspaceID)
+
+	var before *cost.FixedCost
+	if h.Audit.Enabled() || h.Balance != nil {
+		before, _ = h.Repo.GetFixedCost(id, workspaceID)
+	}
+
+	notify := WatchBalance(h.Balance, workspaceID)
+	if err := h.Repo.DeleteFixedCost(id, workspaceID); err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	if before != nil {
+		notify(h.getUserID(c), before.Name)
+		h.Audit.Record(audit.Change{
+			WorkspaceID: workspaceID,
+			Actor:       h.getUserID(c),
+			EntityType:  audit.EntityFixedCost,
+			EntityID:    uint(id),
+			Action:      audit.ActionDelete,
+			Before:      ToJsonStruct(before),
+		})
+	}
+}
+
+// SaveFixedCost stores a fixed cost with an arbitrary recurrence rule, given
+// either as structured "recurrence" or as iCalendar "rrule".
+func (h *FixedCostHandler) SaveFixedCost(c *gin.Context) {
+	var jsonCost JsonFixedCost
+	if err := c.ShouldBindJSON(&jsonCost); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dbObject, err := ToDBStructWithRecurrence(&jsonCost)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dbObject.UserID = h.getUserID(c)
+	dbObject.WorkspaceID = h.getWorkspaceID(c)
+
+	if err := validateCategoryAssignment(h.Repo, dbObject.WorkspaceID, dbObject.CategoryID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validateAccountAssignment(h.Accounts, dbObject.WorkspaceID, dbObject.AccountID, dbObject.TargetAccountID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validateSplitMembers(h.Workspaces, dbObject.WorkspaceID, dbObject.Split); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if h.save(c, dbObject) {
+		c.JSON(http.StatusOK, ToJsonStruct(dbObject))
+	}
 }
 
+// The frequency specific endpoints below predate recurrence rules and are kept
+// for clients still sending a plain dueMonth.
+
 func (h *FixedCostHandler) SaveYearlyFixedCosts(c *gin.Context) {
 	h.saveFixedCost(c, func(dueMonth int) ([]int, error) {
 		if dueMonth < 0 || dueMonth > 12 {
@@ -105,57 +196,139 @@ func (h *FixedCostHandler) saveFixedCost(c *gin.Context, dueMonthConverter func(
 	dbObject.UserID = h.getUserID(c)
 	dbObject.WorkspaceID = h.getWorkspaceID(c)
 
-	h.Repo.SaveFixedObject(dbObject)
+	if err := validateCategoryAssignment(h.Repo, dbObject.WorkspaceID, dbObject.CategoryID); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := validateAccountAssignment(h.Accounts, dbObject.WorkspaceID, dbObject.AccountID, dbObject.TargetAccountID); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := validateSplitMembers(h.Workspaces, dbObject.WorkspaceID, dbObject.Split); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	h.save(c, dbObject)
+}
+
+// save stores the cost, records the change in the audit log and warns if
+// the projected balance falls below the threshold. It responds with an
+// error and returns false if the cost could not be stored.
+func (h *FixedCostHandler) save(c *gin.Context, dbObject *cost.FixedCost) bool {
+	change := audit.Change{
+		WorkspaceID: dbObject.WorkspaceID,
+		Actor:       dbObject.UserID,
+		EntityType:  audit.EntityFixedCost,
+		Action:      audit.ActionCreate,
+	}
+	if dbObject.ID != 0 && h.Audit.Enabled() {
+		if before, err := h.Repo.GetFixedCost(dbObject.ID, dbObject.WorkspaceID); err == nil {
+			change.Action = audit.ActionUpdate
+			change.Before = ToJsonStruct(before)
+		}
+	}
+
+	notify := WatchBalance(h.Balance, dbObject.WorkspaceID)
+	if err := h.Repo.SaveFixedObject(dbObject); err != nil {
+		c.Status(http.StatusInternalServerError)
+		return false
+	}
+	notify(dbObject.UserID, dbObject.Name)
+
+	change.EntityID = uint(dbObject.ID)
+	change.After = ToJsonStruct(dbObject)
+	h.Audit.Record(change)
+	return true
 }
 
 func (h *FixedCostHandler) createFixedCosts(workspaceID uint) Response {
 
 	costs := h.Repo.LoadFixedCosts(workspaceID)
-	totalAnnualBalance := 0
+	totalMonthlyBalance := 0.0
 	currentYearMonth := types.CurrentYearMonth()
 
 	monthly := make([]JsonFixedCost, 0)
 	quaterly := make([]JsonFixedCost, 0)
 	halfyearly := make([]JsonFixedCost, 0)
 	yearly := make([]JsonFixedCost, 0)
+	custom := make([]JsonFixedCost, 0)
 
 	for _, cost := range *costs {
 		if types.IsRelevant(currentYearMonth, cost.From, cost.To) {
-			totalAnnualBalance += (cost.Amount * len(cost.DueMonth))
+			totalMonthlyBalance += cost.MonthlyAverage(currentYearMonth)
 		}
 
-		switch month := len(cost.DueMonth); month {
-		case 1:
+		switch monthsBetweenPayments(cost.Schedule()) {
+		case 12:
 			yearly = append(yearly, ToJsonStruct(&cost))
-		case 2:
+		case 6:
 			halfyearly = append(halfyearly, ToJsonStruct(&cost))
-		case 4:
+		case 3:
 			quaterly = append(quaterly, ToJsonStruct(&cost))
-		case 12:
+		case 1:
 			monthly = append(monthly, ToJsonStruct(&cost))
 		default:
-			panic("only 1, 2, 4 and 12 is valid, but was " + strconv.Itoa(month))
+			custom = append(custom, ToJsonStruct(&cost))
 		}
 	}
 
 	return Response{
-		CurrentBalance: float64(totalAnnualBalance) / 12.0,
+		CurrentBalance: totalMonthlyBalance,
 		Monthly:        monthly,
 		Quarterly:      quaterly,
 		Halfyearly:     halfyearly,
 		Yearly:         yearly,
+		Custom:         custom,
+	}
+}
+
+// monthsBetweenPayments returns the distance between two payments in months,
+// or 0 for rules that are not month based.
+func monthsBetweenPayments(rule cost.Recurrence) int {
+	switch rule.Frequency {
+	case cost.FrequencyMonthly:
+		return rule.Interval
+	case cost.FrequencyYearly:
+		return rule.Interval * 12
+	default:
+		return 0
 	}
 }
 
 func ToJsonStruct(dbObject *cost.FixedCost) JsonFixedCost {
+	schedule := dbObject.Schedule()
+
+	dueMonth := 1
+	if schedule.Anchor != nil {
+		dueMonth = schedule.Anchor.Month
+	}
+
 	return JsonFixedCost{
-		ID:       dbObject.ID,
-		Name:     dbObject.Name,
-		Amount:   dbObject.Amount,
-		From:     dbObject.From,
-		To:       dbObject.To,
-		DueMonth: dbObject.DueMonth[0],
-		IsSaving: dbObject.IsSaving,
+		ID:            dbObject.ID,
+		Name:          dbObject.Name,
+		Amount:        dbObject.Amount,
+		CurrentAmount: dbObject.AmountAt(types.CurrentYearMonth()),
+		Currency:      dbObject.Currency,
+		From:          dbObject.From,
+		To:            dbObject.To,
+		DueMonth:      dueMonth,
+		IsSaving:      dbObject.IsSaving,
+		Recurrence:    &schedule,
+		RRule:         schedule.RRule(),
+		CategoryID:    dbObject.CategoryID,
+		AccountID:     dbObject.AccountID,
+		Tags:          tagsOrEmpty(dbObject.Tags),
+		Split:         splitOrEmpty(dbObject.Split),
+
+		TargetAccountID: dbObject.TargetAccountID,
+
+		EscalationRate:  dbObject.EscalationRate,
+		EscalationMonth: dbObject.EscalationMonth,
+
+		Contract: contractOrNil(dbObject.Contract),
 	}
 }
 
@@ -171,17 +344,214 @@ func ToDBStruct(
 		return nil, err
 	}
 
+	if err := validateEscalation(jsonObject); err != nil {
+		return nil, err
+	}
+
+	if err := validateTransfer(jsonObject.IsSaving, jsonObject.TargetAccountID); err != nil {
+		return nil, err
+	}
+
+	contract, err := toContract(jsonObject)
+	if err != nil {
+		return nil, err
+	}
+
+	split, err := toSplit(jsonObject.Split)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := currency.Normalize(jsonObject.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cost.FixedCost{
+		ID:         jsonObject.ID,
+		Name:       jsonObject.Name,
+		Amount:     jsonObject.Amount,
+		Currency:   code,
+		From:       jsonObject.From,
+		To:         jsonObject.To,
+		DueMonth:   value,
+		IsSaving:   jsonObject.IsSaving,
+		CategoryID: jsonObject.CategoryID,
+		AccountID:  jsonObject.AccountID,
+		Tags:       cost.NormalizeTags(jsonObject.Tags),
+		Split:      split,
+
+		TargetAccountID: jsonObject.TargetAccountID,
+
+		EscalationRate:  jsonObject.EscalationRate,
+		EscalationMonth: jsonObject.EscalationMonth,
+
+		Contract: contract,
+	}, nil
+}
+
+// ToDBStructWithRecurrence converts a cost sent to the unified endpoint. A
+// missing anchor defaults to the start of the validity period or, failing
+// that, the current month.
+func ToDBStructWithRecurrence(jsonObject *JsonFixedCost) (*cost.FixedCost, error) {
+	var recurrence cost.Recurrence
+
+	switch {
+	case jsonObject.RRule != "":
+		parsed, err := cost.ParseRRule(jsonObject.RRule)
+		if err != nil {
+			return nil, err
+		}
+		recurrence = parsed
+	case jsonObject.Recurrence != nil:
+		recurrence = *jsonObject.Recurrence
+	default:
+		return nil, errors.New("either recurrence or rrule is required")
+	}
+
+	if recurrence.Anchor == nil {
+		if jsonObject.From != nil {
+			recurrence.Anchor = jsonObject.From
+		} else {
+			recurrence.Anchor = types.CurrentYearMonth()
+		}
+	}
+
+	if recurrence.Frequency == cost.FrequencyWeekly && recurrence.AnchorDay == 0 {
+		recurrence.AnchorDay = 1
+	}
+
+	if err := recurrence.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := validateEscalation(jsonObject); err != nil {
+		return nil, err
+	}
+
+	if err := validateTransfer(jsonObject.IsSaving, jsonObject.TargetAccountID); err != nil {
+		return nil, err
+	}
+
+	contract, err := toContract(jsonObject)
+	if err != nil {
+		return nil, err
+	}
+
+	split, err := toSplit(jsonObject.Split)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := currency.Normalize(jsonObject.Currency)
+	if err != nil {
+		return nil, err
+	}
+
 	return &cost.FixedCost{
-		ID:       jsonObject.ID,
-		Name:     jsonObject.Name,
-		Amount:   jsonObject.Amount,
-		From:     jsonObject.From,
-		To:       jsonObject.To,
-		DueMonth: value,
-		IsSaving: jsonObject.IsSaving,
+		ID:         jsonObject.ID,
+		Name:       jsonObject.Name,
+		Amount:     jsonObject.Amount,
+		Currency:   code,
+		From:       jsonObject.From,
+		To:         jsonObject.To,
+		Recurrence: recurrence,
+		IsSaving:   jsonObject.IsSaving,
+		CategoryID: jsonObject.CategoryID,
+		AccountID:  jsonObject.AccountID,
+		Tags:       cost.NormalizeTags(jsonObject.Tags),
+		Split:      split,
+
+		TargetAccountID: jsonObject.TargetAccountID,
+
+		EscalationRate:  jsonObject.EscalationRate,
+		EscalationMonth: jsonObject.EscalationMonth,
+
+		Contract: contract,
 	}, nil
 }
 
+func validateEscalation(jsonObject *JsonFixedCost) error {
+	if jsonObject.EscalationRate == 0 {
+		return nil
+	}
+
+	if jsonObject.EscalationRate < -100 || jsonObject.EscalationRate > 100 {
+		return errors.New("escalationRate must be between -100 and 100")
+	}
+
+	if jsonObject.EscalationMonth < 1 || jsonObject.EscalationMonth > 12 {
+		return errors.New("escalationMonth must be between 1 and 12")
+	}
+
+	return nil
+}
+
+func toContract(jsonObject *JsonFixedCost) (cost.Contract, error) {
+	if jsonObject.Contract == nil {
+		return cost.Contract{}, nil
+	}
+
+	if err := jsonObject.Contract.Validate(); err != nil {
+		return cost.Contract{}, err
+	}
+
+	return *jsonObject.Contract, nil
+}
+
+func contractOrNil(contract cost.Contract) *cost.Contract {
+	if contract.IsEmpty() {
+		return nil
+	}
+	return &contract
+}
+
+func toSplit(shares []cost.Share) (cost.Split, error) {
+	if len(shares) == 0 {
+		return nil, nil
+	}
+
+	split := cost.Split(shares)
+	if err := split.Validate(); err != nil {
+		return nil, err
+	}
+	return split, nil
+}
+
+// validateSplitMembers ensures a split only gives shares to members of the
+// workspace. Without a workspace repository the shares are not checked.
+func validateSplitMembers(workspaces storage.WorkspaceRepository, workspaceID uint, split cost.Split) error {
+	if len(split) == 0 || workspaces == nil {
+		return nil
+	}
+
+	workspace, err := workspaces.GetWorkspaceByID(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	for _, share := range split {
+		if !slices.ContainsFunc(workspace.Users, func(u user.User) bool { return u.ID == share.UserID }) {
+			return errors.New("split must only contain members of the workspace")
+		}
+	}
+	return nil
+}
+
+func splitOrEmpty(split cost.Split) []cost.Share {
+	if split == nil {
+		return make([]cost.Share, 0)
+	}
+	return split
+}
+
+func tagsOrEmpty(tags cost.Tags) []string {
+	if tags == nil {
+		return make([]string, 0)
+	}
+	return tags
+}
+
 func (h *FixedCostHandler) getUserID(c *gin.Context) uint {
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -196,4 +566,4 @@ func (h *FixedCostHandler) getWorkspaceID(c *gin.Context) uint {
 		return 0
 	}
 	return workspaceID.(uint)
-}
\ No newline at end of file
+}
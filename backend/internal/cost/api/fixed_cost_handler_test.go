@@ -94,4 +94,82 @@ func TestCreateFixedCosts(t *testing.T) {
 	if len(resp.Yearly) != 1 {
 		t.Errorf("Expected 1 yearly cost, got %d", len(resp.Yearly))
 	}
-}
\ No newline at end of file
+}
+func TestToDBStructWithRecurrence(t *testing.T) {
+	from := &types.YearMonth{Year: 2024, Month: 9}
+
+	fc, err := ToDBStructWithRecurrence(&JsonFixedCost{
+		Name:   "Kindergarten",
+		Amount: -300,
+		From:   from,
+		RRule:  "FREQ=MONTHLY;INTERVAL=2",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fc.Recurrence.Interval != 2 || *fc.Recurrence.Anchor != *from {
+		t.Errorf("Expected bi-monthly rule anchored at From, got %+v", fc.Recurrence)
+	}
+
+	_, err = ToDBStructWithRecurrence(&JsonFixedCost{Name: "Missing rule", Amount: -10})
+	if err == nil {
+		t.Error("Expected error when neither recurrence nor rrule is given")
+	}
+
+	_, err = ToDBStructWithRecurrence(&JsonFixedCost{
+		Name:       "Invalid",
+		Amount:     -10,
+		Recurrence: &cost.Recurrence{Frequency: cost.FrequencyMonthly, Interval: 0},
+	})
+	if err == nil {
+		t.Error("Expected error for interval 0")
+	}
+}
+
+func TestCreateFixedCostsWithCustomRecurrence(t *testing.T) {
+	var workspaceID uint = 1
+
+	mockRepo := &storage.MockRepository{
+		FixedCosts: []cost.FixedCost{
+			{ID: 1, WorkspaceID: workspaceID, Name: "Inspection", Amount: -240, Recurrence: cost.Recurrence{
+				Frequency: cost.FrequencyYearly, Interval: 2, Anchor: &types.YearMonth{Year: 2024, Month: 5},
+			}},
+			{ID: 2, WorkspaceID: workspaceID, Name: "Bi-monthly", Amount: -60, Recurrence: cost.Recurrence{
+				Frequency: cost.FrequencyMonthly, Interval: 6, Anchor: &types.YearMonth{Year: 2024, Month: 2},
+			}},
+		},
+	}
+	handler := &FixedCostHandler{Repo: mockRepo}
+
+	resp := handler.createFixedCosts(workspaceID)
+
+	if len(resp.Custom) != 1 || resp.Custom[0].ID != 1 {
+		t.Errorf("Expected biennial cost in custom bucket, got %+v", resp.Custom)
+	}
+	if len(resp.Halfyearly) != 1 || resp.Halfyearly[0].DueMonth != 2 {
+		t.Errorf("Expected six-monthly rule in halfyearly bucket, got %+v", resp.Halfyearly)
+	}
+	// -240 / 24 + -60 / 6 = -20
+	if resp.CurrentBalance != -20 {
+		t.Errorf("Expected current balance -20, got %f", resp.CurrentBalance)
+	}
+}
+
+func TestToDBStructValidatesEscalation(t *testing.T) {
+	converter := func(m int) ([]int, error) {
+		return []int{m}, nil
+	}
+
+	fc, err := ToDBStruct(&JsonFixedCost{Amount: -50, DueMonth: 1, EscalationRate: 2.5, EscalationMonth: 1}, converter)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fc.EscalationRate != 2.5 || fc.EscalationMonth != 1 {
+		t.Errorf("Escalation not mapped: %+v", fc)
+	}
+
+	_, err = ToDBStruct(&JsonFixedCost{Amount: -50, DueMonth: 1, EscalationRate: 2.5}, converter)
+	if err == nil {
+		t.Error("Expected error for escalation without anniversary month")
+	}
+}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/audit"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/cost/csvimport"
+	"wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+const (
+	ImportKindFixed   = "fixed"
+	ImportKindSpecial = "special"
+)
+
+type ImportHandler struct {
+	Repo    repository.Repository
+	Audit   *audit.Log
+	Balance BalanceMonitor
+}
+
+type ImportRequest struct {
+	CSV          string            `json:"csv" binding:"required"`
+	Delimiter    string            `json:"delimiter"`
+	DecimalComma bool              `json:"decimalComma"`
+	Columns      map[string]string `json:"columns"`
+	DryRun       bool              `json:"dryRun"`
+}
+
+type ImportResult struct {
+	DryRun   bool        `json:"dryRun"`
+	Valid    int         `json:"valid"`
+	Invalid  int         `json:"invalid"`
+	Imported int         `json:"imported"`
+	Rows     []ImportRow `json:"rows"`
+}
+
+type ImportRow struct {
+	Line        int              `json:"line"`
+	Kind        string           `json:"kind"`
+	FixedCost   *JsonFixedCost   `json:"fixedCost,omitempty"`
+	SpecialCost *JsonSpecialCost `json:"specialCost,omitempty"`
+	Errors      []string         `json:"errors"`
+}
+
+type importedRow struct {
+	ImportRow
+	fixedCost   *cost.FixedCost
+	specialCost *cost.SpecialCost
+}
+
+// ImportCosts reads fixed and special costs from CSV. A dry run only returns
+// the preview; otherwise all rows are stored in one transaction, provided
+// none of them has errors.
+func (h *ImportHandler) ImportCosts(c *gin.Context) {
+	var req ImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	options := csvimport.Options{
+		Delimiter:    req.Delimiter,
+		DecimalComma: req.DecimalComma,
+		Columns:      req.Columns,
+	}
+
+	rows, err := csvimport.Parse(req.CSV, options)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workspaceID := h.getWorkspaceID(c)
+	categories, err := h.Repo.LoadCategories(workspaceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load categories"})
+		return
+	}
+	categoryIDs := categoriesByName(categories)
+
+	result := ImportResult{DryRun: req.DryRun, Rows: make([]ImportRow, 0, len(rows))}
+	var fixedCosts []cost.FixedCost
+	var specialCosts []cost.SpecialCost
+
+	for _, row := range rows {
+		imported := convertImportRow(row, options, categoryIDs)
+
+		if len(imported.Errors) > 0 {
+			result.Invalid++
+		} else {
+			result.Valid++
+			if imported.fixedCost != nil {
+				imported.fixedCost.UserID = h.getUserID(c)
+				imported.fixedCost.WorkspaceID = workspaceID
+				fixedCosts = append(fixedCosts, *imported.fixedCost)
+			} else {
+				imported.specialCost.UserID = h.getUserID(c)
+				imported.specialCost.WorkspaceID = workspaceID
+				specialCosts = append(specialCosts, *imported.specialCost)
+			}
+		}
+
+		result.Rows = append(result.Rows, imported.ImportRow)
+	}
+
+	if req.DryRun {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
+	if result.Invalid > 0 {
+		c.JSON(http.StatusUnprocessableEntity, result)
+		return
+	}
+
+	notify := WatchBalance(h.Balance, workspaceID)
+	if err := h.Repo.ImportCosts(fixedCosts, specialCosts); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import costs"})
+		return
+	}
+	if len(fixedCosts) > 0 || len(specialCosts) > 0 {
+		notify(h.getUserID(c), importedNames(fixedCosts, specialCosts))
+	}
+
+	for i := range fixedCosts {
+		h.Audit.Record(audit.Change{
+			WorkspaceID: workspaceID,
+			Actor:       fixedCosts[i].UserID,
+			EntityType:  audit.EntityFixedCost,
+			EntityID:    uint(fixedCosts[i].ID),
+			Action:      audit.ActionCreate,
+			After:       ToJsonStruct(&fixedCosts[i]),
+		})
+	}
+	for i := range specialCosts {
+		h.Audit.Record(audit.Change{
+			WorkspaceID: workspaceID,
+			Actor:       specialCosts[i].UserID,
+			EntityType:  audit.EntitySpecialCost,
+			EntityID:    uint(specialCosts[i].ID),
+			Action:      audit.ActionCreate,
+			After:       ToJsonSpecialCost(&specialCosts[i]),
+		})
+	}
+
+	result.Imported = len(fixedCosts) + len(specialCosts)
+	c.JSON(http.StatusOK, result)
+}
+
+// importedNames lists the names of the imported costs for the low balance
+// notification.
+func importedNames(fixedCosts []cost.FixedCost, specialCosts []cost.SpecialCost) string {
+	names := make([]string, 0, len(fixedCosts)+len(specialCosts))
+	for _, fc := range fixedCosts {
+		names = append(names, fc.Name)
+	}
+	for _, sc := range specialCosts {
+		names = append(names, sc.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func convertImportRow(row csvimport.Row, options csvimport.Options, categoryIDs map[string][]uint) importedRow {
+	result := importedRow{ImportRow: ImportRow{Line: row.Line, Kind: ImportKindFixed, Errors: make([]string, 0)}}
+	addError := func(err error) {
+		result.Errors = append(result.Errors, err.Error())
+	}
+
+	name := row.Get(csvimport.FieldName)
+	if name == "" {
+		addError(errors.New("name is required"))
+	}
+
+	amount, err := csvimport.ParseAmount(row.Get(csvimport.FieldAmount), options.DecimalComma)
+	if err != nil {
+		addError(err)
+	}
+
+	isSaving, err := csvimport.ParseBool(row.Get(csvimport.FieldIsSaving))
+	if err != nil {
+		addError(err)
+	}
+
+	categoryID, err := lookupCategory(categoryIDs, row.Get(csvimport.FieldCategory))
+	if err != nil {
+		addError(err)
+	}
+
+	tags := strings.Split(row.Get(csvimport.FieldTags), ",")
+
+	special, err := csvimport.IsSpecial(row)
+	if err != nil {
+		addError(err)
+	}
+
+	if special {
+		result.Kind = ImportKindSpecial
+
+		jsonCost := JsonSpecialCost{
+			Name:       name,
+			Amount:     amount,
+			Currency:   row.Get(csvimport.FieldCurrency),
+			IsSaving:   isSaving,
+			CategoryID: categoryID,
+			Tags:       tags,
+		}
+		jsonCost.DueDate = parseOptionalYearMonth(row.Get(csvimport.FieldDueDate), addError)
+		if jsonCost.DueDate == nil {
+			addError(errors.New("dueDate is required for special costs"))
+		}
+
+		if len(result.Errors) > 0 {
+			return result
+		}
+
+		dbObject, err := ToDBSpecialCost(&jsonCost)
+		if err != nil {
+			addError(err)
+			return result
+		}
+
+		preview := ToJsonSpecialCost(dbObject)
+		result.SpecialCost = &preview
+		result.specialCost = dbObject
+		return result
+	}
+
+	jsonCost := JsonFixedCost{
+		Name:       name,
+		Amount:     amount,
+		Currency:   row.Get(csvimport.FieldCurrency),
+		IsSaving:   isSaving,
+		CategoryID: categoryID,
+		Tags:       tags,
+	}
+	jsonCost.From = parseOptionalYearMonth(row.Get(csvimport.FieldFrom), addError)
+	jsonCost.To = parseOptionalYearMonth(row.Get(csvimport.FieldTo), addError)
+
+	recurrence, err := csvimport.ParseFrequency(row.Get(csvimport.FieldFrequency))
+	if err != nil {
+		addError(err)
+	}
+
+	if value := row.Get(csvimport.FieldDueMonth); value != "" && recurrence.Anchor == nil {
+		month, err := csvimport.ParseMonth(value)
+		if err != nil {
+			addError(err)
+		} else {
+			year := types.CurrentYearMonth().Year
+			if jsonCost.From != nil {
+				year = jsonCost.From.Year
+			}
+			recurrence.Anchor = &types.YearMonth{Year: year, Month: month}
+		}
+	}
+	jsonCost.Recurrence = &recurrence
+
+	if len(result.Errors) > 0 {
+		return result
+	}
+
+	dbObject, err := ToDBStructWithRecurrence(&jsonCost)
+	if err != nil {
+		addError(err)
+		return result
+	}
+
+	preview := ToJsonStruct(dbObject)
+	result.FixedCost = &preview
+	result.fixedCost = dbObject
+	return result
+}
+
+func parseOptionalYearMonth(value string, addError func(error)) *types.YearMonth {
+	if value == "" {
+		return nil
+	}
+
+	yearMonth, err := csvimport.ParseYearMonth(value)
+	if err != nil {
+		addError(err)
+		return nil
+	}
+	return yearMonth
+}
+
+func categoriesByName(categories []cost.Category) map[string][]uint {
+	result := make(map[string][]uint)
+	for _, category := range categories {
+		key := strings.ToLower(category.Name)
+		result[key] = append(result[key], category.ID)
+	}
+	return result
+}
+
+func lookupCategory(categoryIDs map[string][]uint, name string) (*uint, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	ids := categoryIDs[strings.ToLower(name)]
+	switch len(ids) {
+	case 0:
+		return nil, fmt.Errorf("unknown category %q", name)
+	case 1:
+		return &ids[0], nil
+	default:
+		return nil, fmt.Errorf("category name %q is ambiguous", name)
+	}
+}
+
+func (h *ImportHandler) getUserID(c *gin.Context) uint {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return 0
+	}
+	return userID.(uint)
+}
+
+func (h *ImportHandler) getWorkspaceID(c *gin.Context) uint {
+	workspaceID, exists := c.Get("workspace_id")
+	if !exists {
+		return 0
+	}
+	return workspaceID.(uint)
+}
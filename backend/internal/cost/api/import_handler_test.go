@@ -0,0 +1,132 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/storage"
+)
+
+const importCSV = "Typ;Bezeichnung;Betrag;Währung;Rhythmus;Monat;Von;Datum;Kategorie\n" +
+	"fix;Miete;-1.200,00;;monatlich;;01.2025;;Wohnen\n" +
+	"fix;Krankenkasse;-350,50;CHF;vierteljährlich;Februar;;;\n" +
+	"sonder;Urlaub;-2.000;;;;;07.2025;\n"
+
+func setupImportRouter(mockRepo *storage.MockRepository) *gin.Engine {
+	handler := &ImportHandler{Repo: mockRepo}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+
+	r.POST("/import/costs", handler.ImportCosts)
+
+	return r
+}
+
+func postImport(r *gin.Engine, body map[string]interface{}) (*httptest.ResponseRecorder, ImportResult) {
+	reqBody, _ := json.Marshal(body)
+	req, _ := http.NewRequest(http.MethodPost, "/import/costs", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var result ImportResult
+	json.Unmarshal(w.Body.Bytes(), &result)
+	return w, result
+}
+
+func TestImportCosts_DryRunPreviewsWithoutSaving(t *testing.T) {
+	mockRepo := &storage.MockRepository{
+		Categories: []cost.Category{{ID: 5, WorkspaceID: 1, Name: "Wohnen"}},
+	}
+	r := setupImportRouter(mockRepo)
+
+	w, result := postImport(r, map[string]interface{}{"csv": importCSV, "decimalComma": true, "dryRun": true})
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if result.Valid != 3 || result.Invalid != 0 || result.Imported != 0 {
+		t.Errorf("Unexpected counts: %+v", result)
+	}
+	if len(mockRepo.FixedCosts) != 0 || len(mockRepo.SpecialCosts) != 0 {
+		t.Error("Expected nothing to be saved on a dry run")
+	}
+
+	rent := result.Rows[0].FixedCost
+	if rent == nil || rent.Amount != -1200 || rent.CategoryID == nil || *rent.CategoryID != 5 {
+		t.Errorf("Unexpected rent preview: %+v", rent)
+	}
+
+	insurance := result.Rows[1].FixedCost
+	if insurance == nil || insurance.Currency != "CHF" || insurance.Recurrence.Interval != 3 || insurance.Recurrence.Anchor.Month != 2 {
+		t.Errorf("Unexpected insurance preview: %+v", insurance)
+	}
+
+	if result.Rows[2].Kind != ImportKindSpecial || result.Rows[2].SpecialCost.DueDate.Month != 7 {
+		t.Errorf("Unexpected special cost preview: %+v", result.Rows[2])
+	}
+}
+
+func TestImportCosts_CommitsAllRows(t *testing.T) {
+	mockRepo := &storage.MockRepository{
+		Categories: []cost.Category{{ID: 5, WorkspaceID: 1, Name: "Wohnen"}},
+	}
+	r := setupImportRouter(mockRepo)
+
+	w, result := postImport(r, map[string]interface{}{"csv": importCSV, "decimalComma": true})
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if result.Imported != 3 || len(mockRepo.FixedCosts) != 2 || len(mockRepo.SpecialCosts) != 1 {
+		t.Errorf("Expected 2 fixed and 1 special cost, got %+v", result)
+	}
+	if mockRepo.FixedCosts[0].WorkspaceID != 1 || mockRepo.FixedCosts[0].UserID != 1 {
+		t.Errorf("Expected costs to belong to the workspace: %+v", mockRepo.FixedCosts[0])
+	}
+}
+
+func TestImportCosts_RejectsAllRowsOnErrors(t *testing.T) {
+	mockRepo := &storage.MockRepository{}
+	r := setupImportRouter(mockRepo)
+
+	csv := "Bezeichnung;Betrag;Rhythmus;Kategorie\n" +
+		"Miete;-900;monatlich;\n" +
+		";abc;ab und zu;Unbekannt\n"
+
+	w, result := postImport(r, map[string]interface{}{"csv": csv, "decimalComma": true})
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected 422, got %d: %s", w.Code, w.Body.String())
+	}
+	if result.Valid != 1 || result.Invalid != 1 || result.Rows[1].Line != 3 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if len(result.Rows[1].Errors) != 4 {
+		t.Errorf("Expected errors for name, amount, category and frequency, got %v", result.Rows[1].Errors)
+	}
+	if len(mockRepo.FixedCosts) != 0 {
+		t.Error("Expected nothing to be saved")
+	}
+}
+
+func TestImportCosts_InvalidHeader(t *testing.T) {
+	r := setupImportRouter(&storage.MockRepository{})
+
+	w, _ := postImport(r, map[string]interface{}{"csv": "Foo;Bar\n1;2\n"})
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", w.Code)
+	}
+}
@@ -1,25 +1,59 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/audit"
 	"wondee/finance-app-backend/internal/cost"
 	"wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/currency"
 	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/storage"
 )
 
+// maxInstallments limits plans to 30 years of monthly payments.
+const maxInstallments = 360
+
 type SpecialCostHandler struct {
-	Repo repository.Repository
+	Repo       repository.Repository
+	Accounts   storage.AccountRepository
+	Workspaces storage.WorkspaceRepository
+	Audit      *audit.Log
+	Balance    BalanceMonitor
 }
 
 type JsonSpecialCost struct {
 	ID       int              `json:"id"`
 	Name     string           `json:"name"`
 	Amount   int              `json:"amount"`
+	Currency string           `json:"currency"`
 	DueDate  *types.YearMonth `json:"dueDate"`
 	IsSaving bool             `json:"isSaving"`
+
+	CategoryID *uint        `json:"categoryId"`
+	AccountID  *uint        `json:"accountId"`
+	Tags       []string     `json:"tags"`
+	Split      []cost.Share `json:"split"`
+
+	// Transfers move the amount from accountId to targetAccountId.
+	TargetAccountID *uint `json:"targetAccountId"`
+
+	// Installment plan; TotalAmount and Payments are only returned.
+	Installments int            `json:"installments"`
+	InterestRate float64        `json:"interestRate"`
+	Fee          int            `json:"fee"`
+	TotalAmount  int            `json:"totalAmount"`
+	Payments     []cost.Payment `json:"payments,omitempty"`
+
+	// Estimate; ExpectedAmount is only returned.
+	MinAmount      *int     `json:"minAmount"`
+	MaxAmount      *int     `json:"maxAmount"`
+	Probability    *float64 `json:"probability"`
+	ExpectedAmount int      `json:"expectedAmount"`
 }
 
 func (h *SpecialCostHandler) GetSpecialCosts(c *gin.Context) {
@@ -44,21 +78,167 @@ func (h *SpecialCostHandler) SaveSpecialCosts(c *gin.Context) {
 
 	dbObject.UserID = h.getUserID(c)
 	dbObject.WorkspaceID = h.getWorkspaceID(c)
-	h.Repo.SaveSpecialCost(dbObject)
+
+	if err := validateCategoryAssignment(h.Repo, dbObject.WorkspaceID, dbObject.CategoryID); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := validateAccountAssignment(h.Accounts, dbObject.WorkspaceID, dbObject.AccountID, dbObject.TargetAccountID); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := validateSplitMembers(h.Workspaces, dbObject.WorkspaceID, dbObject.Split); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	change := audit.Change{
+		WorkspaceID: dbObject.WorkspaceID,
+		Actor:       dbObject.UserID,
+		EntityType:  audit.EntitySpecialCost,
+		Action:      audit.ActionCreate,
+	}
+	if before := h.findSpecialCost(dbObject.ID, dbObject.WorkspaceID); before != nil {
+		change.Action = audit.ActionUpdate
+		change.Before = ToJsonSpecialCost(before)
+	}
+
+	notify := WatchBalance(h.Balance, dbObject.WorkspaceID)
+	if err := h.Repo.SaveSpecialCost(dbObject); err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	notify(dbObject.UserID, dbObject.Name)
+
+	change.EntityID = uint(dbObject.ID)
+	change.After = ToJsonSpecialCost(dbObject)
+	h.Audit.Record(change)
 }
 
 func ToDBSpecialCost(jsonCost *JsonSpecialCost) (*cost.SpecialCost, error) {
 	// Validation removed to support Wealth Extraction (IsSaving=true, Amount>0)
 
+	code, err := currency.Normalize(jsonCost.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	split, err := toSplit(jsonCost.Split)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateInstallments(jsonCost); err != nil {
+		return nil, err
+	}
+
+	if err := validateTransfer(jsonCost.IsSaving, jsonCost.TargetAccountID); err != nil {
+		return nil, err
+	}
+
+	if err := validateEstimate(jsonCost); err != nil {
+		return nil, err
+	}
+
 	return &cost.SpecialCost{
-		ID:       jsonCost.ID,
-		Name:     jsonCost.Name,
-		Amount:   jsonCost.Amount,
-		DueDate:  jsonCost.DueDate,
-		IsSaving: jsonCost.IsSaving,
+		ID:         jsonCost.ID,
+		Name:       jsonCost.Name,
+		Amount:     jsonCost.Amount,
+		Currency:   code,
+		DueDate:    jsonCost.DueDate,
+		IsSaving:   jsonCost.IsSaving,
+		CategoryID: jsonCost.CategoryID,
+		AccountID:  jsonCost.AccountID,
+		Tags:       cost.NormalizeTags(jsonCost.Tags),
+		Split:      split,
+
+		TargetAccountID: jsonCost.TargetAccountID,
+
+		Installments: jsonCost.Installments,
+		InterestRate: jsonCost.InterestRate,
+		Fee:          jsonCost.Fee,
+
+		MinAmount:   jsonCost.MinAmount,
+		MaxAmount:   jsonCost.MaxAmount,
+		Probability: jsonCost.Probability,
 	}, nil
 }
 
+func validateInstallments(jsonCost *JsonSpecialCost) error {
+	if jsonCost.Installments < 0 || jsonCost.Installments > maxInstallments {
+		return fmt.Errorf("installments must be between 0 and %d", maxInstallments)
+	}
+
+	if jsonCost.Installments <= 1 {
+		if jsonCost.InterestRate != 0 || jsonCost.Fee != 0 {
+			return errors.New("interestRate and fee require an installment plan")
+		}
+		return nil
+	}
+
+	if jsonCost.DueDate == nil {
+		return errors.New("dueDate of the first installment is required")
+	}
+
+	if jsonCost.InterestRate < 0 || jsonCost.InterestRate > 100 {
+		return errors.New("interestRate must be between 0 and 100")
+	}
+
+	if jsonCost.Fee < 0 {
+		return errors.New("fee must not be negative")
+	}
+
+	return nil
+}
+
+// validateEstimate checks that Amount lies within the range, compared by
+// absolute value so the range reads the same for costs and income.
+func validateEstimate(jsonCost *JsonSpecialCost) error {
+	if jsonCost.MinAmount == nil && jsonCost.MaxAmount == nil && jsonCost.Probability == nil {
+		return nil
+	}
+
+	if jsonCost.Installments > 1 {
+		return errors.New("an estimate cannot be paid in installments")
+	}
+
+	if jsonCost.TargetAccountID != nil {
+		return errors.New("a transfer cannot be an estimate")
+	}
+
+	if jsonCost.MinAmount != nil && !sameSign(*jsonCost.MinAmount, jsonCost.Amount) ||
+		jsonCost.MaxAmount != nil && !sameSign(*jsonCost.MaxAmount, jsonCost.Amount) {
+		return errors.New("minAmount and maxAmount must have the sign of amount")
+	}
+
+	if jsonCost.MinAmount != nil && abs(*jsonCost.MinAmount) > abs(jsonCost.Amount) {
+		return errors.New("minAmount must not exceed amount")
+	}
+
+	if jsonCost.MaxAmount != nil && abs(*jsonCost.MaxAmount) < abs(jsonCost.Amount) {
+		return errors.New("maxAmount must not be below amount")
+	}
+
+	if p := jsonCost.Probability; p != nil && (*p <= 0 || *p > 100) {
+		return errors.New("probability must be greater than 0 and at most 100")
+	}
+
+	return nil
+}
+
+func sameSign(a, b int) bool {
+	return a == 0 || b == 0 || (a < 0) == (b < 0)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
 func (h *SpecialCostHandler) DeleteSpecialCosts(c *gin.Context) {
 	param := c.Param("id")
 	id, err := strconv.Atoi(param)
@@ -69,7 +249,40 @@ func (h *SpecialCostHandler) DeleteSpecialCosts(c *gin.Context) {
 	}
 
 	workspaceID := h.getWorkspaceID(c)
-	h.Repo.DeleteSpecialCost(id, workspaceID)
+	before := h.findSpecialCost(id, workspaceID)
+
+	notify := WatchBalance(h.Balance, workspaceID)
+	if err := h.Repo.DeleteSpecialCost(id, workspaceID); err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	if before != nil {
+		notify(h.getUserID(c), before.Name)
+		h.Audit.Record(audit.Change{
+			WorkspaceID: workspaceID,
+			Actor:       h.getUserID(c),
+			EntityType:  audit.EntitySpecialCost,
+			EntityID:    uint(id),
+			Action:      audit.ActionDelete,
+			Before:      ToJsonSpecialCost(before),
+		})
+	}
+}
+
+// findSpecialCost returns the stored state of a cost for the audit log and
+// the balance monitor; nil if it does not exist or nobody needs it.
+func (h *SpecialCostHandler) findSpecialCost(id int, workspaceID uint) *cost.SpecialCost {
+	if id == 0 || (!h.Audit.Enabled() && h.Balance == nil) {
+		return nil
+	}
+
+	for _, sc := range *h.Repo.LoadSpecialCosts(workspaceID) {
+		if sc.ID == id {
+			return &sc
+		}
+	}
+	return nil
 }
 
 func (h *SpecialCostHandler) createSpecialCosts(workspaceID uint) (result []JsonSpecialCost) {
@@ -78,18 +291,47 @@ func (h *SpecialCostHandler) createSpecialCosts(workspaceID uint) (result []Json
 	specialCosts := h.Repo.LoadSpecialCosts(workspaceID)
 
 	for _, cost := range *specialCosts {
-		result = append(result, JsonSpecialCost{
-			ID:       cost.ID,
-			Name:     cost.Name,
-			Amount:   cost.Amount,
-			DueDate:  cost.DueDate,
-			IsSaving: cost.IsSaving,
-		})
+		result = append(result, ToJsonSpecialCost(&cost))
 	}
 
 	return
 }
 
+func ToJsonSpecialCost(dbObject *cost.SpecialCost) JsonSpecialCost {
+	return JsonSpecialCost{
+		ID:         dbObject.ID,
+		Name:       dbObject.Name,
+		Amount:     dbObject.Amount,
+		Currency:   dbObject.Currency,
+		DueDate:    dbObject.DueDate,
+		IsSaving:   dbObject.IsSaving,
+		CategoryID: dbObject.CategoryID,
+		AccountID:  dbObject.AccountID,
+		Tags:       tagsOrEmpty(dbObject.Tags),
+		Split:      splitOrEmpty(dbObject.Split),
+
+		TargetAccountID: dbObject.TargetAccountID,
+
+		Installments: dbObject.Installments,
+		InterestRate: dbObject.InterestRate,
+		Fee:          dbObject.Fee,
+		TotalAmount:  dbObject.TotalAmount(),
+		Payments:     installmentPayments(dbObject),
+
+		MinAmount:      dbObject.MinAmount,
+		MaxAmount:      dbObject.MaxAmount,
+		Probability:    dbObject.Probability,
+		ExpectedAmount: dbObject.ExpectedAmount(),
+	}
+}
+
+func installmentPayments(dbObject *cost.SpecialCost) []cost.Payment {
+	if !dbObject.IsInstallmentPlan() {
+		return nil
+	}
+	return dbObject.Payments()
+}
+
 func (h *SpecialCostHandler) getUserID(c *gin.Context) uint {
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -104,4 +346,4 @@ func (h *SpecialCostHandler) getWorkspaceID(c *gin.Context) uint {
 		return 0
 	}
 	return workspaceID.(uint)
-}
\ No newline at end of file
+}
@@ -2,9 +2,12 @@ package api
 
 import (
 	"testing"
+	"wondee/finance-app-backend/internal/account"
 	"wondee/finance-app-backend/internal/cost"
 	"wondee/finance-app-backend/internal/platform/types"
 	"wondee/finance-app-backend/internal/storage"
+	"wondee/finance-app-backend/internal/user"
+	"wondee/finance-app-backend/internal/workspace"
 )
 
 func TestToDBSpecialCost(t *testing.T) {
@@ -57,4 +60,140 @@ func TestCreateSpecialCosts(t *testing.T) {
 	if result[0].Name != "S1" {
 		t.Errorf("Expected first cost name S1, got %s", result[0].Name)
 	}
-}
\ No newline at end of file
+}
+func TestToDBSpecialCostWithInstallments(t *testing.T) {
+	dueDate := &types.YearMonth{Year: 2025, Month: 11}
+
+	sc, err := ToDBSpecialCost(&JsonSpecialCost{Name: "Sofa", Amount: -1000, DueDate: dueDate, Installments: 6, Fee: 15})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sc.Installments != 6 || sc.Fee != 15 {
+		t.Errorf("Installment plan not mapped: %+v", sc)
+	}
+
+	jsonCost := ToJsonSpecialCost(sc)
+	if jsonCost.TotalAmount != -1015 || len(jsonCost.Payments) != 6 {
+		t.Errorf("Expected total and payments of the plan, got %+v", jsonCost)
+	}
+
+	invalid := []*JsonSpecialCost{
+		{Amount: -1000, Installments: 6},
+		{Amount: -1000, DueDate: dueDate, Installments: -1},
+		{Amount: -1000, DueDate: dueDate, Installments: 6, InterestRate: -2},
+		{Amount: -1000, DueDate: dueDate, Installments: 6, Fee: -5},
+		{Amount: -1000, DueDate: dueDate, Fee: 5},
+	}
+	for _, jsonCost := range invalid {
+		if _, err := ToDBSpecialCost(jsonCost); err == nil {
+			t.Errorf("Expected error for %+v", jsonCost)
+		}
+	}
+}
+
+func TestToDBSpecialCostWithEstimate(t *testing.T) {
+	minAmount, maxAmount, probability := -400, -1500, 50.0
+
+	sc, err := ToDBSpecialCost(&JsonSpecialCost{Name: "Car repair", Amount: -800, MinAmount: &minAmount, MaxAmount: &maxAmount, Probability: &probability})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if *sc.MinAmount != -400 || *sc.MaxAmount != -1500 || *sc.Probability != 50 {
+		t.Errorf("Estimate not mapped: %+v", sc)
+	}
+	if jsonCost := ToJsonSpecialCost(sc); jsonCost.ExpectedAmount != -400 {
+		t.Errorf("Expected amount of -400, got %d", jsonCost.ExpectedAmount)
+	}
+
+	tooHigh, positive, none := -900, 400, 0.0
+	invalid := []*JsonSpecialCost{
+		{Amount: -800, MinAmount: &tooHigh},
+		{Amount: -800, MaxAmount: &minAmount},
+		{Amount: -800, MinAmount: &positive},
+		{Amount: -800, Probability: &none},
+		{Amount: -800, DueDate: &types.YearMonth{Year: 2025, Month: 1}, Installments: 3, MaxAmount: &maxAmount},
+	}
+	for _, jsonCost := range invalid {
+		if _, err := ToDBSpecialCost(jsonCost); err == nil {
+			t.Errorf("Expected error for %+v", jsonCost)
+		}
+	}
+}
+
+func TestValidateAccountAssignment(t *testing.T) {
+	mockRepo := &storage.MockRepository{
+		Accounts: []account.Account{
+			{ID: 1, WorkspaceID: 1, Name: "Girokonto", Type: account.TypeChecking},
+			{ID: 2, WorkspaceID: 2, Name: "Fremdes Konto", Type: account.TypeChecking},
+		},
+	}
+	own, foreign := uint(1), uint(2)
+
+	if err := validateAccountAssignment(mockRepo, 1, nil, nil); err != nil {
+		t.Errorf("Unexpected error without account: %v", err)
+	}
+	if err := validateAccountAssignment(mockRepo, 1, &own, nil); err != nil {
+		t.Errorf("Unexpected error for own account: %v", err)
+	}
+	if err := validateAccountAssignment(mockRepo, 1, &foreign, nil); err == nil {
+		t.Error("Expected error for account of another workspace")
+	}
+	if err := validateAccountAssignment(mockRepo, 1, nil, &foreign); err == nil {
+		t.Error("Expected error for transfer to account of another workspace")
+	}
+}
+
+func TestValidateSplitMembers(t *testing.T) {
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: 1, Users: []user.User{{ID: 1}, {ID: 2}}}},
+	}
+
+	members := cost.Split{{UserID: 1, Percent: 50}, {UserID: 2, Percent: 50}}
+	if err := validateSplitMembers(mockRepo, 1, members); err != nil {
+		t.Errorf("Unexpected error for split between members: %v", err)
+	}
+	if err := validateSplitMembers(mockRepo, 1, nil); err != nil {
+		t.Errorf("Unexpected error without split: %v", err)
+	}
+
+	stranger := cost.Split{{UserID: 1, Percent: 50}, {UserID: 9, Percent: 50}}
+	if err := validateSplitMembers(mockRepo, 1, stranger); err == nil {
+		t.Error("Expected error for share of a user outside the workspace")
+	}
+}
+
+func TestValidateTransfer(t *testing.T) {
+	savings := uint(2)
+	mockRepo := &storage.MockRepository{
+		Accounts: []account.Account{
+			{ID: 1, WorkspaceID: 1, Name: "Girokonto", Type: account.TypeChecking},
+			{ID: savings, WorkspaceID: 1, Name: "Tagesgeld", Type: account.TypeSavings},
+		},
+	}
+	checking := uint(1)
+
+	if err := validateAccountAssignment(mockRepo, 1, nil, &savings); err != nil {
+		t.Errorf("Unexpected error for transfer from the default account: %v", err)
+	}
+	if err := validateAccountAssignment(mockRepo, 1, nil, &checking); err == nil {
+		t.Error("Expected error for transfer from the default account to itself")
+	}
+	if err := validateAccountAssignment(mockRepo, 1, &savings, &savings); err == nil {
+		t.Error("Expected error for transfer to the same account")
+	}
+
+	invalid := []*JsonSpecialCost{
+		{Name: "Sparen", Amount: -500, IsSaving: true, TargetAccountID: &savings},
+		{Name: "Sparen", Amount: -500, Probability: new(float64), TargetAccountID: &savings},
+	}
+	for _, jsonCost := range invalid {
+		if _, err := ToDBSpecialCost(jsonCost); err == nil {
+			t.Errorf("Expected error for %+v", jsonCost)
+		}
+	}
+
+	sc, err := ToDBSpecialCost(&JsonSpecialCost{Name: "Steuerrücklage", Amount: -1200, TargetAccountID: &savings})
+	if err != nil || !sc.IsTransfer() || *ToJsonSpecialCost(sc).TargetAccountID != savings {
+		t.Errorf("Expected transfer to be mapped, got %+v (%v)", sc, err)
+	}
+}
@@ -0,0 +1,137 @@
+package cost
+
+import (
+	"database/sql/driver"
+	"strings"
+)
+
+// Category classifies fixed and special costs within a workspace. Categories
+// form a tree via ParentID; root categories have no parent.
+type Category struct {
+	ID          uint   `gorm:"primaryKey"`
+	WorkspaceID uint   `gorm:"not null;index"`
+	ParentID    *uint  `gorm:"index"`
+	Name        string `gorm:"not null"`
+}
+
+// Tags are free-form labels attached to a cost, stored comma separated.
+type Tags []string
+
+// NormalizeTags trims the given tags and drops empty and duplicate entries.
+func NormalizeTags(tags []string) Tags {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	result := make(Tags, 0, len(tags))
+	seen := make(map[string]bool)
+
+	for _, tag := range tags {
+		tag = strings.TrimSpace(strings.ReplaceAll(tag, ",", " "))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+
+	return result
+}
+
+func (this *Tags) Scan(value interface{}) error {
+	str, ok := value.(string)
+
+	if !ok || len(str) == 0 {
+		return nil
+	}
+
+	*this = strings.Split(str, ",")
+	return nil
+}
+
+func (this Tags) Value() (driver.Value, error) {
+	if len(this) == 0 {
+		return nil, nil
+	}
+
+	return strings.Join(this, ","), nil
+}
+
+// CategoryTree gives access to the hierarchy of a workspace's categories.
+type CategoryTree struct {
+	byID     map[uint]Category
+	children map[uint][]Category
+	roots    []Category
+}
+
+func NewCategoryTree(categories []Category) *CategoryTree {
+	tree := &CategoryTree{
+		byID:     make(map[uint]Category),
+		children: make(map[uint][]Category),
+	}
+
+	for _, category := range categories {
+		tree.byID[category.ID] = category
+	}
+
+	for _, category := range categories {
+		if category.ParentID != nil && tree.Contains(*category.ParentID) {
+			tree.children[*category.ParentID] = append(tree.children[*category.ParentID], category)
+		} else {
+			tree.roots = append(tree.roots, category)
+		}
+	}
+
+	return tree
+}
+
+// CategorySource provides the categories of a workspace.
+type CategorySource interface {
+	LoadCategories(workspaceID uint) ([]Category, error)
+}
+
+// LoadCategoryTree builds the category tree of a workspace. If the
+// categories cannot be read the tree is empty, so every cost counts as
+// uncategorized.
+func LoadCategoryTree(source CategorySource, workspaceID uint) *CategoryTree {
+	categories, err := source.LoadCategories(workspaceID)
+	if err != nil {
+		return NewCategoryTree(nil)
+	}
+	return NewCategoryTree(categories)
+}
+
+func (t *CategoryTree) Contains(id uint) bool {
+	_, ok := t.byID[id]
+	return ok
+}
+
+func (t *CategoryTree) Get(id uint) (Category, bool) {
+	category, ok := t.byID[id]
+	return category, ok
+}
+
+func (t *CategoryTree) Roots() []Category {
+	return t.roots
+}
+
+func (t *CategoryTree) Children(id uint) []Category {
+	return t.children[id]
+}
+
+// IsDescendant reports whether category id lies below ancestor (or is it).
+func (t *CategoryTree) IsDescendant(id, ancestor uint) bool {
+	for visited := 0; visited <= len(t.byID); visited++ {
+		if id == ancestor {
+			return true
+		}
+
+		category, ok := t.byID[id]
+		if !ok || category.ParentID == nil {
+			return false
+		}
+		id = *category.ParentID
+	}
+
+	return false
+}
@@ -0,0 +1,112 @@
+package cost
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+type NoticeUnit string
+
+const (
+	NoticeDays   NoticeUnit = "DAYS"
+	NoticeWeeks  NoticeUnit = "WEEKS"
+	NoticeMonths NoticeUnit = "MONTHS"
+)
+
+// Contract holds the optional contract terms behind a fixed cost.
+//
+// A contract runs until the end of MinimumTermEnd and is then extended by
+// RenewalMonths at a time, unless it is cancelled NoticePeriod before the end
+// of the current term. Without RenewalMonths the contract simply ends.
+type Contract struct {
+	Provider       string           `json:"provider"`
+	Number         string           `json:"number"`
+	MinimumTermEnd *types.YearMonth `json:"minimumTermEnd" gorm:"type:string"`
+	NoticePeriod   int              `json:"noticePeriod"`
+	NoticeUnit     NoticeUnit       `json:"noticeUnit"`
+	RenewalMonths  int              `json:"renewalMonths"`
+}
+
+// Deadline is the last day a contract can be cancelled for the end of a
+// term.
+type Deadline struct {
+	Date    time.Time
+	TermEnd time.Time
+}
+
+// IsEmpty reports whether no contract data was entered.
+func (c Contract) IsEmpty() bool {
+	return c == Contract{}
+}
+
+func (c Contract) Validate() error {
+	if c.MinimumTermEnd != nil && (c.MinimumTermEnd.Month < 1 || c.MinimumTermEnd.Month > 12) {
+		return errors.New("minimumTermEnd month must be between 1 and 12")
+	}
+
+	if c.NoticePeriod < 0 {
+		return errors.New("noticePeriod must not be negative")
+	}
+
+	if c.NoticePeriod > 0 {
+		switch c.NoticeUnit {
+		case NoticeDays, NoticeWeeks, NoticeMonths:
+		default:
+			return fmt.Errorf("unsupported noticeUnit %q", c.NoticeUnit)
+		}
+	}
+
+	if c.RenewalMonths < 0 {
+		return errors.New("renewalMonths must not be negative")
+	}
+
+	if c.RenewalMonths > 0 && c.MinimumTermEnd == nil {
+		return errors.New("renewalMonths requires minimumTermEnd")
+	}
+
+	return nil
+}
+
+// NextDeadline returns the first cancellation deadline on or after the given
+// day. There is none if the term end is unknown or the contract has already
+// run out without renewal.
+func (c Contract) NextDeadline(day time.Time) (Deadline, bool) {
+	if c.MinimumTermEnd == nil {
+		return Deadline{}, false
+	}
+
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	termEnd := c.MinimumTermEnd
+
+	for {
+		deadline := Deadline{Date: c.noticeBefore(termEnd), TermEnd: lastDayOf(termEnd)}
+		if !deadline.Date.Before(day) {
+			return deadline, true
+		}
+		if c.RenewalMonths == 0 {
+			return Deadline{}, false
+		}
+		termEnd = types.AddMonths(termEnd, c.RenewalMonths)
+	}
+}
+
+// noticeBefore returns the last day notice can be given for a term ending
+// with the given month.
+func (c Contract) noticeBefore(termEnd *types.YearMonth) time.Time {
+	switch c.NoticeUnit {
+	case NoticeMonths:
+		// Month based notice periods run to the end of a month.
+		return time.Date(termEnd.Year, time.Month(termEnd.Month-c.NoticePeriod)+1, 0, 0, 0, 0, 0, time.UTC)
+	case NoticeWeeks:
+		return lastDayOf(termEnd).AddDate(0, 0, -7*c.NoticePeriod)
+	default:
+		return lastDayOf(termEnd).AddDate(0, 0, -c.NoticePeriod)
+	}
+}
+
+func lastDayOf(ym *types.YearMonth) time.Time {
+	return time.Date(ym.Year, time.Month(ym.Month)+1, 0, 0, 0, 0, 0, time.UTC)
+}
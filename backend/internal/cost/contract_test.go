@@ -0,0 +1,83 @@
+package cost
+
+import (
+	"testing"
+	"time"
+
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+func TestNextDeadline(t *testing.T) {
+	termEnd := &types.YearMonth{Year: 2025, Month: 12}
+
+	tests := []struct {
+		name     string
+		contract Contract
+		day      string
+		deadline string
+		termEnd  string
+	}{
+		{"months", Contract{MinimumTermEnd: termEnd, NoticePeriod: 3, NoticeUnit: NoticeMonths}, "2025-06-15", "2025-09-30", "2025-12-31"},
+		{"weeks", Contract{MinimumTermEnd: termEnd, NoticePeriod: 4, NoticeUnit: NoticeWeeks}, "2025-06-15", "2025-12-03", "2025-12-31"},
+		{"days", Contract{MinimumTermEnd: termEnd, NoticePeriod: 30, NoticeUnit: NoticeDays}, "2025-06-15", "2025-12-01", "2025-12-31"},
+		{"deadline is today", Contract{MinimumTermEnd: termEnd, NoticePeriod: 3, NoticeUnit: NoticeMonths}, "2025-09-30", "2025-09-30", "2025-12-31"},
+		{"renewed yearly", Contract{MinimumTermEnd: termEnd, NoticePeriod: 3, NoticeUnit: NoticeMonths, RenewalMonths: 12}, "2025-10-01", "2026-09-30", "2026-12-31"},
+		{"renewed monthly", Contract{MinimumTermEnd: termEnd, NoticePeriod: 1, NoticeUnit: NoticeMonths, RenewalMonths: 1}, "2027-03-10", "2027-03-31", "2027-04-30"},
+		{"no notice period", Contract{MinimumTermEnd: termEnd, RenewalMonths: 24}, "2026-01-01", "2027-12-31", "2027-12-31"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day, _ := time.Parse("2006-01-02", tt.day)
+
+			deadline, ok := tt.contract.NextDeadline(day)
+			if !ok {
+				t.Fatal("Expected a deadline")
+			}
+			if deadline.Date.Format("2006-01-02") != tt.deadline || deadline.TermEnd.Format("2006-01-02") != tt.termEnd {
+				t.Errorf("Expected %s for term end %s, got %s for %s",
+					tt.deadline, tt.termEnd, deadline.Date.Format("2006-01-02"), deadline.TermEnd.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestNextDeadline_None(t *testing.T) {
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, ok := (Contract{Provider: "Fitness First"}).NextDeadline(day); ok {
+		t.Error("Expected no deadline without term end")
+	}
+
+	expired := Contract{MinimumTermEnd: &types.YearMonth{Year: 2025, Month: 12}, NoticePeriod: 1, NoticeUnit: NoticeMonths}
+	if _, ok := expired.NextDeadline(day); ok {
+		t.Error("Expected no deadline for a contract ended without renewal")
+	}
+}
+
+func TestContractValidate(t *testing.T) {
+	termEnd := &types.YearMonth{Year: 2025, Month: 12}
+
+	valid := []Contract{
+		{},
+		{Provider: "Telekom", Number: "4711"},
+		{MinimumTermEnd: termEnd, NoticePeriod: 3, NoticeUnit: NoticeMonths, RenewalMonths: 12},
+	}
+	for _, contract := range valid {
+		if err := contract.Validate(); err != nil {
+			t.Errorf("Expected %+v to be valid, got %v", contract, err)
+		}
+	}
+
+	invalid := []Contract{
+		{MinimumTermEnd: &types.YearMonth{Year: 2025, Month: 13}},
+		{MinimumTermEnd: termEnd, NoticePeriod: 3},
+		{MinimumTermEnd: termEnd, NoticePeriod: -1, NoticeUnit: NoticeDays},
+		{RenewalMonths: 12},
+	}
+	for _, contract := range invalid {
+		if err := contract.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", contract)
+		}
+	}
+}
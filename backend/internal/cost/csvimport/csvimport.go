@@ -0,0 +1,323 @@
+// Package csvimport reads cost lists exported from spreadsheets or other
+// budgeting tools. It only parses and normalizes values; turning rows into
+// costs and validating them is up to the caller.
+package csvimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+// Fields a column can be mapped to.
+const (
+	FieldKind      = "kind"
+	FieldName      = "name"
+	FieldAmount    = "amount"
+	FieldCurrency  = "currency"
+	FieldFrequency = "frequency"
+	FieldDueMonth  = "dueMonth"
+	FieldFrom      = "from"
+	FieldTo        = "to"
+	FieldDueDate   = "dueDate"
+	FieldIsSaving  = "isSaving"
+	FieldCategory  = "category"
+	FieldTags      = "tags"
+)
+
+// defaultColumns lists the header names recognized without an explicit
+// mapping, lower-cased.
+var defaultColumns = map[string][]string{
+	FieldKind:      {"kind", "type", "typ", "art"},
+	FieldName:      {"name", "bezeichnung", "beschreibung", "titel"},
+	FieldAmount:    {"amount", "betrag"},
+	FieldCurrency:  {"currency", "währung", "waehrung"},
+	FieldFrequency: {"frequency", "frequenz", "rhythmus", "turnus", "intervall"},
+	FieldDueMonth:  {"duemonth", "due month", "fälligkeitsmonat", "monat"},
+	FieldFrom:      {"from", "von", "start", "beginn"},
+	FieldTo:        {"to", "bis", "ende"},
+	FieldDueDate:   {"duedate", "due date", "fällig", "fälligkeit", "datum"},
+	FieldIsSaving:  {"issaving", "saving", "sparen", "sparrate"},
+	FieldCategory:  {"category", "kategorie"},
+	FieldTags:      {"tags", "schlagworte"},
+}
+
+type Options struct {
+	// Delimiter separates the columns. If empty, it is guessed from the
+	// header line.
+	Delimiter string
+	// DecimalComma reads "1.234,56" instead of "1,234.56".
+	DecimalComma bool
+	// Columns maps fields to header names, overriding the defaults.
+	Columns map[string]string
+}
+
+// Row holds the values of one data line keyed by field.
+type Row struct {
+	Line   int
+	Values map[string]string
+}
+
+func (r Row) Get(field string) string {
+	return r.Values[field]
+}
+
+// Parse reads the header line, maps its columns to fields and returns the
+// non-empty data lines. A header without a name and amount column is
+// rejected.
+func Parse(data string, options Options) ([]Row, error) {
+	delimiter, err := delimiterOf(data, options.Delimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(data, "\ufeff")))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("csv is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := mapColumns(header, options.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]Row, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := Row{Line: line, Values: make(map[string]string)}
+		empty := true
+
+		for field, index := range columns {
+			if index < len(record) {
+				value := strings.TrimSpace(record[index])
+				row.Values[field] = value
+				empty = empty && value == ""
+			}
+		}
+
+		if !empty {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+func delimiterOf(data, delimiter string) (rune, error) {
+	if delimiter == "\\t" || delimiter == "tab" {
+		return '\t', nil
+	}
+
+	if delimiter != "" {
+		runes := []rune(delimiter)
+		if len(runes) != 1 || runes[0] == '"' || runes[0] == '\n' || runes[0] == '\r' {
+			return 0, errors.New("delimiter must be a single character")
+		}
+		return runes[0], nil
+	}
+
+	header, _, _ := strings.Cut(data, "\n")
+	best, count := ';', strings.Count(header, ";")
+	for _, candidate := range []rune{',', '\t', '|'} {
+		if n := strings.Count(header, string(candidate)); n > count {
+			best, count = candidate, n
+		}
+	}
+
+	return best, nil
+}
+
+func mapColumns(header []string, explicit map[string]string) (map[string]int, error) {
+	indexes := make(map[string]int)
+	for i, name := range header {
+		indexes[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	columns := make(map[string]int)
+	for field, aliases := range defaultColumns {
+		if name, ok := explicit[field]; ok {
+			index, found := indexes[strings.ToLower(strings.TrimSpace(name))]
+			if !found {
+				return nil, fmt.Errorf("column %q mapped to %s not found", name, field)
+			}
+			columns[field] = index
+			continue
+		}
+
+		for _, alias := range aliases {
+			if index, found := indexes[alias]; found {
+				columns[field] = index
+				break
+			}
+		}
+	}
+
+	for field := range explicit {
+		if _, known := defaultColumns[field]; !known {
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	for _, required := range []string{FieldName, FieldAmount} {
+		if _, found := columns[required]; !found {
+			return nil, fmt.Errorf("no column found for %s", required)
+		}
+	}
+
+	return columns, nil
+}
+
+// ParseAmount reads a signed amount with optional thousands separators and
+// currency symbols and rounds it to whole units.
+func ParseAmount(value string, decimalComma bool) (int, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9', r == '-', r == '+', r == ',', r == '.':
+			return r
+		case r == '−': // typographic minus
+			return '-'
+		default:
+			return -1
+		}
+	}, value)
+
+	if decimalComma {
+		cleaned = strings.ReplaceAll(cleaned, ".", "")
+		cleaned = strings.ReplaceAll(cleaned, ",", ".")
+	} else {
+		cleaned = strings.ReplaceAll(cleaned, ",", "")
+	}
+
+	amount, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil || cleaned == "" {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+
+	return int(math.Round(amount)), nil
+}
+
+var yearMonthLayouts = []string{
+	"01.2006", "1.2006", "02.01.2006", "2.1.2006", "02.01.06",
+	"01/2006", "1/2006", "2006-01", "2006-01-02",
+}
+
+// ParseYearMonth reads German and ISO dates; a day is accepted and ignored.
+func ParseYearMonth(value string) (*types.YearMonth, error) {
+	for _, layout := range yearMonthLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return types.New(date.Year(), int(date.Month()))
+		}
+	}
+	return nil, fmt.Errorf("invalid date %q", value)
+}
+
+var germanMonths = map[string]int{
+	"januar": 1, "jan": 1, "februar": 2, "feb": 2, "märz": 3, "maerz": 3, "mär": 3, "mrz": 3,
+	"april": 4, "apr": 4, "mai": 5, "juni": 6, "jun": 6, "juli": 7, "jul": 7,
+	"august": 8, "aug": 8, "september": 9, "sep": 9, "sept": 9, "oktober": 10, "okt": 10,
+	"november": 11, "nov": 11, "dezember": 12, "dez": 12,
+}
+
+// ParseMonth reads a month number or a German month name.
+func ParseMonth(value string) (int, error) {
+	if month, ok := germanMonths[strings.ToLower(strings.TrimSuffix(value, "."))]; ok {
+		return month, nil
+	}
+
+	month, err := strconv.Atoi(value)
+	if err != nil || month < 1 || month > 12 {
+		return 0, fmt.Errorf("invalid month %q", value)
+	}
+	return month, nil
+}
+
+var everyNMonths = regexp.MustCompile(`^(?:alle|every)\s+(\d+)\s+(monate|months|wochen|weeks|jahre|years)$`)
+
+// ParseFrequency reads the labels used in the app ("monatlich",
+// "alle 2 Monate", ...), their English counterparts or an iCalendar rule.
+// The returned rule has no anchor yet.
+func ParseFrequency(value string) (cost.Recurrence, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+
+	if strings.Contains(strings.ToUpper(value), "FREQ=") {
+		return cost.ParseRRule(value)
+	}
+
+	switch normalized {
+	case "wöchentlich", "woechentlich", "weekly":
+		return cost.Recurrence{Frequency: cost.FrequencyWeekly, Interval: 1}, nil
+	case "", "monatlich", "monthly", "1":
+		return cost.Recurrence{Frequency: cost.FrequencyMonthly, Interval: 1}, nil
+	case "vierteljährlich", "vierteljaehrlich", "quartalsweise", "quarterly", "3":
+		return cost.Recurrence{Frequency: cost.FrequencyMonthly, Interval: 3}, nil
+	case "halbjährlich", "halbjaehrlich", "halfyearly", "half-yearly", "6":
+		return cost.Recurrence{Frequency: cost.FrequencyMonthly, Interval: 6}, nil
+	case "jährlich", "jaehrlich", "yearly", "annually", "12":
+		return cost.Recurrence{Frequency: cost.FrequencyYearly, Interval: 1}, nil
+	}
+
+	if match := everyNMonths.FindStringSubmatch(normalized); match != nil {
+		interval, _ := strconv.Atoi(match[1])
+		switch match[2] {
+		case "wochen", "weeks":
+			return cost.Recurrence{Frequency: cost.FrequencyWeekly, Interval: interval}, nil
+		case "jahre", "years":
+			return cost.Recurrence{Frequency: cost.FrequencyYearly, Interval: interval}, nil
+		default:
+			return cost.Recurrence{Frequency: cost.FrequencyMonthly, Interval: interval}, nil
+		}
+	}
+
+	return cost.Recurrence{}, fmt.Errorf("unknown frequency %q", value)
+}
+
+// ParseBool reads yes/no values in English and German.
+func ParseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "", "0", "false", "no", "nein", "n":
+		return false, nil
+	case "1", "true", "yes", "ja", "j", "x":
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid yes/no value %q", value)
+}
+
+// IsSpecial decides whether a row describes a special cost. An explicit kind
+// wins; otherwise rows with a due date and no frequency are special costs.
+func IsSpecial(row Row) (bool, error) {
+	switch strings.ToLower(row.Get(FieldKind)) {
+	case "special", "sonder", "sonderkosten", "einmalig", "once":
+		return true, nil
+	case "fixed", "fix", "fixkosten", "regelmäßig", "recurring":
+		return false, nil
+	case "":
+		return row.Get(FieldDueDate) != "" && row.Get(FieldFrequency) == "", nil
+	}
+	return false, fmt.Errorf("unknown kind %q", row.Get(FieldKind))
+}
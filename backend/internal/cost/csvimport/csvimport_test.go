@@ -0,0 +1,134 @@
+package csvimport
+
+import (
+	"testing"
+
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+func TestParse_GermanExportWithDefaultColumns(t *testing.T) {
+	data := "\ufeffBezeichnung;Betrag;Rhythmus;Monat\n" +
+		"Miete;-1.200,00;monatlich;\n" +
+		";;;\n" +
+		"\"Haftpflicht; Familie\";-89,90;jährlich;März\n"
+
+	rows, err := Parse(data, Options{DecimalComma: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows without the empty line, got %d", len(rows))
+	}
+
+	if rows[1].Line != 4 || rows[1].Get(FieldName) != "Haftpflicht; Familie" || rows[1].Get(FieldDueMonth) != "März" {
+		t.Errorf("Unexpected row: %+v", rows[1])
+	}
+}
+
+func TestParse_ExplicitColumnsAndDelimiter(t *testing.T) {
+	data := "Payee,Value\nGym,-30\n"
+
+	if _, err := Parse(data, Options{}); err == nil {
+		t.Error("Expected an error without name and amount columns")
+	}
+
+	rows, err := Parse(data, Options{Delimiter: ",", Columns: map[string]string{FieldName: "Payee", FieldAmount: "value"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rows[0].Get(FieldName) != "Gym" || rows[0].Get(FieldAmount) != "-30" {
+		t.Errorf("Unexpected row: %+v", rows[0])
+	}
+
+	if _, err := Parse(data, Options{Columns: map[string]string{FieldName: "Missing", FieldAmount: "Value"}}); err == nil {
+		t.Error("Expected an error for a mapped column that does not exist")
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		value        string
+		decimalComma bool
+		expected     int
+	}{
+		{"-1.234,56 €", true, -1235},
+		{"89,40", true, 89},
+		{"-1,234.56", false, -1235},
+		{"+300", false, 300},
+		{"−12", false, -12},
+	}
+
+	for _, tt := range tests {
+		amount, err := ParseAmount(tt.value, tt.decimalComma)
+		if err != nil || amount != tt.expected {
+			t.Errorf("ParseAmount(%q) = %d, %v; expected %d", tt.value, amount, err, tt.expected)
+		}
+	}
+
+	if _, err := ParseAmount("abc", true); err == nil {
+		t.Error("Expected an error for a non-numeric amount")
+	}
+}
+
+func TestParseYearMonth(t *testing.T) {
+	expected := types.YearMonth{Year: 2025, Month: 3}
+
+	for _, value := range []string{"03.2025", "3.2025", "15.03.2025", "15.03.25", "03/2025", "2025-03", "2025-03-15"} {
+		yearMonth, err := ParseYearMonth(value)
+		if err != nil || *yearMonth != expected {
+			t.Errorf("ParseYearMonth(%q) = %v, %v", value, yearMonth, err)
+		}
+	}
+
+	if _, err := ParseYearMonth("März 2025"); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
+
+func TestParseFrequency(t *testing.T) {
+	tests := []struct {
+		value     string
+		frequency cost.Frequency
+		interval  int
+	}{
+		{"", cost.FrequencyMonthly, 1},
+		{"Vierteljährlich", cost.FrequencyMonthly, 3},
+		{"halfyearly", cost.FrequencyMonthly, 6},
+		{"jährlich", cost.FrequencyYearly, 1},
+		{"alle 2 Monate", cost.FrequencyMonthly, 2},
+		{"alle 2 Wochen", cost.FrequencyWeekly, 2},
+		{"FREQ=YEARLY;INTERVAL=2", cost.FrequencyYearly, 2},
+	}
+
+	for _, tt := range tests {
+		rule, err := ParseFrequency(tt.value)
+		if err != nil || rule.Frequency != tt.frequency || rule.Interval != tt.interval {
+			t.Errorf("ParseFrequency(%q) = %+v, %v", tt.value, rule, err)
+		}
+	}
+
+	if _, err := ParseFrequency("ab und zu"); err == nil {
+		t.Error("Expected an error for an unknown frequency")
+	}
+}
+
+func TestIsSpecial(t *testing.T) {
+	tests := []struct {
+		values   map[string]string
+		expected bool
+	}{
+		{map[string]string{FieldDueDate: "12.2025"}, true},
+		{map[string]string{FieldDueDate: "12.2025", FieldFrequency: "jährlich"}, false},
+		{map[string]string{FieldKind: "Sonderkosten"}, true},
+		{map[string]string{FieldKind: "fix", FieldDueDate: "12.2025"}, false},
+	}
+
+	for _, tt := range tests {
+		special, err := IsSpecial(Row{Values: tt.values})
+		if err != nil || special != tt.expected {
+			t.Errorf("IsSpecial(%v) = %v, %v", tt.values, special, err)
+		}
+	}
+}
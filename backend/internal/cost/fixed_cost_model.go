@@ -2,21 +2,161 @@ package cost
 
 import (
 	"database/sql/driver"
+	"math"
 	"strconv"
 	"strings"
 	"wondee/finance-app-backend/internal/platform/types"
+
+	"gorm.io/gorm"
 )
 
 type FixedCost struct {
-	ID          int    `gorm:"primary_key"`
-	UserID      uint   `json:"user_id"`
-	WorkspaceID uint   `json:"workspace_id"`
+	ID          int  `gorm:"primary_key"`
+	UserID      uint `json:"user_id"`
+	WorkspaceID uint `json:"workspace_id"`
 	Name        string
 	Amount      int
+	Currency    string `gorm:"size:3"` // ISO 4217 code, empty for the workspace's base currency
 	From        *types.YearMonth
 	To          *types.YearMonth
-	DueMonth    Months `gorm:"type:string"`
+	DueMonth    Months     `gorm:"type:string"` // Deprecated: legacy schedule, superseded by Recurrence
+	Recurrence  Recurrence `gorm:"embedded;embeddedPrefix:recurrence_"`
 	IsSaving    bool
+	CategoryID  *uint `gorm:"index"`
+	AccountID   *uint `gorm:"index"` // nil for the default account
+	Tags        Tags  `gorm:"type:string"`
+	Split       Split `gorm:"type:string"`
+
+	// Transfers move the amount from AccountID to TargetAccountID; they are
+	// neither income nor expense of the workspace.
+	TargetAccountID *uint `gorm:"index"`
+
+	// Optional yearly indexation: the amount rises by EscalationRate percent
+	// every year in EscalationMonth.
+	EscalationRate  float64
+	EscalationMonth int
+
+	Contract Contract `gorm:"embedded;embeddedPrefix:contract_"`
+
+	Revisions []AmountRevision `gorm:"foreignKey:FixedCostID;constraint:OnDelete:CASCADE"`
+
+	// Deleted costs stay in the trash until they are restored or purged.
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// IsTransfer reports whether the cost moves money between two accounts.
+func (fc *FixedCost) IsTransfer() bool {
+	return fc.TargetAccountID != nil
+}
+
+// Schedule returns the recurrence rule of the cost. Costs saved before
+// recurrence rules existed only carry DueMonth, which is translated into the
+// equivalent monthly or yearly rule.
+func (fc *FixedCost) Schedule() Recurrence {
+	if fc.Recurrence.Frequency != "" {
+		return fc.Recurrence
+	}
+
+	switch len(fc.DueMonth) {
+	case 0:
+		return Recurrence{Frequency: FrequencyMonthly, Interval: 1}
+	case 1:
+		return Recurrence{
+			Frequency: FrequencyYearly,
+			Interval:  1,
+			Anchor:    &types.YearMonth{Year: 2000, Month: fc.DueMonth[0]},
+		}
+	default:
+		return Recurrence{
+			Frequency: FrequencyMonthly,
+			Interval:  12 / len(fc.DueMonth),
+			Anchor:    &types.YearMonth{Year: 2000, Month: fc.DueMonth[0]},
+		}
+	}
+}
+
+// Occurrences returns how many times the cost is due in the given month,
+// taking the validity period into account. Every calculation that needs to
+// know when a fixed cost is paid must go through this function.
+func (fc *FixedCost) Occurrences(ym *types.YearMonth) int {
+	if !types.IsRelevant(ym, fc.From, fc.To) {
+		return 0
+	}
+	return fc.Schedule().Occurrences(ym)
+}
+
+// AmountAt returns the amount of a single payment valid in the given month:
+// the amount of the latest revision that took effect until then, escalated
+// by every anniversary passed since.
+func (fc *FixedCost) AmountAt(ym *types.YearMonth) int {
+	amount, since := fc.baseAmountAt(ym)
+
+	if fc.EscalationRate == 0 {
+		return amount
+	}
+
+	// Without a known start the entered amount is taken as today's amount.
+	if since == nil {
+		since = types.CurrentYearMonth()
+	}
+
+	years := anniversariesBetween(since, ym, fc.EscalationMonth)
+	if years == 0 {
+		return amount
+	}
+
+	return int(math.Round(float64(amount) * math.Pow(1+fc.EscalationRate/100, float64(years))))
+}
+
+// baseAmountAt returns the amount set for the given month, before any
+// escalation, together with the month it was set for.
+func (fc *FixedCost) baseAmountAt(ym *types.YearMonth) (int, *types.YearMonth) {
+	var latest *AmountRevision
+
+	for i, revision := range fc.Revisions {
+		if types.MonthsBetween(&revision.ValidFrom, ym) < 0 {
+			continue
+		}
+		if latest == nil || types.MonthsBetween(&latest.ValidFrom, &revision.ValidFrom) > 0 {
+			latest = &fc.Revisions[i]
+		}
+	}
+
+	if latest == nil {
+		return fc.Amount, fc.From
+	}
+	return latest.Amount, &latest.ValidFrom
+}
+
+// anniversariesBetween counts the months after "from" up to and including
+// "to" that fall on the given anniversary month.
+func anniversariesBetween(from, to *types.YearMonth, month int) int {
+	months := types.MonthsBetween(from, to)
+
+	first := floorMod(month-from.Month, 12)
+	if first == 0 {
+		first = 12
+	}
+
+	if months < first {
+		return 0
+	}
+	return 1 + (months-first)/12
+}
+
+// DueAmount returns the total amount due in the given month.
+func (fc *FixedCost) DueAmount(ym *types.YearMonth) int {
+	occurrences := fc.Occurrences(ym)
+	if occurrences == 0 {
+		return 0
+	}
+	return fc.AmountAt(ym) * occurrences
+}
+
+// MonthlyAverage returns the amount valid in the given month spread evenly
+// over the months of a year.
+func (fc *FixedCost) MonthlyAverage(ym *types.YearMonth) float64 {
+	return float64(fc.AmountAt(ym)) * fc.Schedule().PerYear() / 12.0
 }
 
 type Months []int
@@ -24,9 +164,9 @@ type Months []int
 var ALL_MONTHS = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
 
 func (this *Months) Scan(value interface{}) error {
-	str := value.(string)
+	str, ok := value.(string)
 
-	if len(str) == 0 {
+	if !ok || len(str) == 0 {
 		return nil
 	}
 
@@ -0,0 +1,101 @@
+package cost
+
+import (
+	"testing"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+func TestAmountAtUsesLatestRevision(t *testing.T) {
+	rent := FixedCost{
+		Amount:   -900,
+		From:     &types.YearMonth{Year: 2023, Month: 1},
+		DueMonth: ALL_MONTHS,
+		Revisions: []AmountRevision{
+			{Amount: -1000, ValidFrom: types.YearMonth{Year: 2025, Month: 1}},
+			{Amount: -950, ValidFrom: types.YearMonth{Year: 2024, Month: 4}},
+		},
+	}
+
+	tests := []struct {
+		month    *types.YearMonth
+		expected int
+	}{
+		{&types.YearMonth{Year: 2023, Month: 6}, -900},
+		{&types.YearMonth{Year: 2024, Month: 3}, -900},
+		{&types.YearMonth{Year: 2024, Month: 4}, -950},
+		{&types.YearMonth{Year: 2024, Month: 12}, -950},
+		{&types.YearMonth{Year: 2025, Month: 1}, -1000},
+	}
+
+	for _, tt := range tests {
+		if got := rent.AmountAt(tt.month); got != tt.expected {
+			t.Errorf("%d-%d: expected %d, got %d", tt.month.Year, tt.month.Month, tt.expected, got)
+		}
+		if got := rent.DueAmount(tt.month); got != tt.expected {
+			t.Errorf("%d-%d: expected due amount %d, got %d", tt.month.Year, tt.month.Month, tt.expected, got)
+		}
+	}
+
+	if got := rent.MonthlyAverage(&types.YearMonth{Year: 2024, Month: 5}); got != -950 {
+		t.Errorf("Expected monthly average -950, got %f", got)
+	}
+}
+
+func TestAmountAtWithEscalation(t *testing.T) {
+	insurance := FixedCost{
+		Amount:          -1000,
+		From:            &types.YearMonth{Year: 2024, Month: 3},
+		DueMonth:        ALL_MONTHS,
+		EscalationRate:  10,
+		EscalationMonth: 1,
+	}
+
+	tests := []struct {
+		month    *types.YearMonth
+		expected int
+	}{
+		{&types.YearMonth{Year: 2024, Month: 12}, -1000},
+		{&types.YearMonth{Year: 2025, Month: 1}, -1100},
+		{&types.YearMonth{Year: 2025, Month: 12}, -1100},
+		{&types.YearMonth{Year: 2026, Month: 1}, -1210},
+	}
+
+	for _, tt := range tests {
+		if got := insurance.AmountAt(tt.month); got != tt.expected {
+			t.Errorf("%d-%d: expected %d, got %d", tt.month.Year, tt.month.Month, tt.expected, got)
+		}
+	}
+
+	// A revision sets a new base amount that is escalated from then on
+	insurance.Revisions = []AmountRevision{{Amount: -1200, ValidFrom: types.YearMonth{Year: 2025, Month: 6}}}
+
+	if got := insurance.AmountAt(&types.YearMonth{Year: 2025, Month: 12}); got != -1200 {
+		t.Errorf("Expected revised amount -1200, got %d", got)
+	}
+	if got := insurance.AmountAt(&types.YearMonth{Year: 2026, Month: 1}); got != -1320 {
+		t.Errorf("Expected escalated revision -1320, got %d", got)
+	}
+}
+
+func TestAnniversariesBetween(t *testing.T) {
+	from := &types.YearMonth{Year: 2024, Month: 5}
+
+	tests := []struct {
+		to       *types.YearMonth
+		month    int
+		expected int
+	}{
+		{&types.YearMonth{Year: 2024, Month: 5}, 5, 0},
+		{&types.YearMonth{Year: 2025, Month: 4}, 5, 0},
+		{&types.YearMonth{Year: 2025, Month: 5}, 5, 1},
+		{&types.YearMonth{Year: 2024, Month: 6}, 6, 1},
+		{&types.YearMonth{Year: 2027, Month: 6}, 6, 4},
+		{&types.YearMonth{Year: 2023, Month: 6}, 6, 0},
+	}
+
+	for _, tt := range tests {
+		if got := anniversariesBetween(from, tt.to, tt.month); got != tt.expected {
+			t.Errorf("Until %d-%d in month %d: expected %d, got %d", tt.to.Year, tt.to.Month, tt.month, tt.expected, got)
+		}
+	}
+}
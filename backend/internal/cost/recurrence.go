@@ -0,0 +1,197 @@
+package cost
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+type Frequency string
+
+const (
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+const daysPerYear = 365.25
+
+// Recurrence describes when a fixed cost is due. It covers the subset of
+// RFC 5545 recurrence rules the app can express: FREQ, INTERVAL and DTSTART.
+//
+// The anchor only defines the phase of the rule (e.g. "every two months,
+// starting in March"); the validity of a cost is still bounded by From/To.
+// AnchorDay is only evaluated for weekly rules.
+type Recurrence struct {
+	Frequency Frequency        `json:"frequency"`
+	Interval  int              `json:"interval"`
+	Anchor    *types.YearMonth `json:"anchor" gorm:"type:string"`
+	AnchorDay int              `json:"anchorDay"`
+}
+
+func (r Recurrence) Validate() error {
+	switch r.Frequency {
+	case FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+	default:
+		return fmt.Errorf("unsupported frequency %q", r.Frequency)
+	}
+
+	if r.Interval < 1 {
+		return errors.New("interval must be at least 1")
+	}
+
+	if r.Anchor == nil {
+		return errors.New("anchor is required")
+	}
+
+	if r.Anchor.Month < 1 || r.Anchor.Month > 12 {
+		return errors.New("anchor month must be between 1 and 12")
+	}
+
+	if r.Frequency == FrequencyWeekly && (r.AnchorDay < 1 || r.AnchorDay > daysIn(r.Anchor)) {
+		return errors.New("anchorDay must be a valid day of the anchor month")
+	}
+
+	return nil
+}
+
+// Occurrences returns how many times the rule fires within the given month.
+func (r Recurrence) Occurrences(ym *types.YearMonth) int {
+	interval := max(r.Interval, 1)
+	anchor := r.anchor()
+
+	switch r.Frequency {
+	case FrequencyWeekly:
+		return r.weeklyOccurrences(ym, anchor, interval)
+	case FrequencyYearly:
+		interval *= 12
+	}
+
+	if floorMod(types.MonthsBetween(anchor, ym), interval) == 0 {
+		return 1
+	}
+	return 0
+}
+
+// PerYear returns the average number of occurrences within a year.
+func (r Recurrence) PerYear() float64 {
+	interval := float64(max(r.Interval, 1))
+
+	switch r.Frequency {
+	case FrequencyWeekly:
+		return daysPerYear / 7 / interval
+	case FrequencyYearly:
+		return 1 / interval
+	default:
+		return 12 / interval
+	}
+}
+
+// RRule renders the rule in iCalendar notation, e.g.
+// "DTSTART:20250301\nRRULE:FREQ=MONTHLY;INTERVAL=2".
+func (r Recurrence) RRule() string {
+	rule := fmt.Sprintf("RRULE:FREQ=%s;INTERVAL=%d", r.Frequency, max(r.Interval, 1))
+	if r.Anchor == nil {
+		return rule
+	}
+
+	return fmt.Sprintf("DTSTART:%04d%02d%02d\n%s", r.Anchor.Year, r.Anchor.Month, max(r.AnchorDay, 1), rule)
+}
+
+// ParseRRule reads a rule as produced by RRule. The DTSTART line and the
+// "RRULE:" prefix are optional; unsupported rule parts are rejected.
+func ParseRRule(value string) (Recurrence, error) {
+	result := Recurrence{Interval: 1}
+
+	for _, line := range strings.Fields(value) {
+		switch {
+		case strings.HasPrefix(line, "DTSTART:"):
+			date := strings.TrimPrefix(line, "DTSTART:")
+			if len(date) > 8 {
+				date = date[:8] // drop an optional time part
+			}
+			start, err := time.Parse("20060102", date)
+			if err != nil {
+				return Recurrence{}, fmt.Errorf("invalid DTSTART: %w", err)
+			}
+			result.Anchor = &types.YearMonth{Year: start.Year(), Month: int(start.Month())}
+			result.AnchorDay = start.Day()
+		default:
+			if err := result.parseRuleParts(strings.TrimPrefix(line, "RRULE:")); err != nil {
+				return Recurrence{}, err
+			}
+		}
+	}
+
+	if result.Frequency == "" {
+		return Recurrence{}, errors.New("FREQ is required")
+	}
+
+	return result, nil
+}
+
+func (r *Recurrence) parseRuleParts(rule string) error {
+	for _, part := range strings.Split(rule, ";") {
+		key, value, found := strings.Cut(part, "=")
+		if !found {
+			return fmt.Errorf("invalid rule part %q", part)
+		}
+
+		switch key {
+		case "FREQ":
+			r.Frequency = Frequency(value)
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid INTERVAL: %w", err)
+			}
+			r.Interval = interval
+		default:
+			return fmt.Errorf("unsupported rule part %q", key)
+		}
+	}
+
+	return nil
+}
+
+func (r Recurrence) anchor() *types.YearMonth {
+	if r.Anchor == nil {
+		return &types.YearMonth{Year: 2000, Month: 1}
+	}
+	return r.Anchor
+}
+
+func (r Recurrence) weeklyOccurrences(ym, anchor *types.YearMonth, interval int) int {
+	period := 7 * interval
+	start := time.Date(anchor.Year, time.Month(anchor.Month), max(r.AnchorDay, 1), 0, 0, 0, 0, time.UTC)
+	first := time.Date(ym.Year, time.Month(ym.Month), 1, 0, 0, 0, 0, time.UTC)
+
+	fromDay := daysBetween(start, first)
+	toDay := fromDay + daysIn(ym) - 1
+
+	return floorDiv(toDay, period) - floorDiv(fromDay+period-1, period) + 1
+}
+
+func daysIn(ym *types.YearMonth) int {
+	return time.Date(ym.Year, time.Month(ym.Month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func floorDiv(a, b int) int {
+	result := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		result--
+	}
+	return result
+}
+
+func floorMod(a, b int) int {
+	return a - floorDiv(a, b)*b
+}
@@ -0,0 +1,97 @@
+package cost
+
+import (
+	"testing"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	anchor := &types.YearMonth{Year: 2024, Month: 3}
+
+	tests := []struct {
+		name     string
+		rule     Recurrence
+		month    *types.YearMonth
+		expected int
+	}{
+		{"Bi-monthly on anchor", Recurrence{FrequencyMonthly, 2, anchor, 0}, &types.YearMonth{Year: 2024, Month: 3}, 1},
+		{"Bi-monthly off cycle", Recurrence{FrequencyMonthly, 2, anchor, 0}, &types.YearMonth{Year: 2024, Month: 4}, 0},
+		{"Bi-monthly before anchor", Recurrence{FrequencyMonthly, 2, anchor, 0}, &types.YearMonth{Year: 2024, Month: 1}, 1},
+		{"Biennial due", Recurrence{FrequencyYearly, 2, anchor, 0}, &types.YearMonth{Year: 2026, Month: 3}, 1},
+		{"Biennial skipped year", Recurrence{FrequencyYearly, 2, anchor, 0}, &types.YearMonth{Year: 2025, Month: 3}, 0},
+		// 2024-03-04 is a Monday, March 2024 has four Mondays and April five
+		{"Weekly in anchor month", Recurrence{FrequencyWeekly, 1, anchor, 4}, &types.YearMonth{Year: 2024, Month: 3}, 4},
+		{"Weekly next month", Recurrence{FrequencyWeekly, 1, anchor, 4}, &types.YearMonth{Year: 2024, Month: 4}, 5},
+		{"Weekly before anchor", Recurrence{FrequencyWeekly, 1, anchor, 4}, &types.YearMonth{Year: 2024, Month: 2}, 4},
+		{"Fortnightly", Recurrence{FrequencyWeekly, 2, anchor, 4}, &types.YearMonth{Year: 2024, Month: 4}, 3}, // 1st, 15th, 29th
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Occurrences(tt.month); got != tt.expected {
+				t.Errorf("Expected %d occurrences, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFixedCostScheduleFromDueMonth(t *testing.T) {
+	quarterly := FixedCost{Amount: -30, DueMonth: []int{2, 5, 8, 11}}
+
+	schedule := quarterly.Schedule()
+	if schedule.Frequency != FrequencyMonthly || schedule.Interval != 3 || schedule.Anchor.Month != 2 {
+		t.Errorf("Unexpected schedule %+v", schedule)
+	}
+
+	for month := 1; month <= 12; month++ {
+		expected := 0
+		if month%3 == 2 {
+			expected = 1
+		}
+		if got := quarterly.Occurrences(&types.YearMonth{Year: 2024, Month: month}); got != expected {
+			t.Errorf("Month %d: expected %d occurrences, got %d", month, expected, got)
+		}
+	}
+
+	if quarterly.MonthlyAverage(&types.YearMonth{Year: 2024, Month: 1}) != -10 {
+		t.Errorf("Expected monthly average -10, got %f", quarterly.MonthlyAverage(&types.YearMonth{Year: 2024, Month: 1}))
+	}
+}
+
+func TestFixedCostOccurrencesRespectValidity(t *testing.T) {
+	fc := FixedCost{
+		Amount:     -10,
+		From:       &types.YearMonth{Year: 2024, Month: 1},
+		To:         &types.YearMonth{Year: 2024, Month: 6},
+		Recurrence: Recurrence{FrequencyMonthly, 1, &types.YearMonth{Year: 2024, Month: 1}, 0},
+	}
+
+	if fc.DueAmount(&types.YearMonth{Year: 2024, Month: 6}) != -10 {
+		t.Error("Expected cost to be due within validity period")
+	}
+	if fc.DueAmount(&types.YearMonth{Year: 2024, Month: 7}) != 0 {
+		t.Error("Expected no amount after validity period")
+	}
+}
+
+func TestRRuleRoundTrip(t *testing.T) {
+	rule := Recurrence{FrequencyWeekly, 2, &types.YearMonth{Year: 2025, Month: 3}, 15}
+
+	parsed, err := ParseRRule(rule.RRule())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if parsed.Frequency != rule.Frequency || parsed.Interval != rule.Interval ||
+		*parsed.Anchor != *rule.Anchor || parsed.AnchorDay != rule.AnchorDay {
+		t.Errorf("Expected %+v, got %+v", rule, parsed)
+	}
+
+	bare, err := ParseRRule("FREQ=YEARLY;INTERVAL=2")
+	if err != nil || bare.Frequency != FrequencyYearly || bare.Interval != 2 {
+		t.Errorf("Unexpected result for bare rule: %+v, %v", bare, err)
+	}
+
+	if _, err := ParseRRule("FREQ=DAILY;BYDAY=MO"); err == nil {
+		t.Error("Expected error for unsupported rule part")
+	}
+}
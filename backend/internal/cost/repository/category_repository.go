@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"sort"
+	"wondee/finance-app-backend/internal/cost"
+
+	"gorm.io/gorm"
+)
+
+func (r *PostgresRepository) LoadCategories(workspaceID uint) ([]cost.Category, error) {
+	var categories []cost.Category
+	if err := r.DB.Where("workspace_id = ?", workspaceID).Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Name < categories[j].Name
+	})
+
+	return categories, nil
+}
+
+func (r *PostgresRepository) SaveCategory(category *cost.Category) error {
+	if category.ID == 0 {
+		return r.DB.Create(category).Error
+	}
+	return r.DB.Save(category).Error
+}
+
+// DeleteCategory removes a category, moves its subcategories up to its parent
+// and leaves the costs assigned to it uncategorized, including those in the
+// trash.
+func (r *PostgresRepository) DeleteCategory(id uint, workspaceID uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var category cost.Category
+		if err := tx.Where("id = ? AND workspace_id = ?", id, workspaceID).First(&category).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&cost.Category{}).
+			Where("parent_id = ? AND workspace_id = ?", id, workspaceID).
+			Update("parent_id", category.ParentID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Model(&cost.FixedCost{}).
+			Where("category_id = ? AND workspace_id = ?", id, workspaceID).
+			Update("category_id", nil).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Model(&cost.SpecialCost{}).
+			Where("category_id = ? AND workspace_id = ?", id, workspaceID).
+			Update("category_id", nil).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&category).Error
+	})
+}
@@ -3,11 +3,13 @@ package repository
 import (
 	"sort"
 	"wondee/finance-app-backend/internal/cost"
+
+	"gorm.io/gorm"
 )
 
 func (r *PostgresRepository) LoadFixedCosts(workspaceID uint) *[]cost.FixedCost {
 	var costs []cost.FixedCost
-	r.DB.Where("workspace_id = ?", workspaceID).Find(&costs)
+	r.DB.Preload("Revisions").Where("workspace_id = ?", workspaceID).Find(&costs)
 
 	sort.Slice(costs, func(i, j int) bool {
 		isIncomeI := costs[i].Amount >= 0
@@ -25,18 +27,57 @@ func (r *PostgresRepository) LoadFixedCosts(workspaceID uint) *[]cost.FixedCost
 
 func (r *PostgresRepository) LoadFixedCostsByUser(userID uint) *[]cost.FixedCost {
 	var costs []cost.FixedCost
-	r.DB.Where("user_id = ?", userID).Find(&costs)
+	r.DB.Preload("Revisions").Where("user_id = ?", userID).Find(&costs)
 	return &costs
 }
 
-func (r *PostgresRepository) SaveFixedObject(cost *cost.FixedCost) {
+func (r *PostgresRepository) SaveFixedObject(cost *cost.FixedCost) error {
 	if cost.ID == 0 {
-		r.DB.Create(cost)
-	} else {
-		r.DB.Save(cost)
+		return r.DB.Create(cost).Error
+	}
+	return r.DB.Save(cost).Error
+}
+
+func (r *PostgresRepository) GetFixedCost(id int, workspaceID uint) (*cost.FixedCost, error) {
+	var fixedCost cost.FixedCost
+	err := r.DB.Preload("Revisions").Where("id = ? AND workspace_id = ?", id, workspaceID).First(&fixedCost).Error
+	if err != nil {
+		return nil, err
 	}
+	return &fixedCost, nil
 }
 
-func (r *PostgresRepository) DeleteFixedCost(id int, workspaceID uint) {
-	r.DB.Where("workspace_id = ?", workspaceID).Delete(&cost.FixedCost{}, id)
+// DeleteFixedCost moves the cost to the trash. Its revisions are kept until
+// the cost is purged, so a restore brings them back.
+func (r *PostgresRepository) DeleteFixedCost(id int, workspaceID uint) error {
+	return r.DB.Where("workspace_id = ?", workspaceID).Delete(&cost.FixedCost{}, id).Error
+}
+
+// SaveAmountRevision stores a revision, replacing an existing revision of the
+// same fixed cost taking effect in the same month.
+func (r *PostgresRepository) SaveAmountRevision(revision *cost.AmountRevision) error {
+	validFrom, _ := revision.ValidFrom.Value()
+
+	var existing cost.AmountRevision
+	err := r.DB.Where("fixed_cost_id = ? AND valid_from = ?", revision.FixedCostID, validFrom).First(&existing).Error
+	if err == nil {
+		revision.ID = existing.ID
+		revision.CreatedAt = existing.CreatedAt
+		return r.DB.Save(revision).Error
+	} else if err == gorm.ErrRecordNotFound {
+		return r.DB.Create(revision).Error
+	}
+
+	return err
+}
+
+func (r *PostgresRepository) DeleteAmountRevision(id uint, fixedCostID int) error {
+	result := r.DB.Where("id = ? AND fixed_cost_id = ?", id, fixedCostID).Delete(&cost.AmountRevision{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"wondee/finance-app-backend/internal/cost"
+
+	"gorm.io/gorm"
+)
+
+// ImportCosts creates all given costs in one transaction; if one fails,
+// nothing is stored.
+func (r *PostgresRepository) ImportCosts(fixedCosts []cost.FixedCost, specialCosts []cost.SpecialCost) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		for i := range fixedCosts {
+			if err := tx.Create(&fixedCosts[i]).Error; err != nil {
+				return err
+			}
+		}
+		for i := range specialCosts {
+			if err := tx.Create(&specialCosts[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -8,13 +8,22 @@ import (
 type Repository interface {
 	LoadFixedCosts(workspaceID uint) *[]cost.FixedCost
 	LoadFixedCostsByUser(userID uint) *[]cost.FixedCost
-	SaveFixedObject(cost *cost.FixedCost)
-	DeleteFixedCost(id int, workspaceID uint)
+	SaveFixedObject(cost *cost.FixedCost) error
+	DeleteFixedCost(id int, workspaceID uint) error
+	GetFixedCost(id int, workspaceID uint) (*cost.FixedCost, error)
+	SaveAmountRevision(revision *cost.AmountRevision) error
+	DeleteAmountRevision(id uint, fixedCostID int) error
 
 	LoadSpecialCosts(workspaceID uint) *[]cost.SpecialCost
 	LoadSpecialCostsByUser(userID uint) *[]cost.SpecialCost
-	SaveSpecialCost(cost *cost.SpecialCost)
-	DeleteSpecialCost(id int, workspaceID uint)
+	SaveSpecialCost(cost *cost.SpecialCost) error
+	DeleteSpecialCost(id int, workspaceID uint) error
+
+	LoadCategories(workspaceID uint) ([]cost.Category, error)
+	SaveCategory(category *cost.Category) error
+	DeleteCategory(id uint, workspaceID uint) error
+
+	ImportCosts(fixedCosts []cost.FixedCost, specialCosts []cost.SpecialCost) error
 }
 
 type PostgresRepository struct {
@@ -35,14 +35,14 @@ func (r *PostgresRepository) LoadSpecialCostsByUser(userID uint) *[]cost.Special
 	return &specialCosts
 }
 
-func (r *PostgresRepository) SaveSpecialCost(cost *cost.SpecialCost) {
+func (r *PostgresRepository) SaveSpecialCost(cost *cost.SpecialCost) error {
 	if cost.ID == 0 {
-		r.DB.Create(cost)
-	} else {
-		r.DB.Save(cost)
+		return r.DB.Create(cost).Error
 	}
+	return r.DB.Save(cost).Error
 }
 
-func (r *PostgresRepository) DeleteSpecialCost(id int, workspaceID uint) {
-	r.DB.Where("workspace_id = ?", workspaceID).Delete(&cost.SpecialCost{}, id)
+// DeleteSpecialCost moves the cost to the trash.
+func (r *PostgresRepository) DeleteSpecialCost(id int, workspaceID uint) error {
+	return r.DB.Where("workspace_id = ?", workspaceID).Delete(&cost.SpecialCost{}, id).Error
 }
@@ -1,13 +1,200 @@
 package cost
 
-import "wondee/finance-app-backend/internal/platform/types"
+import (
+	"fmt"
+	"math"
+
+	"wondee/finance-app-backend/internal/platform/types"
+
+	"gorm.io/gorm"
+)
 
 type SpecialCost struct {
-	ID          int    `gorm:"primary_key"`
-	UserID      uint   `json:"user_id"`
-	WorkspaceID uint   `json:"workspace_id"`
+	ID          int  `gorm:"primary_key"`
+	UserID      uint `json:"user_id"`
+	WorkspaceID uint `json:"workspace_id"`
 	Name        string
 	Amount      int
+	Currency    string `gorm:"size:3"` // ISO 4217 code, empty for the workspace's base currency
 	DueDate     *types.YearMonth
 	IsSaving    bool
+	CategoryID  *uint `gorm:"index"`
+	AccountID   *uint `gorm:"index"` // nil for the default account
+	Tags        Tags  `gorm:"type:string"`
+	Split       Split `gorm:"type:string"`
+
+	// Transfers move the amount from AccountID to TargetAccountID; they are
+	// neither income nor expense of the workspace.
+	TargetAccountID *uint `gorm:"index"`
+
+	// Optional installment plan: Amount is paid off in Installments monthly
+	// payments starting with DueDate. InterestRate is charged per year on
+	// the outstanding balance, Fee is due with the first payment.
+	Installments int
+	InterestRate float64
+	Fee          int
+
+	// Optional estimate: the cost may turn out anywhere between MinAmount
+	// and MaxAmount, Amount being the most likely value. Probability is the
+	// chance in percent that the cost occurs at all, nil meaning certain.
+	MinAmount   *int
+	MaxAmount   *int
+	Probability *float64
+
+	// Deleted costs stay in the trash until they are restored or purged.
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// Payment is a single payment of a special cost.
+type Payment struct {
+	Number  int             `json:"number"`
+	DueDate types.YearMonth `json:"dueDate"`
+	Amount  int             `json:"amount"`
+}
+
+// IsTransfer reports whether the cost moves money between two accounts.
+func (sc *SpecialCost) IsTransfer() bool {
+	return sc.TargetAccountID != nil
+}
+
+// IsInstallmentPlan reports whether the cost is paid in several parts.
+func (sc *SpecialCost) IsInstallmentPlan() bool {
+	return sc.Installments > 1
+}
+
+// Payments returns the payments of the cost: a single one at DueDate or one
+// per installment. Interest and fee carry the sign of the amount; rounding
+// differences are settled with the last installment.
+func (sc *SpecialCost) Payments() []Payment {
+	if sc.DueDate == nil {
+		return nil
+	}
+
+	amounts := sc.divide(sc.Amount)
+	payments := make([]Payment, len(amounts))
+	for i, amount := range amounts {
+		payments[i] = Payment{
+			Number:  i + 1,
+			DueDate: *types.AddMonths(sc.DueDate, i),
+			Amount:  amount,
+		}
+	}
+	return payments
+}
+
+// divide splits an amount of the cost into its payments, so the bounds of
+// an estimate are divided just like the amount.
+func (sc *SpecialCost) divide(amount int) []int {
+	if !sc.IsInstallmentPlan() {
+		return []int{amount}
+	}
+
+	sign := 1
+	if amount < 0 {
+		sign = -1
+	}
+
+	n := sc.Installments
+	principal := float64(sign * amount)
+
+	total := principal
+	if rate := sc.InterestRate / 12 / 100; rate > 0 {
+		// Annuity: equal payments covering interest and redemption
+		total = principal * rate / (1 - math.Pow(1+rate, -float64(n))) * float64(n)
+	}
+
+	installment := int(math.Round(total / float64(n)))
+	last := int(math.Round(total)) - installment*(n-1)
+
+	amounts := make([]int, n)
+	for i := range amounts {
+		amounts[i] = installment
+		if i == n-1 {
+			amounts[i] = last
+		}
+		if i == 0 {
+			amounts[i] += sc.Fee
+		}
+		amounts[i] *= sign
+	}
+	return amounts
+}
+
+// TotalAmount is the sum of all payments including interest and fee.
+func (sc *SpecialCost) TotalAmount() int {
+	if !sc.IsInstallmentPlan() {
+		return sc.Amount
+	}
+
+	total := 0
+	for _, payment := range sc.Payments() {
+		total += payment.Amount
+	}
+	return total
+}
+
+// Expand returns one special cost per payment. The parts keep the ID of the
+// cost, so the plan is still edited and deleted as one unit; installments
+// are named like "Sofa (2/6)".
+func (sc *SpecialCost) Expand() []SpecialCost {
+	payments := sc.Payments()
+
+	var mins, maxs []int
+	if sc.MinAmount != nil {
+		mins = sc.divide(*sc.MinAmount)
+	}
+	if sc.MaxAmount != nil {
+		maxs = sc.divide(*sc.MaxAmount)
+	}
+
+	result := make([]SpecialCost, 0, len(payments))
+	for i, payment := range payments {
+		part := *sc
+		part.Amount = payment.Amount
+		part.DueDate = &payment.DueDate
+		if mins != nil {
+			part.MinAmount = &mins[i]
+		}
+		if maxs != nil {
+			part.MaxAmount = &maxs[i]
+		}
+		if sc.IsInstallmentPlan() {
+			part.Name = fmt.Sprintf("%s (%d/%d)", sc.Name, payment.Number, sc.Installments)
+		}
+		result = append(result, part)
+	}
+	return result
+}
+
+// IsUncertain reports whether the cost is an estimate with a range or a
+// probability below 100 percent.
+func (sc *SpecialCost) IsUncertain() bool {
+	return sc.MinAmount != nil || sc.MaxAmount != nil || sc.occurrence() < 1
+}
+
+// ExpectedAmount is the amount weighted with the probability of the cost.
+func (sc *SpecialCost) ExpectedAmount() int {
+	return int(math.Round(float64(sc.Amount) * sc.occurrence()))
+}
+
+// Bounds returns the lowest and highest possible amount of the cost. A cost
+// that might not occur at all can also end up as zero.
+func (sc *SpecialCost) Bounds() (low, high int) {
+	low, high = sc.Amount, sc.Amount
+	for _, amount := range []*int{sc.MinAmount, sc.MaxAmount} {
+		if amount != nil {
+			low, high = min(low, *amount), max(high, *amount)
+		}
+	}
+	if sc.occurrence() < 1 {
+		low, high = min(low, 0), max(high, 0)
+	}
+	return low, high
+}
+
+func (sc *SpecialCost) occurrence() float64 {
+	if sc.Probability == nil {
+		return 1
+	}
+	return *sc.Probability / 100
 }
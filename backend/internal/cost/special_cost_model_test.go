@@ -0,0 +1,137 @@
+package cost
+
+import (
+	"testing"
+
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+func TestPaymentsOfSingleCost(t *testing.T) {
+	sc := &SpecialCost{Name: "Car", Amount: -1000, DueDate: &types.YearMonth{Year: 2025, Month: 5}}
+
+	payments := sc.Payments()
+	if len(payments) != 1 || payments[0].Amount != -1000 || payments[0].DueDate != *sc.DueDate {
+		t.Errorf("Expected a single payment, got %+v", payments)
+	}
+
+	if payments := (&SpecialCost{Amount: -1000}).Payments(); len(payments) != 0 {
+		t.Errorf("Expected no payment without due date, got %+v", payments)
+	}
+}
+
+func TestPaymentsOfInstallmentPlan(t *testing.T) {
+	sc := &SpecialCost{
+		Name:         "Sofa",
+		Amount:       -1000,
+		DueDate:      &types.YearMonth{Year: 2025, Month: 11},
+		Installments: 6,
+		Fee:          15,
+	}
+
+	payments := sc.Payments()
+	if len(payments) != 6 {
+		t.Fatalf("Expected 6 payments, got %d", len(payments))
+	}
+
+	// 1000 / 6 = 166.67: five times 167, the last one 165, the fee with the first
+	expected := []int{-182, -167, -167, -167, -167, -165}
+	for i, payment := range payments {
+		if payment.Amount != expected[i] || payment.Number != i+1 {
+			t.Errorf("Payment %d: expected %d, got %+v", i+1, expected[i], payment)
+		}
+	}
+	if payments[2].DueDate != (types.YearMonth{Year: 2026, Month: 1}) {
+		t.Errorf("Expected third installment in January, got %+v", payments[2].DueDate)
+	}
+	if sc.TotalAmount() != -1015 {
+		t.Errorf("Expected total of -1015, got %d", sc.TotalAmount())
+	}
+}
+
+func TestPaymentsWithInterest(t *testing.T) {
+	sc := &SpecialCost{
+		Amount:       -12000,
+		DueDate:      &types.YearMonth{Year: 2025, Month: 1},
+		Installments: 12,
+		InterestRate: 6,
+	}
+
+	// Annuity of 12000 at 0.5% per month over 12 months: 1032.80
+	payments := sc.Payments()
+	if payments[0].Amount != -1033 || payments[11].Amount != -1031 {
+		t.Errorf("Unexpected installments %+v", payments)
+	}
+	if sc.TotalAmount() != -12394 {
+		t.Errorf("Expected total of -12394, got %d", sc.TotalAmount())
+	}
+}
+
+func TestExpand(t *testing.T) {
+	sc := &SpecialCost{ID: 4, Name: "Sofa", Amount: -600, DueDate: &types.YearMonth{Year: 2025, Month: 1}, Installments: 3}
+
+	parts := sc.Expand()
+	if len(parts) != 3 {
+		t.Fatalf("Expected 3 parts, got %d", len(parts))
+	}
+	if parts[1].ID != 4 || parts[1].Name != "Sofa (2/3)" || parts[1].Amount != -200 || parts[1].DueDate.Month != 2 {
+		t.Errorf("Unexpected part %+v", parts[1])
+	}
+
+	single := &SpecialCost{ID: 5, Name: "Car", Amount: -1000, DueDate: &types.YearMonth{Year: 2025, Month: 1}}
+	if parts := single.Expand(); len(parts) != 1 || parts[0].Name != "Car" {
+		t.Errorf("Expected the cost itself, got %+v", parts)
+	}
+}
+
+func TestUncertainCost(t *testing.T) {
+	minAmount, maxAmount, probability := -400, -1500, 60.0
+	sc := &SpecialCost{Name: "Car repair", Amount: -800, MinAmount: &minAmount, MaxAmount: &maxAmount, Probability: &probability}
+
+	if !sc.IsUncertain() {
+		t.Error("Expected the cost to be uncertain")
+	}
+	if sc.ExpectedAmount() != -480 {
+		t.Errorf("Expected -480, got %d", sc.ExpectedAmount())
+	}
+	// The repair might not be needed at all
+	if low, high := sc.Bounds(); low != -1500 || high != 0 {
+		t.Errorf("Expected bounds -1500..0, got %d..%d", low, high)
+	}
+
+	sc.Probability = nil
+	if low, high := sc.Bounds(); low != -1500 || high != -400 {
+		t.Errorf("Expected bounds -1500..-400, got %d..%d", low, high)
+	}
+
+	certain := &SpecialCost{Amount: -800}
+	if certain.IsUncertain() || certain.ExpectedAmount() != -800 {
+		t.Errorf("Expected a certain cost to keep its amount, got %d", certain.ExpectedAmount())
+	}
+	if low, high := certain.Bounds(); low != -800 || high != -800 {
+		t.Errorf("Expected bounds -800..-800, got %d..%d", low, high)
+	}
+}
+
+func TestExpandDividesBounds(t *testing.T) {
+	minAmount, maxAmount := -300, -900
+	sc := &SpecialCost{
+		Name:         "Kitchen",
+		Amount:       -600,
+		MinAmount:    &minAmount,
+		MaxAmount:    &maxAmount,
+		DueDate:      &types.YearMonth{Year: 2025, Month: 1},
+		Installments: 3,
+	}
+
+	parts := sc.Expand()
+	for _, part := range parts {
+		if low, high := part.Bounds(); low != -300 || high != -100 {
+			t.Errorf("Expected bounds -300..-100 for %s, got %d..%d", part.Name, low, high)
+		}
+	}
+
+	// The plan itself keeps its range
+	if low, high := sc.Bounds(); low != -900 || high != -300 {
+		t.Errorf("Expected bounds -900..-300, got %d..%d", low, high)
+	}
+}
@@ -0,0 +1,124 @@
+package cost
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Share is the part of a cost one workspace member carries: either Percent
+// of every payment or a fixed Amount per payment.
+type Share struct {
+	UserID  uint    `json:"userId"`
+	Percent float64 `json:"percent,omitempty"`
+	Amount  int     `json:"amount,omitempty"`
+}
+
+// Split assigns a cost to workspace members. Fixed amounts are taken off a
+// payment first, the rest is divided by the percentages. Whatever remains
+// unassigned, e.g. the whole payment of a cost without split, is shared
+// equally by all members. A cost owned by one member has a single share of
+// 100 percent.
+type Split []Share
+
+func (s Split) Validate() error {
+	seen := make(map[uint]bool)
+	percent := 0.0
+
+	for _, share := range s {
+		if share.UserID == 0 {
+			return errors.New("userId is required for every share")
+		}
+		if seen[share.UserID] {
+			return fmt.Errorf("user %d has more than one share", share.UserID)
+		}
+		seen[share.UserID] = true
+
+		if share.Percent < 0 || share.Amount < 0 {
+			return errors.New("shares must not be negative")
+		}
+		if (share.Percent > 0) == (share.Amount > 0) {
+			return errors.New("a share needs either percent or amount")
+		}
+		percent += share.Percent
+	}
+
+	if percent > 100+1e-9 {
+		return errors.New("percentages must not add up to more than 100")
+	}
+
+	return nil
+}
+
+// Divide splits a single payment between the given members. Shares of users
+// who are no longer members count as unassigned.
+func (s Split) Divide(payment float64, members []uint) map[uint]float64 {
+	result := make(map[uint]float64)
+
+	isMember := make(map[uint]bool)
+	for _, member := range members {
+		isMember[member] = true
+	}
+
+	sign := 1.0
+	if payment < 0 {
+		sign = -1
+	}
+
+	remaining := payment
+	for _, share := range s {
+		if share.Amount > 0 && isMember[share.UserID] {
+			part := math.Min(float64(share.Amount), math.Abs(remaining)) * sign
+			result[share.UserID] += part
+			remaining -= part
+		}
+	}
+
+	unassigned := remaining
+	for _, share := range s {
+		if share.Percent > 0 && isMember[share.UserID] {
+			part := remaining * share.Percent / 100
+			result[share.UserID] += part
+			unassigned -= part
+		}
+	}
+
+	if math.Abs(unassigned) > 1e-9 && len(members) > 0 {
+		each := unassigned / float64(len(members))
+		for _, member := range members {
+			result[member] += each
+		}
+	}
+
+	return result
+}
+
+func (this *Split) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(data, this)
+}
+
+func (this Split) Value() (driver.Value, error) {
+	if len(this) == 0 {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(this)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
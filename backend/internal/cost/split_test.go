@@ -0,0 +1,87 @@
+package cost
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSplitDivide(t *testing.T) {
+	members := []uint{1, 2}
+
+	tests := []struct {
+		name     string
+		split    Split
+		payment  float64
+		expected map[uint]float64
+	}{
+		{"shared equally", nil, -100, map[uint]float64{1: -50, 2: -50}},
+		{"owned by one member", Split{{UserID: 2, Percent: 100}}, 3000, map[uint]float64{1: 0, 2: 3000}},
+		{"percentages", Split{{UserID: 1, Percent: 60}, {UserID: 2, Percent: 40}}, -1000, map[uint]float64{1: -600, 2: -400}},
+		{"fixed amount, rest shared", Split{{UserID: 1, Amount: 200}}, -1000, map[uint]float64{1: -600, 2: -400}},
+		{"fixed amount and percent", Split{{UserID: 1, Amount: 200}, {UserID: 2, Percent: 100}}, -1000, map[uint]float64{1: -200, 2: -800}},
+		{"fixed amount above payment", Split{{UserID: 1, Amount: 80}, {UserID: 2, Amount: 80}}, -100, map[uint]float64{1: -80, 2: -20}},
+		{"former member", Split{{UserID: 3, Percent: 100}}, -100, map[uint]float64{1: -50, 2: -50}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts := tt.split.Divide(tt.payment, members)
+			for member, expected := range tt.expected {
+				if math.Abs(parts[member]-expected) > 1e-9 {
+					t.Errorf("Expected %v for member %d, got %v", expected, member, parts[member])
+				}
+			}
+			if _, ok := parts[3]; ok {
+				t.Errorf("Expected no part for former member, got %v", parts)
+			}
+		})
+	}
+}
+
+func TestSplitValidate(t *testing.T) {
+	valid := []Split{
+		nil,
+		{{UserID: 1, Percent: 100}},
+		{{UserID: 1, Percent: 30}, {UserID: 2, Amount: 50}},
+	}
+	for _, split := range valid {
+		if err := split.Validate(); err != nil {
+			t.Errorf("Expected %+v to be valid, got %v", split, err)
+		}
+	}
+
+	invalid := []Split{
+		{{Percent: 100}},
+		{{UserID: 1, Percent: 50}, {UserID: 1, Percent: 50}},
+		{{UserID: 1, Percent: 70}, {UserID: 2, Percent: 40}},
+		{{UserID: 1, Percent: 50, Amount: 10}},
+		{{UserID: 1}},
+		{{UserID: 1, Amount: -10}},
+	}
+	for _, split := range invalid {
+		if err := split.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", split)
+		}
+	}
+}
+
+func TestSplitValueAndScan(t *testing.T) {
+	split := Split{{UserID: 1, Percent: 60}, {UserID: 2, Amount: 25}}
+
+	value, err := split.Value()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var scanned Split
+	if err := scanned.Scan(value); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(scanned) != 2 || scanned[0] != split[0] || scanned[1] != split[1] {
+		t.Errorf("Expected %+v, got %+v", split, scanned)
+	}
+
+	if value, _ := Split(nil).Value(); value != nil {
+		t.Errorf("Expected NULL for empty split, got %v", value)
+	}
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/currency"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/storage"
+)
+
+type Handler struct {
+	Repo storage.Repository
+}
+
+type CurrencySettings struct {
+	BaseCurrency string             `json:"baseCurrency"`
+	Rates        []JsonExchangeRate `json:"rates"`
+}
+
+type JsonExchangeRate struct {
+	ID        uint             `json:"id"`
+	Currency  string           `json:"currency"`
+	Rate      float64          `json:"rate"`
+	ValidFrom *types.YearMonth `json:"validFrom"`
+}
+
+type UpdateBaseCurrencyRequest struct {
+	BaseCurrency string `json:"baseCurrency" binding:"required"`
+}
+
+// GetCurrencySettings returns the base currency and the exchange-rate table
+// of the workspace.
+func (h *Handler) GetCurrencySettings(c *gin.Context) {
+	h.respondWithSettings(c, h.getWorkspaceID(c))
+}
+
+// UpdateBaseCurrency changes the currency all amounts are converted into.
+// Existing rates are kept as they are and have to be maintained by the user.
+func (h *Handler) UpdateBaseCurrency(c *gin.Context) {
+	var req UpdateBaseCurrencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	code, err := currency.Normalize(req.BaseCurrency)
+	if err != nil || code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "baseCurrency must be a three-letter ISO 4217 code"})
+		return
+	}
+
+	workspaceID := h.getWorkspaceID(c)
+	if err := h.Repo.UpdateWorkspaceBaseCurrency(workspaceID, code); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update base currency"})
+		return
+	}
+
+	h.respondWithSettings(c, workspaceID)
+}
+
+// SaveExchangeRate adds a rate valid from the given month onwards. An
+// existing rate of the same currency and month is replaced.
+func (h *Handler) SaveExchangeRate(c *gin.Context) {
+	var jsonRate JsonExchangeRate
+	if err := c.ShouldBindJSON(&jsonRate); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workspaceID := h.getWorkspaceID(c)
+	rate, err := ToDBExchangeRate(&jsonRate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	rate.WorkspaceID = workspaceID
+
+	if err := h.Repo.SaveExchangeRate(rate); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save exchange rate"})
+		return
+	}
+
+	h.respondWithSettings(c, workspaceID)
+}
+
+func (h *Handler) DeleteExchangeRate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	workspaceID := h.getWorkspaceID(c)
+	if err := h.Repo.DeleteExchangeRate(uint(id), workspaceID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Exchange rate not found"})
+		return
+	}
+
+	h.respondWithSettings(c, workspaceID)
+}
+
+func ToDBExchangeRate(jsonRate *JsonExchangeRate) (*currency.ExchangeRate, error) {
+	code, err := currency.Normalize(jsonRate.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if code == "" {
+		return nil, errors.New("currency is required")
+	}
+
+	if jsonRate.Rate <= 0 {
+		return nil, errors.New("rate must be positive")
+	}
+
+	if jsonRate.ValidFrom == nil {
+		return nil, errors.New("validFrom is required")
+	}
+	if _, err := types.New(jsonRate.ValidFrom.Year, jsonRate.ValidFrom.Month); err != nil {
+		return nil, err
+	}
+
+	return &currency.ExchangeRate{
+		Currency:  code,
+		Rate:      jsonRate.Rate,
+		ValidFrom: *jsonRate.ValidFrom,
+	}, nil
+}
+
+func (h *Handler) respondWithSettings(c *gin.Context, workspaceID uint) {
+	baseCurrency := currency.DefaultCurrency
+	if ws, err := h.Repo.GetWorkspaceByID(workspaceID); err == nil && ws.BaseCurrency != "" {
+		baseCurrency = ws.BaseCurrency
+	}
+
+	rates, err := h.Repo.GetExchangeRates(workspaceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load exchange rates"})
+		return
+	}
+
+	jsonRates := make([]JsonExchangeRate, 0, len(rates))
+	for _, rate := range rates {
+		validFrom := rate.ValidFrom
+		jsonRates = append(jsonRates, JsonExchangeRate{
+			ID:        rate.ID,
+			Currency:  rate.Currency,
+			Rate:      rate.Rate,
+			ValidFrom: &validFrom,
+		})
+	}
+
+	c.JSON(http.StatusOK, CurrencySettings{
+		BaseCurrency: baseCurrency,
+		Rates:        jsonRates,
+	})
+}
+
+func (h *Handler) getWorkspaceID(c *gin.Context) uint {
+	workspaceID, exists := c.Get("workspace_id")
+	if !exists {
+		return 0
+	}
+	return workspaceID.(uint)
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/currency"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/storage"
+	"wondee/finance-app-backend/internal/workspace"
+)
+
+func setupRouter(mockRepo *storage.MockRepository) *gin.Engine {
+	handler := &Handler{Repo: mockRepo}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+
+	r.GET("/currency", handler.GetCurrencySettings)
+	r.PUT("/currency/base", handler.UpdateBaseCurrency)
+	r.POST("/currency/rates", handler.SaveExchangeRate)
+	r.DELETE("/currency/rates/:id", handler.DeleteExchangeRate)
+
+	return r
+}
+
+func sendJSON(r *gin.Engine, method, path string, body map[string]interface{}) *httptest.ResponseRecorder {
+	reqBody, _ := json.Marshal(body)
+	req, _ := http.NewRequest(method, path, bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func decodeSettings(t *testing.T, w *httptest.ResponseRecorder) CurrencySettings {
+	var settings CurrencySettings
+	if err := json.Unmarshal(w.Body.Bytes(), &settings); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return settings
+}
+
+func TestSaveExchangeRate_ReplacesRateOfSameMonth(t *testing.T) {
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: 1, BaseCurrency: "EUR"}},
+	}
+	r := setupRouter(mockRepo)
+
+	validFrom := map[string]int{"year": 2025, "month": 1}
+	sendJSON(r, http.MethodPost, "/currency/rates", map[string]interface{}{"currency": "chf", "rate": 1.05, "validFrom": validFrom})
+	w := sendJSON(r, http.MethodPost, "/currency/rates", map[string]interface{}{"currency": "CHF", "rate": 1.07, "validFrom": validFrom})
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	settings := decodeSettings(t, w)
+	if settings.BaseCurrency != "EUR" {
+		t.Errorf("Expected base currency EUR, got %s", settings.BaseCurrency)
+	}
+	if len(settings.Rates) != 1 || settings.Rates[0].Currency != "CHF" || settings.Rates[0].Rate != 1.07 {
+		t.Errorf("Expected a single CHF rate of 1.07, got %+v", settings.Rates)
+	}
+}
+
+func TestSaveExchangeRate_Validation(t *testing.T) {
+	r := setupRouter(&storage.MockRepository{})
+
+	validFrom := map[string]int{"year": 2025, "month": 1}
+	tests := []map[string]interface{}{
+		{"currency": "", "rate": 1.05, "validFrom": validFrom},
+		{"currency": "SWISS", "rate": 1.05, "validFrom": validFrom},
+		{"currency": "CHF", "rate": 0, "validFrom": validFrom},
+		{"currency": "CHF", "rate": 1.05},
+		{"currency": "CHF", "rate": 1.05, "validFrom": map[string]int{"year": 2025, "month": 13}},
+	}
+
+	for _, body := range tests {
+		if w := sendJSON(r, http.MethodPost, "/currency/rates", body); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %v, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestUpdateBaseCurrency(t *testing.T) {
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: 1, BaseCurrency: "EUR"}},
+	}
+	r := setupRouter(mockRepo)
+
+	w := sendJSON(r, http.MethodPut, "/currency/base", map[string]interface{}{"baseCurrency": "chf"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if settings := decodeSettings(t, w); settings.BaseCurrency != "CHF" {
+		t.Errorf("Expected base currency CHF, got %s", settings.BaseCurrency)
+	}
+
+	if w := sendJSON(r, http.MethodPut, "/currency/base", map[string]interface{}{"baseCurrency": "francs"}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid code, got %d", w.Code)
+	}
+}
+
+func TestDeleteExchangeRate_OnlyWithinWorkspace(t *testing.T) {
+	mockRepo := &storage.MockRepository{
+		ExchangeRates: []currency.ExchangeRate{
+			{ID: 1, WorkspaceID: 1, Currency: "USD", Rate: 0.9, ValidFrom: types.YearMonth{Year: 2025, Month: 1}},
+			{ID: 2, WorkspaceID: 2, Currency: "USD", Rate: 0.8, ValidFrom: types.YearMonth{Year: 2025, Month: 1}},
+		},
+	}
+	r := setupRouter(mockRepo)
+
+	if w := sendJSON(r, http.MethodDelete, "/currency/rates/2", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a rate of another workspace, got %d", w.Code)
+	}
+
+	w := sendJSON(r, http.MethodDelete, "/currency/rates/1", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", w.Code)
+	}
+	if settings := decodeSettings(t, w); len(settings.Rates) != 0 {
+		t.Errorf("Expected no rates left, got %+v", settings.Rates)
+	}
+	if len(mockRepo.ExchangeRates) != 1 {
+		t.Errorf("Expected the other workspace's rate to be kept, got %d rates", len(mockRepo.ExchangeRates))
+	}
+}
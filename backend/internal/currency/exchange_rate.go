@@ -0,0 +1,168 @@
+package currency
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/workspace"
+)
+
+// DefaultCurrency is the base currency of workspaces that never chose one.
+const DefaultCurrency = "EUR"
+
+// ExchangeRate states how many units of the workspace's base currency one
+// unit of Currency is worth, starting with ValidFrom. A rate stays valid
+// until the next rate of the same currency.
+type ExchangeRate struct {
+	ID          uint            `gorm:"primaryKey"`
+	WorkspaceID uint            `gorm:"not null;uniqueIndex:idx_exchange_rate_month,priority:1"`
+	Currency    string          `gorm:"size:3;not null;uniqueIndex:idx_exchange_rate_month,priority:2"`
+	Rate        float64         `gorm:"not null"`
+	ValidFrom   types.YearMonth `gorm:"type:string;not null;uniqueIndex:idx_exchange_rate_month,priority:3"`
+	CreatedAt   time.Time
+}
+
+// Normalize upper-cases an ISO 4217 currency code. An empty code is kept and
+// stands for the base currency of the workspace.
+func Normalize(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return "", nil
+	}
+
+	if len(code) != 3 {
+		return "", errors.New("currency must be a three-letter ISO 4217 code")
+	}
+	for _, ch := range code {
+		if ch < 'A' || ch > 'Z' {
+			return "", errors.New("currency must be a three-letter ISO 4217 code")
+		}
+	}
+
+	return code, nil
+}
+
+// SortRates orders rates by currency and then by ValidFrom.
+func SortRates(rates []ExchangeRate) {
+	sort.SliceStable(rates, func(i, j int) bool {
+		if rates[i].Currency != rates[j].Currency {
+			return rates[i].Currency < rates[j].Currency
+		}
+		return types.MonthsBetween(&rates[i].ValidFrom, &rates[j].ValidFrom) > 0
+	})
+}
+
+// Converter turns amounts into the base currency of a workspace. It keeps
+// track of the currencies it could not convert for lack of a rate.
+type Converter struct {
+	base    string
+	rates   map[string][]ExchangeRate
+	missing map[string]bool
+}
+
+// NewConverter creates a converter into base using the given rates. An empty
+// base falls back to DefaultCurrency.
+func NewConverter(base string, rates []ExchangeRate) *Converter {
+	base, _ = Normalize(base)
+	if base == "" {
+		base = DefaultCurrency
+	}
+
+	sorted := append([]ExchangeRate(nil), rates...)
+	SortRates(sorted)
+
+	byCurrency := make(map[string][]ExchangeRate)
+	for _, rate := range sorted {
+		byCurrency[rate.Currency] = append(byCurrency[rate.Currency], rate)
+	}
+
+	return &Converter{base: base, rates: byCurrency, missing: make(map[string]bool)}
+}
+
+// Source provides the base currency and the exchange rates of a workspace.
+type Source interface {
+	GetWorkspaceByID(id uint) (*workspace.Workspace, error)
+	GetExchangeRates(workspaceID uint) ([]ExchangeRate, error)
+}
+
+// LoadConverter prepares the conversion into the workspace's base currency.
+// Without a stored workspace DefaultCurrency is the base. If the rates cannot
+// be read, the returned converter knows none of them.
+func LoadConverter(source Source, workspaceID uint) (*Converter, error) {
+	base := ""
+	if workspace, err := source.GetWorkspaceByID(workspaceID); err == nil {
+		base = workspace.BaseCurrency
+	}
+
+	rates, err := source.GetExchangeRates(workspaceID)
+	if err != nil {
+		return NewConverter(base, nil), err
+	}
+	return NewConverter(base, rates), nil
+}
+
+// Base returns the currency amounts are converted into.
+func (c *Converter) Base() string {
+	return c.base
+}
+
+// RateAt returns the rate of code valid in the given month. Months before
+// the first known rate use that first rate. The second result is false if
+// the currency has no rate at all.
+func (c *Converter) RateAt(code string, ym *types.YearMonth) (float64, bool) {
+	code, _ = Normalize(code)
+	if code == "" || code == c.base {
+		return 1, true
+	}
+
+	rates := c.rates[code]
+	if len(rates) == 0 {
+		return 1, false
+	}
+
+	result := rates[0].Rate
+	for _, rate := range rates[1:] {
+		if types.MonthsBetween(&rate.ValidFrom, ym) < 0 {
+			break
+		}
+		result = rate.Rate
+	}
+
+	return result, true
+}
+
+// Convert returns amount in the base currency, rounded to whole units.
+// Amounts in currencies without any rate are taken over unchanged and the
+// currency is reported by Missing.
+func (c *Converter) Convert(amount int, code string, ym *types.YearMonth) int {
+	return int(math.Round(c.ConvertFloat(float64(amount), code, ym)))
+}
+
+// ConvertFloat is Convert without rounding, for averaged amounts.
+func (c *Converter) ConvertFloat(amount float64, code string, ym *types.YearMonth) float64 {
+	code, _ = Normalize(code)
+	rate, ok := c.RateAt(code, ym)
+	if !ok {
+		c.missing[code] = true
+	}
+	return amount * rate
+}
+
+// Missing returns the currencies, sorted, that amounts were converted from
+// without a rate, or nil if there were none.
+func (c *Converter) Missing() []string {
+	if len(c.missing) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(c.missing))
+	for code := range c.missing {
+		result = append(result, code)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,95 @@
+package currency
+
+import (
+	"testing"
+
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		valid    bool
+	}{
+		{"chf", "CHF", true},
+		{" usd ", "USD", true},
+		{"", "", true},
+		{"EURO", "", false},
+		{"E1R", "", false},
+	}
+
+	for _, tt := range tests {
+		code, err := Normalize(tt.input)
+		if tt.valid && (err != nil || code != tt.expected) {
+			t.Errorf("Normalize(%q) = %q, %v; expected %q", tt.input, code, err, tt.expected)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("Normalize(%q) expected an error", tt.input)
+		}
+	}
+}
+
+func TestConverter_UsesRateValidInMonth(t *testing.T) {
+	converter := NewConverter("EUR", []ExchangeRate{
+		{Currency: "CHF", Rate: 1.10, ValidFrom: types.YearMonth{Year: 2025, Month: 7}},
+		{Currency: "CHF", Rate: 1.05, ValidFrom: types.YearMonth{Year: 2025, Month: 1}},
+	})
+
+	tests := []struct {
+		month    types.YearMonth
+		expected int
+	}{
+		{types.YearMonth{Year: 2024, Month: 6}, -105}, // before the first rate
+		{types.YearMonth{Year: 2025, Month: 1}, -105},
+		{types.YearMonth{Year: 2025, Month: 6}, -105},
+		{types.YearMonth{Year: 2025, Month: 7}, -110},
+		{types.YearMonth{Year: 2026, Month: 3}, -110},
+	}
+
+	for _, tt := range tests {
+		if got := converter.Convert(-100, "CHF", &tt.month); got != tt.expected {
+			t.Errorf("Convert in %v: expected %d, got %d", tt.month, tt.expected, got)
+		}
+	}
+}
+
+func TestConverter_BaseAndUnknownCurrencies(t *testing.T) {
+	month := types.YearMonth{Year: 2025, Month: 1}
+	converter := NewConverter("", []ExchangeRate{
+		{Currency: "USD", Rate: 0.9, ValidFrom: month},
+	})
+
+	if missing := converter.Missing(); missing != nil {
+		t.Errorf("Expected no missing rates yet, got %v", missing)
+	}
+
+	if converter.Base() != DefaultCurrency {
+		t.Errorf("Expected base %s, got %s", DefaultCurrency, converter.Base())
+	}
+
+	if got := converter.Convert(100, "", &month); got != 100 {
+		t.Errorf("Expected amount without currency unchanged, got %d", got)
+	}
+
+	if got := converter.Convert(100, "EUR", &month); got != 100 {
+		t.Errorf("Expected base currency unchanged, got %d", got)
+	}
+
+	if _, ok := converter.RateAt("GBP", &month); ok {
+		t.Error("Expected no rate for GBP")
+	}
+
+	if got := converter.Convert(100, "GBP", &month); got != 100 {
+		t.Errorf("Expected currency without rate unchanged, got %d", got)
+	}
+
+	if got := converter.Convert(333, "usd", &month); got != 300 {
+		t.Errorf("Expected 300, got %d", got)
+	}
+
+	converter.Convert(100, "chf", &month)
+	if missing := converter.Missing(); len(missing) != 2 || missing[0] != "CHF" || missing[1] != "GBP" {
+		t.Errorf("Expected CHF and GBP to be reported missing, got %v", missing)
+	}
+}
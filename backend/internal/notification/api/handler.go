@@ -0,0 +1,98 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"wondee/finance-app-backend/internal/notification"
+	"wondee/finance-app-backend/internal/notification/repository"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+// Handler handles HTTP requests for the notifications of a workspace
+type Handler struct {
+	repo repository.Repository
+}
+
+// NewHandler creates a new Handler instance
+func NewHandler(repo repository.Repository) *Handler {
+	return &Handler{repo: repo}
+}
+
+// Response types
+
+type NotificationDTO struct {
+	ID        uint            `json:"id"`
+	Kind      string          `json:"kind"`
+	Subject   string          `json:"subject"`
+	Month     types.YearMonth `json:"month"`
+	Amount    int             `json:"amount"`
+	Threshold int             `json:"threshold"`
+	CreatedBy uint            `json:"createdBy"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Read      bool            `json:"read"`
+}
+
+// GetNotifications lists the notifications of the workspace, newest first;
+// with ?unread=true only those not marked read yet.
+func (h *Handler) GetNotifications(c *gin.Context) {
+	unreadOnly := c.Query("unread") == "true"
+
+	notifications, err := h.repo.ListNotifications(h.getWorkspaceID(c), unreadOnly)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load notifications"})
+		return
+	}
+
+	result := make([]NotificationDTO, 0, len(notifications))
+	for i := range notifications {
+		result = append(result, ToNotificationDTO(&notifications[i]))
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// MarkRead marks a notification read for the whole workspace.
+func (h *Handler) MarkRead(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	if err := h.repo.MarkRead(uint(id), h.getWorkspaceID(c)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notification"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func ToNotificationDTO(n *notification.Notification) NotificationDTO {
+	return NotificationDTO{
+		ID:        n.ID,
+		Kind:      n.Kind,
+		Subject:   n.Subject,
+		Month:     n.Month,
+		Amount:    n.Amount,
+		Threshold: n.Threshold,
+		CreatedBy: n.CreatedBy,
+		CreatedAt: n.CreatedAt,
+		Read:      n.IsRead(),
+	}
+}
+
+func (h *Handler) getWorkspaceID(c *gin.Context) uint {
+	workspaceID, exists := c.Get("workspace_id")
+	if !exists {
+		return 0
+	}
+	return workspaceID.(uint)
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+	"wondee/finance-app-backend/internal/notification"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+// MockNotificationRepository implements notification repository.Repository
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) CreateNotification(n *notification.Notification) error {
+	args := m.Called(n)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) ListNotifications(workspaceID uint, unreadOnly bool) ([]notification.Notification, error) {
+	args := m.Called(workspaceID, unreadOnly)
+	return args.Get(0).([]notification.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) GetNotification(id uint, workspaceID uint) (*notification.Notification, error) {
+	args := m.Called(id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*notification.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkRead(id uint, workspaceID uint) error {
+	args := m.Called(id, workspaceID)
+	return args.Error(0)
+}
+
+func setupTestRouter(mockRepo *MockNotificationRepository) *gin.Engine {
+	handler := NewHandler(mockRepo)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+	router.GET("/notifications", handler.GetNotifications)
+	router.POST("/notifications/:id/read", handler.MarkRead)
+	return router
+}
+
+func TestGetNotifications_UnreadOnly(t *testing.T) {
+	mockRepo := new(MockNotificationRepository)
+	router := setupTestRouter(mockRepo)
+
+	mockRepo.On("ListNotifications", uint(1), true).Return([]notification.Notification{
+		{ID: 3, WorkspaceID: 1, Kind: notification.KindLowBalance, Subject: "Holiday",
+			Month: types.YearMonth{Year: 2026, Month: 8}, Amount: 120, Threshold: 500, CreatedBy: 2, CreatedAt: time.Now()},
+	}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/notifications?unread=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var result []NotificationDTO
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Len(t, result, 1)
+	assert.Equal(t, "Holiday", result[0].Subject)
+	assert.Equal(t, 120, result[0].Amount)
+	assert.False(t, result[0].Read)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMarkRead(t *testing.T) {
+	mockRepo := new(MockNotificationRepository)
+	router := setupTestRouter(mockRepo)
+
+	mockRepo.On("MarkRead", uint(3), uint(1)).Return(nil)
+	mockRepo.On("MarkRead", uint(4), uint(1)).Return(gorm.ErrRecordNotFound)
+
+	req, _ := http.NewRequest(http.MethodPost, "/notifications/3/read", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	req, _ = http.NewRequest(http.MethodPost, "/notifications/4/read", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	mockRepo.AssertExpectations(t)
+}
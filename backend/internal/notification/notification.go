@@ -0,0 +1,37 @@
+package notification
+
+import (
+	"time"
+
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+// Kinds of notifications
+const (
+	// KindLowBalance: the change named Subject, i.e. the saved, deleted or
+	// restored cost, the applied scenario or the imported costs, lets the
+	// projected balance fall below Threshold, at least in the pessimistic
+	// case, first in Month. Amount is the lowest pessimistic balance.
+	KindLowBalance = "lowBalance"
+)
+
+// Notification informs the members of a workspace about something that
+// needs their attention. The meaning of Subject, Month and Amount depends
+// on the kind. Amounts are in the base currency.
+type Notification struct {
+	ID          uint            `gorm:"primaryKey"`
+	WorkspaceID uint            `gorm:"not null;index"`
+	Kind        string          `gorm:"size:20;not null"`
+	Subject     string          `gorm:"not null"`
+	Month       types.YearMonth `gorm:"type:string"`
+	Amount      int
+	Threshold   int
+	CreatedBy   uint
+	CreatedAt   time.Time
+	ReadAt      *time.Time
+}
+
+// IsRead reports whether the notification was marked read.
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
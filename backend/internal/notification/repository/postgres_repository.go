@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"time"
+
+	"wondee/finance-app-backend/internal/notification"
+)
+
+func (r *PostgresRepository) CreateNotification(n *notification.Notification) error {
+	return r.DB.Create(n).Error
+}
+
+func (r *PostgresRepository) ListNotifications(workspaceID uint, unreadOnly bool) ([]notification.Notification, error) {
+	var notifications []notification.Notification
+	query := r.DB.Where("workspace_id = ?", workspaceID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if err := query.Order("created_at DESC, id DESC").Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *PostgresRepository) GetNotification(id uint, workspaceID uint) (*notification.Notification, error) {
+	var n notification.Notification
+	result := r.DB.Where("id = ? AND workspace_id = ?", id, workspaceID).First(&n)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &n, nil
+}
+
+func (r *PostgresRepository) MarkRead(id uint, workspaceID uint) error {
+	result := r.DB.Model(&notification.Notification{}).
+		Where("id = ? AND workspace_id = ? AND read_at IS NULL", id, workspaceID).
+		Update("read_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		// Already read notifications are fine, unknown ones are not
+		if _, err := r.GetNotification(id, workspaceID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
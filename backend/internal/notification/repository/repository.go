@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"wondee/finance-app-backend/internal/notification"
+
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for notification data access
+type Repository interface {
+	CreateNotification(n *notification.Notification) error
+	// ListNotifications returns the notifications of a workspace, newest
+	// first, optionally only the unread ones.
+	ListNotifications(workspaceID uint, unreadOnly bool) ([]notification.Notification, error)
+	GetNotification(id uint, workspaceID uint) (*notification.Notification, error)
+	MarkRead(id uint, workspaceID uint) error
+}
+
+// PostgresRepository implements Repository using GORM
+type PostgresRepository struct {
+	DB *gorm.DB
+}
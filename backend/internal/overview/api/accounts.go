@@ -0,0 +1,103 @@
+package api
+
+import (
+	"wondee/finance-app-backend/internal/account"
+	"wondee/finance-app-backend/internal/currency"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+// AccountOverview projects the balance of a single account. Amounts are in
+// the base currency like the total overview.
+type AccountOverview struct {
+	ID            uint           `json:"id"`
+	Name          string         `json:"name"`
+	Type          string         `json:"type"`
+	CurrentAmount int            `json:"currentAmount"`
+	Entries       []AccountEntry `json:"entries"`
+}
+
+// AccountEntry reports the balance of an account at the end of the month.
+// Transfers between accounts are summed up separately.
+type AccountEntry struct {
+	YearMonth       types.YearMonth `json:"yearMonth"`
+	CurrentAmount   int             `json:"currentAmount"`
+	SumFixedCosts   int             `json:"sumFixedCosts"`
+	SumSpecialCosts int             `json:"sumSpecialCosts"`
+	SumTransfers    int             `json:"sumTransfers"`
+}
+
+// accountBook books costs onto the accounts of a workspace. Costs without
+// (or with an unknown) account go to the default account.
+type accountBook struct {
+	accounts []account.Account
+	index    map[uint]int
+	fallback int
+}
+
+func newAccountBook(accounts []account.Account) *accountBook {
+	book := &accountBook{accounts: accounts, index: make(map[uint]int, len(accounts)), fallback: -1}
+	for i, a := range accounts {
+		book.index[a.ID] = i
+	}
+	if d := account.Default(accounts); d != nil {
+		book.fallback = book.index[d.ID]
+	}
+	return book
+}
+
+// indexOf returns the position of the account a cost is booked to, -1 for a
+// workspace without accounts.
+func (b *accountBook) indexOf(accountID *uint) int {
+	if accountID != nil {
+		if i, ok := b.index[*accountID]; ok {
+			return i
+		}
+	}
+	return b.fallback
+}
+
+// transfer books a transfer onto the entries of both accounts.
+func (b *accountBook) transfer(entries []AccountEntry, accountID, targetAccountID *uint, amount int) {
+	if source := b.indexOf(accountID); source >= 0 {
+		entries[source].SumTransfers += amount
+	}
+	if targetAccountID == nil {
+		return
+	}
+	if target, ok := b.index[*targetAccountID]; ok {
+		entries[target].SumTransfers -= amount
+	}
+}
+
+// newOverviews starts the projection of every account with its balance
+// converted at the current month, with room for the given number of entries.
+func (b *accountBook) newOverviews(converter *currency.Converter, yearMonth *types.YearMonth, entries int) []AccountOverview {
+	result := make([]AccountOverview, len(b.accounts))
+	for i, a := range b.accounts {
+		result[i] = AccountOverview{
+			ID:            a.ID,
+			Name:          a.Name,
+			Type:          a.Type,
+			CurrentAmount: converter.Convert(a.Balance, a.Currency, yearMonth),
+			Entries:       make([]AccountEntry, 0, entries),
+		}
+	}
+	return result
+}
+
+// totalAmount sums the starting balances of the accounts.
+func totalAmount(overviews []AccountOverview) int {
+	total := 0
+	for _, o := range overviews {
+		total += o.CurrentAmount
+	}
+	return total
+}
+
+func (h *Handler) loadAccountBook(workspaceID uint) *accountBook {
+	accounts, err := h.Repo.GetAccounts(workspaceID)
+	if err != nil {
+		return newAccountBook(nil)
+	}
+	return newAccountBook(accounts)
+}
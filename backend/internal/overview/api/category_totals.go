@@ -0,0 +1,68 @@
+package api
+
+import (
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/overview/model"
+)
+
+const UNCATEGORIZED = "Ohne Kategorie"
+
+// categoryAmounts collects the amounts booked on each category; costs
+// without (or with an unknown) category are collected under key 0.
+type categoryAmounts map[uint]float64
+
+func (a categoryAmounts) add(tree *cost.CategoryTree, categoryID *uint, amount float64) {
+	if categoryID == nil || !tree.Contains(*categoryID) {
+		a[0] += amount
+		return
+	}
+	a[*categoryID] += amount
+}
+
+// groupByCategory rolls the amounts up the category tree. Categories are
+// listed depth first, categories without any amount are omitted.
+func groupByCategory(tree *cost.CategoryTree, amounts categoryAmounts) []model.CategoryTotal {
+	result := make([]model.CategoryTotal, 0)
+
+	for _, root := range tree.Roots() {
+		appendCategoryTotals(tree, root, amounts, &result)
+	}
+
+	if amount := amounts[0]; amount != 0 {
+		result = append(result, model.CategoryTotal{
+			Name:   UNCATEGORIZED,
+			Amount: amount,
+			Total:  amount,
+		})
+	}
+
+	return result
+}
+
+func appendCategoryTotals(
+	tree *cost.CategoryTree,
+	category cost.Category,
+	amounts categoryAmounts,
+	result *[]model.CategoryTotal,
+) float64 {
+	index := len(*result)
+	*result = append(*result, model.CategoryTotal{
+		CategoryID: &category.ID,
+		ParentID:   category.ParentID,
+		Name:       category.Name,
+		Amount:     amounts[category.ID],
+	})
+
+	total := amounts[category.ID]
+	for _, child := range tree.Children(category.ID) {
+		total += appendCategoryTotals(tree, child, amounts, result)
+	}
+
+	if total == 0 && len(*result) == index+1 {
+		*result = (*result)[:index]
+		return 0
+	}
+
+	(*result)[index].Total = total
+	return total
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/platform/export"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+// ExportOverview downloads the monthly entries of the overview, over the
+// same horizon as GetOverview.
+func (h *Handler) ExportOverview(c *gin.Context) {
+	horizon, err := ParseHorizon(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	overview := h.CreateOverview(h.getWorkspaceID(c), horizon)
+
+	table := &export.Table{
+		Name:    "overview",
+		Columns: []string{"yearMonth", "currentAmount", "sumFixedCosts", "sumSpecialCosts", "currency"},
+	}
+
+	for _, entry := range overview.Entries {
+		table.Append(entry.YearMonth, entry.CurrentAmount, entry.SumFixedCosts, entry.SumSpecialCosts, overview.Currency)
+	}
+
+	export.Respond(c, table)
+}
+
+// ExportSurplusHistory downloads the surplus of the past months.
+func (h *Handler) ExportSurplusHistory(c *gin.Context) {
+	stats := h.CalculateSurplusStatistics(types.CurrentYearMonth(), h.getWorkspaceID(c))
+
+	table := &export.Table{
+		Name:    "surplus",
+		Columns: []string{"month", "surplus", "projected"},
+	}
+
+	for _, point := range stats.History {
+		table.Append(point.Month, point.Surplus, point.Projected)
+	}
+
+	export.Respond(c, table)
+}
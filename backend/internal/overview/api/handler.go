@@ -1,40 +1,63 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"wondee/finance-app-backend/internal/cost"
 	cost_repo "wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/currency"
+	notification_repo "wondee/finance-app-backend/internal/notification/repository"
+	"wondee/finance-app-backend/internal/overview/model"
 	"wondee/finance-app-backend/internal/platform/types"
 	"wondee/finance-app-backend/internal/storage"
 
 	"github.com/gin-gonic/gin"
 )
 
-const MAX_ENTRIES = 30
-
 type Handler struct {
 	Repo     storage.Repository
 	CostRepo cost_repo.Repository
+
+	// Notifications receives the low balance alerts; without it no alerts
+	// are sent.
+	Notifications notification_repo.Repository
 }
 
 type Overview struct {
 	CurrentAmount int             `json:"currentAmount"`
+	Currency      string          `json:"currency"`
 	Entries       []OverviewEntry `json:"entries"`
+
+	// MissingRates lists the currencies without an exchange rate; their
+	// amounts are included unconverted.
+	MissingRates []string `json:"missingRates,omitempty"`
+
+	// Accounts is empty as long as the workspace has no accounts.
+	Accounts []AccountOverview `json:"accounts"`
 }
 
+// OverviewEntry reports the expected balance at the end of the month.
+// PessimisticAmount and OptimisticAmount bound it when every estimated
+// special cost so far turns out at the worst or the best end of its range.
 type OverviewEntry struct {
-	YearMonth       types.YearMonth `json:"yearMonth"`
-	CurrentAmount   int              `json:"currentAmount"`
-	SumFixedCosts   int              `json:"sumFixedCosts"`
-	SumSpecialCosts int              `json:"sumSpecialCosts"`
+	YearMonth         types.YearMonth `json:"yearMonth"`
+	CurrentAmount     int             `json:"currentAmount"`
+	PessimisticAmount int             `json:"pessimisticAmount"`
+	OptimisticAmount  int             `json:"optimisticAmount"`
+	SumFixedCosts     int             `json:"sumFixedCosts"`
+	SumSpecialCosts   int             `json:"sumSpecialCosts"`
 }
 
+// CostDetail amounts are in the base currency; costs kept in another
+// currency additionally report their original amount.
 type CostDetail struct {
-	ID     int    `json:"id"`
-	Name   string `json:"name"`
-	Amount int    `json:"amount"`
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	Amount         int    `json:"amount"`
+	OriginalAmount int    `json:"originalAmount,omitempty"`
+	Currency       string `json:"currency,omitempty"`
 }
 
 type FixedCostDetail struct {
@@ -42,52 +65,84 @@ type FixedCostDetail struct {
 	DisplayType string `json:"displayType"`
 }
 
+// OverviewDetail lists the costs of a month. Transfers between accounts are
+// listed separately and left out of the category totals.
 type OverviewDetail struct {
-	FixedCosts   []FixedCostDetail `json:"fixedCosts"`
-	SpecialCosts []CostDetail      `json:"specialCosts"`
+	FixedCosts   []FixedCostDetail     `json:"fixedCosts"`
+	SpecialCosts []CostDetail          `json:"specialCosts"`
+	Transfers    []CostDetail          `json:"transfers"`
+	Categories   []model.CategoryTotal `json:"categories"`
+	MissingRates []string              `json:"missingRates,omitempty"`
 }
 
+// GetOverview projects the balance over the horizon given by "from", "to"
+// and "months", see ParseHorizon.
 func (h *Handler) GetOverview(c *gin.Context) {
+	horizon, err := ParseHorizon(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	workspaceID := h.getWorkspaceID(c)
-	c.IndentedJSON(http.StatusOK, h.createOverview(workspaceID))
+	c.IndentedJSON(http.StatusOK, h.CreateOverview(workspaceID, horizon))
 }
 
+// GetOverviewDetail lists the costs of a month, given as ?month=YYYY-MM or
+// relative to the current month as ?index=n.
 func (h *Handler) GetOverviewDetail(c *gin.Context) {
-	n, err := strconv.Atoi(c.Query("index"))
-
-	if err != nil {
-		c.AbortWithStatus(http.StatusBadRequest)
-		return
+	var yearMonth *types.YearMonth
+	if value := c.Query("month"); value != "" {
+		parsed, err := parseYearMonth(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "month must be formatted as YYYY-MM"})
+			return
+		}
+		yearMonth = parsed
+	} else {
+		n, err := strconv.Atoi(c.Query("index"))
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		if n >= MAX_MONTHS {
+			c.IndentedJSON(http.StatusOK, OverviewDetail{})
+			return
+		}
+		yearMonth = types.AddMonths(types.CurrentYearMonth(), n)
 	}
 
 	workspaceID := h.getWorkspaceID(c)
 	c.IndentedJSON(
 		http.StatusOK,
-		h.createOverviewDetail(n, workspaceID),
+		h.createOverviewDetail(yearMonth, workspaceID),
 	)
 }
 
-func (h *Handler) createOverviewDetail(n int, workspaceID uint) OverviewDetail {
-	if n > MAX_ENTRIES {
-		return OverviewDetail{}
-	}
-
-	relevantFixedCostsMap := h.createRelevantMap(workspaceID)
+func (h *Handler) createOverviewDetail(yearMonth *types.YearMonth, workspaceID uint) OverviewDetail {
+	fixedCostList := h.CostRepo.LoadFixedCosts(workspaceID)
 	specialCostMap := h.createSpecialCostMap(workspaceID)
 
-	yearMonth := types.AddMonths(types.CurrentYearMonth(), n)
-
 	fixedCosts := make([]FixedCostDetail, 0)
 	specialCosts := make([]CostDetail, 0)
-
-	for _, cost := range relevantFixedCostsMap[yearMonth.Month] {
-		if types.IsRelevant(yearMonth, cost.From, cost.To) {
+	transfers := make([]CostDetail, 0)
+
+	categoryTree := cost.LoadCategoryTree(h.CostRepo, workspaceID)
+	converter, _ := currency.LoadConverter(h.Repo, workspaceID)
+	amounts := make(categoryAmounts)
+
+	for _, cost := range *fixedCostList {
+		if amount := cost.DueAmount(yearMonth); amount != 0 {
+			converted := converter.Convert(amount, cost.Currency, yearMonth)
+			if cost.IsTransfer() {
+				transfers = append(transfers, toCostDetail(converter, cost.ID, cost.Name, amount, converted, cost.Currency))
+				continue
+			}
+			amounts.add(categoryTree, cost.CategoryID, float64(converted))
 
 			costDetail := FixedCostDetail{}
-			costDetail.ID = cost.ID
-			costDetail.Amount = cost.Amount
-			costDetail.Name = cost.Name
-			costDetail.DisplayType = determineDisplayType(cost.DueMonth)
+			costDetail.CostDetail = toCostDetail(converter, cost.ID, cost.Name, amount, converted, cost.Currency)
+			costDetail.DisplayType = determineDisplayType(cost.Schedule())
 
 			fixedCosts = append(fixedCosts, costDetail)
 		}
@@ -95,109 +150,193 @@ func (h *Handler) createOverviewDetail(n int, workspaceID uint) OverviewDetail {
 
 	if costs := specialCostMap[*yearMonth]; costs != nil {
 		for _, cost := range costs {
-			specialCosts = append(specialCosts, CostDetail{
-				ID:     cost.ID,
-				Name:   cost.Name,
-				Amount: cost.Amount,
-			})
+			amount := cost.ExpectedAmount()
+			converted := converter.Convert(amount, cost.Currency, yearMonth)
+			if cost.IsTransfer() {
+				transfers = append(transfers, toCostDetail(converter, cost.ID, cost.Name, amount, converted, cost.Currency))
+				continue
+			}
+			amounts.add(categoryTree, cost.CategoryID, float64(converted))
+			specialCosts = append(specialCosts, toCostDetail(converter, cost.ID, cost.Name, amount, converted, cost.Currency))
 		}
 	}
 
 	return OverviewDetail{
 		FixedCosts:   fixedCosts,
 		SpecialCosts: specialCosts,
+		Transfers:    transfers,
+		Categories:   groupByCategory(categoryTree, amounts),
+		MissingRates: converter.Missing(),
 	}
 }
 
-func determineDisplayType(dueMonth []int) string {
-	switch len(dueMonth) {
-	case 1:
+func toCostDetail(converter *currency.Converter, id int, name string, amount, converted int, code string) CostDetail {
+	detail := CostDetail{ID: id, Name: name, Amount: converted}
+	if code != "" && code != converter.Base() {
+		detail.OriginalAmount = amount
+		detail.Currency = code
+	}
+	return detail
+}
+
+func determineDisplayType(rule cost.Recurrence) string {
+	switch {
+	case rule.Frequency == cost.FrequencyYearly && rule.Interval == 1:
 		return "jährlich"
-	case 2:
-		return "halbjährlich"
-	case 4:
+	case rule.Frequency == cost.FrequencyYearly:
+		return fmt.Sprintf("alle %d Jahre", rule.Interval)
+	case rule.Frequency == cost.FrequencyWeekly && rule.Interval == 1:
+		return "wöchentlich"
+	case rule.Frequency == cost.FrequencyWeekly:
+		return fmt.Sprintf("alle %d Wochen", rule.Interval)
+	}
+
+	switch rule.Interval {
+	case 1:
+		return "monatlich"
+	case 3:
 		return "vierteljährlich"
+	case 6:
+		return "halbjährlich"
 	case 12:
-		return "monatlich"
+		return "jährlich"
 	default:
-		return "ILLEGAL"
+		return fmt.Sprintf("alle %d Monate", rule.Interval)
 	}
 }
 
-func (h *Handler) createOverview(workspaceID uint) Overview {
+// CreateOverview projects the balance month by month, in total and per
+// account. Once a workspace has accounts, their balances replace the single
+// current amount of the workspace. Transfers only show up per account.
+// The projection starts with the current month, but only the months of the
+// horizon are returned.
+func (h *Handler) CreateOverview(workspaceID uint, horizon Horizon) Overview {
 	currentAmount := 0
 	if workspace, err := h.Repo.GetWorkspaceByID(workspaceID); err == nil {
 		currentAmount = workspace.CurrentAmount
 	}
 
-	entries := make([]OverviewEntry, MAX_ENTRIES)
+	entries := make([]OverviewEntry, 0, horizon.Months())
+
+	tmpYearMonth := types.CurrentYearMonth()
+	months := types.MonthsBetween(tmpYearMonth, &horizon.To) + 1
 
-	relevantFixedCostsMap := h.createRelevantMap(workspaceID)
+	// Costs that end before or start after the projection never come up
+	fixedCosts := relevantFixedCosts(*h.CostRepo.LoadFixedCosts(workspaceID), tmpYearMonth, &horizon.To)
 	specialCostMap := h.createSpecialCostMap(workspaceID)
+	converter, _ := currency.LoadConverter(h.Repo, workspaceID)
+	book := h.loadAccountBook(workspaceID)
+
+	accounts := book.newOverviews(converter, tmpYearMonth, horizon.Months())
+	if len(accounts) > 0 {
+		currentAmount = totalAmount(accounts)
+	}
 
 	tmpAmount := currentAmount
-	tmpYearMonth := types.CurrentYearMonth()
+	pessimisticAmount, optimisticAmount := currentAmount, currentAmount
+	accountAmounts := make([]int, len(accounts))
+	for i := range accounts {
+		accountAmounts[i] = accounts[i].CurrentAmount
+	}
 
-	for i := range entries {
+	for i := 0; i < months; i++ {
 		sumFixedCosts := 0
+		accountEntries := make([]AccountEntry, len(accounts))
 
-		for _, fixcost := range relevantFixedCostsMap[tmpYearMonth.Month] {
-			if types.IsRelevant(tmpYearMonth, fixcost.From, fixcost.To) {
-				sumFixedCosts += fixcost.Amount
+		for _, fixcost := range fixedCosts {
+			due := fixcost.DueAmount(tmpYearMonth)
+			if due == 0 {
+				continue
+			}
+			amount := converter.Convert(due, fixcost.Currency, tmpYearMonth)
+			if fixcost.IsTransfer() {
+				book.transfer(accountEntries, fixcost.AccountID, fixcost.TargetAccountID, amount)
+				continue
+			}
+			sumFixedCosts += amount
+			if a := book.indexOf(fixcost.AccountID); a >= 0 {
+				accountEntries[a].SumFixedCosts += amount
 			}
 		}
 
-		sumSpecialCosts := 0
+		sumSpecialCosts, lowSpecialCosts, highSpecialCosts := 0, 0, 0
 
 		for _, specialcost := range specialCostMap[*tmpYearMonth] {
-			sumSpecialCosts += specialcost.Amount
+			amount := converter.Convert(specialcost.ExpectedAmount(), specialcost.Currency, tmpYearMonth)
+			if specialcost.IsTransfer() {
+				book.transfer(accountEntries, specialcost.AccountID, specialcost.TargetAccountID, amount)
+				continue
+			}
+			low, high := specialcost.Bounds()
+			sumSpecialCosts += amount
+			lowSpecialCosts += converter.Convert(low, specialcost.Currency, tmpYearMonth)
+			highSpecialCosts += converter.Convert(high, specialcost.Currency, tmpYearMonth)
+			if a := book.indexOf(specialcost.AccountID); a >= 0 {
+				accountEntries[a].SumSpecialCosts += amount
+			}
 		}
 
 		newTmpAmount := tmpAmount + sumFixedCosts + sumSpecialCosts
-		entries[i] = OverviewEntry{
-			YearMonth:       *tmpYearMonth,
-			CurrentAmount:   newTmpAmount,
-			SumFixedCosts:   sumFixedCosts,
-			SumSpecialCosts: sumSpecialCosts,
+		pessimisticAmount += sumFixedCosts + lowSpecialCosts
+		optimisticAmount += sumFixedCosts + highSpecialCosts
+		visible := horizon.Contains(tmpYearMonth)
+		if visible {
+			entries = append(entries, OverviewEntry{
+				YearMonth:         *tmpYearMonth,
+				CurrentAmount:     newTmpAmount,
+				PessimisticAmount: pessimisticAmount,
+				OptimisticAmount:  optimisticAmount,
+				SumFixedCosts:     sumFixedCosts,
+				SumSpecialCosts:   sumSpecialCosts,
+			})
 		}
+
+		for a, entry := range accountEntries {
+			accountAmounts[a] += entry.SumFixedCosts + entry.SumSpecialCosts + entry.SumTransfers
+			if visible {
+				entry.YearMonth = *tmpYearMonth
+				entry.CurrentAmount = accountAmounts[a]
+				accounts[a].Entries = append(accounts[a].Entries, entry)
+			}
+		}
+
 		tmpYearMonth = types.NextYearMonth(tmpYearMonth)
 		tmpAmount = newTmpAmount
 	}
 
 	return Overview{
 		CurrentAmount: currentAmount,
+		Currency:      converter.Base(),
 		Entries:       entries,
+		Accounts:      accounts,
+		MissingRates:  converter.Missing(),
 	}
 
 }
 
-func (h *Handler) createRelevantMap(workspaceID uint) map[int][]cost.FixedCost {
-	result := make(map[int][]cost.FixedCost)
-	for _, c := range *h.CostRepo.LoadFixedCosts(workspaceID) {
-		for _, dueMonth := range c.DueMonth {
-			if result[dueMonth] == nil {
-				result[dueMonth] = []cost.FixedCost{c}
-			} else {
-				result[dueMonth] =
-					append(result[dueMonth], c)
-			}
+// relevantFixedCosts returns the costs valid in at least one month between
+// from and to.
+func relevantFixedCosts(costs []cost.FixedCost, from, to *types.YearMonth) []cost.FixedCost {
+	result := make([]cost.FixedCost, 0, len(costs))
+	for _, fc := range costs {
+		if fc.To != nil && types.MonthsBetween(from, fc.To) < 0 {
+			continue
+		}
+		if fc.From != nil && types.MonthsBetween(fc.From, to) < 0 {
+			continue
 		}
+		result = append(result, fc)
 	}
-
 	return result
 }
 
+// createSpecialCostMap groups the special costs by the month they are due,
+// with installment plans expanded into their payments.
 func (h *Handler) createSpecialCostMap(workspaceID uint) map[types.YearMonth][]cost.SpecialCost {
 	result := make(map[types.YearMonth][]cost.SpecialCost)
 	for _, sc := range *h.CostRepo.LoadSpecialCosts(workspaceID) {
-		if sc.DueDate == nil {
-			continue
-		}
-		if result[*sc.DueDate] == nil {
-			result[*sc.DueDate] = []cost.SpecialCost{sc}
-		} else {
-			result[*sc.DueDate] =
-				append(result[*sc.DueDate], sc)
+		for _, part := range sc.Expand() {
+			result[*part.DueDate] = append(result[*part.DueDate], part)
 		}
 	}
 
@@ -210,4 +349,4 @@ func (h *Handler) getWorkspaceID(c *gin.Context) uint {
 		return 0
 	}
 	return workspaceID.(uint)
-}
\ No newline at end of file
+}
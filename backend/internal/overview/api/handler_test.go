@@ -1,11 +1,19 @@
 package api
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"wondee/finance-app-backend/internal/account"
 	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/currency"
 	"wondee/finance-app-backend/internal/platform/types"
 	"wondee/finance-app-backend/internal/storage"
 	"wondee/finance-app-backend/internal/workspace"
+
+	"github.com/gin-gonic/gin"
 )
 
 func TestCreateOverview(t *testing.T) {
@@ -24,7 +32,7 @@ func TestCreateOverview(t *testing.T) {
 	}
 	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
 
-	overview := handler.createOverview(workspaceID)
+	overview := handler.CreateOverview(workspaceID, defaultHorizon())
 
 	if overview.CurrentAmount != 1234 {
 		t.Errorf("Expected CurrentAmount 1234, got %d", overview.CurrentAmount)
@@ -59,7 +67,7 @@ func TestCreateOverviewDetail(t *testing.T) {
 	}
 	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
 
-	detail := handler.createOverviewDetail(0, workspaceID)
+	detail := handler.createOverviewDetail(types.CurrentYearMonth(), workspaceID)
 
 	foundSpecial := false
 	for _, c := range detail.SpecialCosts {
@@ -82,4 +90,430 @@ func TestCreateOverviewDetail(t *testing.T) {
 	if !foundFixed {
 		t.Error("Fixed cost ID not found in detail")
 	}
-}
\ No newline at end of file
+}
+func TestCreateOverviewWithRecurrence(t *testing.T) {
+	var workspaceID uint = 1
+	current := types.CurrentYearMonth()
+
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: workspaceID}},
+		FixedCosts: []cost.FixedCost{
+			{ID: 1, WorkspaceID: workspaceID, Name: "Kindergarten", Amount: -100, Recurrence: cost.Recurrence{
+				Frequency: cost.FrequencyMonthly, Interval: 2, Anchor: current,
+			}},
+		},
+	}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+
+	overview := handler.CreateOverview(workspaceID, defaultHorizon())
+
+	if overview.Entries[0].SumFixedCosts != -100 || overview.Entries[1].SumFixedCosts != 0 {
+		t.Errorf("Expected bi-monthly cost in every other month, got %d and %d",
+			overview.Entries[0].SumFixedCosts, overview.Entries[1].SumFixedCosts)
+	}
+	if overview.Entries[3].CurrentAmount != -200 {
+		t.Errorf("Expected -200 after four months, got %d", overview.Entries[3].CurrentAmount)
+	}
+
+	detail := handler.createOverviewDetail(types.CurrentYearMonth(), workspaceID)
+	if len(detail.FixedCosts) != 1 || detail.FixedCosts[0].DisplayType != "alle 2 Monate" {
+		t.Errorf("Unexpected detail %+v", detail.FixedCosts)
+	}
+}
+
+func TestCreateOverviewDetailGroupsByCategory(t *testing.T) {
+	var workspaceID uint = 1
+	housing := uint(1)
+	rent := uint(2)
+	insurance := uint(3)
+
+	mockRepo := &storage.MockRepository{
+		Categories: []cost.Category{
+			{ID: housing, WorkspaceID: workspaceID, Name: "Wohnen"},
+			{ID: rent, WorkspaceID: workspaceID, Name: "Miete", ParentID: &housing},
+			{ID: insurance, WorkspaceID: workspaceID, Name: "Versicherungen"},
+		},
+		FixedCosts: []cost.FixedCost{
+			{ID: 1, WorkspaceID: workspaceID, Name: "Rent", Amount: -900, CategoryID: &rent},
+			{ID: 2, WorkspaceID: workspaceID, Name: "Electricity", Amount: -80, CategoryID: &housing},
+			{ID: 3, WorkspaceID: workspaceID, Name: "Salary", Amount: 3000},
+		},
+		SpecialCosts: []cost.SpecialCost{
+			{ID: 4, WorkspaceID: workspaceID, Name: "Renovation", Amount: -500, CategoryID: &rent, DueDate: types.CurrentYearMonth()},
+		},
+	}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+
+	detail := handler.createOverviewDetail(types.CurrentYearMonth(), workspaceID)
+
+	if len(detail.Categories) != 3 {
+		t.Fatalf("Expected Wohnen, Miete and uncategorized, got %+v", detail.Categories)
+	}
+	if detail.Categories[0].Name != "Wohnen" || detail.Categories[0].Amount != -80 || detail.Categories[0].Total != -1480 {
+		t.Errorf("Unexpected total for Wohnen: %+v", detail.Categories[0])
+	}
+	if detail.Categories[1].Name != "Miete" || detail.Categories[1].Total != -1400 {
+		t.Errorf("Unexpected total for Miete: %+v", detail.Categories[1])
+	}
+	if detail.Categories[2].CategoryID != nil || detail.Categories[2].Total != 3000 {
+		t.Errorf("Unexpected uncategorized total: %+v", detail.Categories[2])
+	}
+}
+
+func TestCreateOverviewUsesAmountRevisions(t *testing.T) {
+	var workspaceID uint = 1
+	current := types.CurrentYearMonth()
+
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: workspaceID}},
+		FixedCosts: []cost.FixedCost{
+			{ID: 1, WorkspaceID: workspaceID, Name: "Rent", Amount: -900, DueMonth: cost.ALL_MONTHS, Revisions: []cost.AmountRevision{
+				{Amount: -1000, ValidFrom: *types.AddMonths(current, 2)},
+			}},
+		},
+	}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+
+	overview := handler.CreateOverview(workspaceID, defaultHorizon())
+
+	if overview.Entries[1].SumFixedCosts != -900 || overview.Entries[2].SumFixedCosts != -1000 {
+		t.Errorf("Expected rent increase in third month, got %d and %d",
+			overview.Entries[1].SumFixedCosts, overview.Entries[2].SumFixedCosts)
+	}
+}
+
+func TestCreateOverviewConvertsForeignCurrencies(t *testing.T) {
+	var workspaceID uint = 1
+	current := types.CurrentYearMonth()
+
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: workspaceID, BaseCurrency: "EUR"}},
+		FixedCosts: []cost.FixedCost{
+			{ID: 1, WorkspaceID: workspaceID, Name: "Rent", Amount: -900, DueMonth: cost.ALL_MONTHS},
+			{ID: 2, WorkspaceID: workspaceID, Name: "Insurance", Amount: -200, Currency: "CHF", DueMonth: cost.ALL_MONTHS},
+		},
+		SpecialCosts: []cost.SpecialCost{
+			{ID: 3, WorkspaceID: workspaceID, Name: "Dividend", Amount: 100, Currency: "USD", DueDate: current},
+		},
+		ExchangeRates: []currency.ExchangeRate{
+			{WorkspaceID: workspaceID, Currency: "CHF", Rate: 1.05, ValidFrom: *current},
+			{WorkspaceID: workspaceID, Currency: "USD", Rate: 0.9, ValidFrom: *current},
+		},
+	}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+
+	overview := handler.CreateOverview(workspaceID, defaultHorizon())
+
+	if overview.Currency != "EUR" {
+		t.Errorf("Expected currency EUR, got %s", overview.Currency)
+	}
+	if overview.Entries[0].SumFixedCosts != -1110 || overview.Entries[0].SumSpecialCosts != 90 {
+		t.Errorf("Expected converted sums -1110 and 90, got %d and %d",
+			overview.Entries[0].SumFixedCosts, overview.Entries[0].SumSpecialCosts)
+	}
+
+	detail := handler.createOverviewDetail(types.CurrentYearMonth(), workspaceID)
+
+	for _, fixedCost := range detail.FixedCosts {
+		switch fixedCost.ID {
+		case 1:
+			if fixedCost.Currency != "" || fixedCost.OriginalAmount != 0 {
+				t.Errorf("Expected no original amount for base currency costs: %+v", fixedCost)
+			}
+		case 2:
+			if fixedCost.Amount != -210 || fixedCost.OriginalAmount != -200 || fixedCost.Currency != "CHF" {
+				t.Errorf("Unexpected insurance detail: %+v", fixedCost)
+			}
+		}
+	}
+	if overview.MissingRates != nil || detail.MissingRates != nil {
+		t.Errorf("Expected no missing rates, got %v and %v", overview.MissingRates, detail.MissingRates)
+	}
+}
+
+func TestCreateOverviewReportsMissingRates(t *testing.T) {
+	var workspaceID uint = 1
+
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: workspaceID, BaseCurrency: "EUR"}},
+		FixedCosts: []cost.FixedCost{
+			{ID: 1, WorkspaceID: workspaceID, Name: "Insurance", Amount: -200, Currency: "CHF", DueMonth: cost.ALL_MONTHS},
+		},
+	}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+
+	overview := handler.CreateOverview(workspaceID, defaultHorizon())
+
+	if len(overview.MissingRates) != 1 || overview.MissingRates[0] != "CHF" {
+		t.Errorf("Expected CHF to be reported without rate, got %v", overview.MissingRates)
+	}
+	if overview.Entries[0].SumFixedCosts != -200 {
+		t.Errorf("Expected the amount to be taken over unchanged, got %d", overview.Entries[0].SumFixedCosts)
+	}
+}
+
+func TestCreateOverviewExpandsInstallmentPlans(t *testing.T) {
+	var workspaceID uint = 1
+	current := types.CurrentYearMonth()
+
+	mockRepo := &storage.MockRepository{
+		SpecialCosts: []cost.SpecialCost{
+			{ID: 7, WorkspaceID: workspaceID, Name: "Sofa", Amount: -900, DueDate: types.AddMonths(current, 1), Installments: 3},
+		},
+	}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+
+	overview := handler.CreateOverview(workspaceID, defaultHorizon())
+
+	expected := []int{0, -300, -300, -300, 0}
+	for i, amount := range expected {
+		if overview.Entries[i].SumSpecialCosts != amount {
+			t.Errorf("Month %d: expected %d, got %d", i, amount, overview.Entries[i].SumSpecialCosts)
+		}
+	}
+
+	detail := handler.createOverviewDetail(types.AddMonths(types.CurrentYearMonth(), 2), workspaceID)
+	if len(detail.SpecialCosts) != 1 || detail.SpecialCosts[0].ID != 7 || detail.SpecialCosts[0].Name != "Sofa (2/3)" {
+		t.Errorf("Expected second installment in detail, got %+v", detail.SpecialCosts)
+	}
+}
+
+func TestCreateOverviewReturnsBalanceBands(t *testing.T) {
+	var workspaceID uint = 1
+	current := types.CurrentYearMonth()
+	minAmount, maxAmount, probability := -200, -1000, 50.0
+
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: workspaceID, CurrentAmount: 2000}},
+		FixedCosts: []cost.FixedCost{
+			{WorkspaceID: workspaceID, Name: "Rent", Amount: -100, DueMonth: cost.ALL_MONTHS},
+		},
+		SpecialCosts: []cost.SpecialCost{
+			{WorkspaceID: workspaceID, Name: "Car repair", Amount: -600, DueDate: types.AddMonths(current, 1),
+				MinAmount: &minAmount, MaxAmount: &maxAmount, Probability: &probability},
+			{WorkspaceID: workspaceID, Name: "Tax refund", Amount: 300, DueDate: types.AddMonths(current, 2)},
+		},
+	}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+
+	overview := handler.CreateOverview(workspaceID, defaultHorizon())
+
+	expected := []struct{ current, pessimistic, optimistic int }{
+		{1900, 1900, 1900},
+		{1500, 800, 1800},
+		{1700, 1000, 2000},
+	}
+	for i, e := range expected {
+		entry := overview.Entries[i]
+		if entry.CurrentAmount != e.current || entry.PessimisticAmount != e.pessimistic || entry.OptimisticAmount != e.optimistic {
+			t.Errorf("Month %d: expected %d (%d..%d), got %d (%d..%d)", i, e.current, e.pessimistic, e.optimistic,
+				entry.CurrentAmount, entry.PessimisticAmount, entry.OptimisticAmount)
+		}
+	}
+}
+
+func TestCreateOverviewProjectsAccounts(t *testing.T) {
+	var workspaceID uint = 1
+	current := types.CurrentYearMonth()
+	savingsID, unknownID := uint(2), uint(9)
+
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: workspaceID, CurrentAmount: 99999, BaseCurrency: "EUR"}},
+		Accounts: []account.Account{
+			{ID: 1, WorkspaceID: workspaceID, Name: "Girokonto", Type: account.TypeChecking, Balance: 1000},
+			{ID: savingsID, WorkspaceID: workspaceID, Name: "Tagesgeld", Type: account.TypeSavings, Balance: 500, Currency: "CHF"},
+		},
+		FixedCosts: []cost.FixedCost{
+			{WorkspaceID: workspaceID, Name: "Rent", Amount: -300, DueMonth: cost.ALL_MONTHS},
+			{WorkspaceID: workspaceID, Name: "Interest", Amount: 10, DueMonth: cost.ALL_MONTHS, AccountID: &savingsID},
+		},
+		SpecialCosts: []cost.SpecialCost{
+			{WorkspaceID: workspaceID, Name: "Holiday", Amount: -200, DueDate: types.AddMonths(current, 1), AccountID: &unknownID},
+		},
+		ExchangeRates: []currency.ExchangeRate{
+			{WorkspaceID: workspaceID, Currency: "CHF", Rate: 1.1, ValidFrom: *current},
+		},
+	}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+
+	overview := handler.CreateOverview(workspaceID, defaultHorizon())
+
+	if overview.CurrentAmount != 1550 {
+		t.Fatalf("Expected account balances to replace the current amount, got %d", overview.CurrentAmount)
+	}
+	if len(overview.Accounts) != 2 {
+		t.Fatalf("Expected two accounts, got %+v", overview.Accounts)
+	}
+
+	checking, savings := overview.Accounts[0], overview.Accounts[1]
+	if checking.Entries[0].CurrentAmount != 700 || checking.Entries[1].CurrentAmount != 200 || checking.Entries[1].SumSpecialCosts != -200 {
+		t.Errorf("Unexpected checking account projection %+v", checking.Entries[:2])
+	}
+	if savings.CurrentAmount != 550 || savings.Entries[1].CurrentAmount != 570 {
+		t.Errorf("Unexpected savings account projection %+v", savings)
+	}
+	if overview.Entries[1].CurrentAmount != checking.Entries[1].CurrentAmount+savings.Entries[1].CurrentAmount {
+		t.Errorf("Expected total to be the sum of the accounts, got %d", overview.Entries[1].CurrentAmount)
+	}
+}
+
+func TestCreateOverviewKeepsTransfersNeutral(t *testing.T) {
+	var workspaceID uint = 1
+	current := types.CurrentYearMonth()
+	savingsID := uint(2)
+
+	mockRepo := &storage.MockRepository{
+		Accounts: []account.Account{
+			{ID: 1, WorkspaceID: workspaceID, Name: "Girokonto", Type: account.TypeChecking, Balance: 2000},
+			{ID: savingsID, WorkspaceID: workspaceID, Name: "Tagesgeld", Type: account.TypeSavings},
+		},
+		FixedCosts: []cost.FixedCost{
+			{ID: 1, WorkspaceID: workspaceID, Name: "Rent", Amount: -800, DueMonth: cost.ALL_MONTHS},
+			{ID: 2, WorkspaceID: workspaceID, Name: "Sparplan", Amount: -300, DueMonth: cost.ALL_MONTHS, TargetAccountID: &savingsID},
+		},
+		SpecialCosts: []cost.SpecialCost{
+			{ID: 3, WorkspaceID: workspaceID, Name: "Steuerrücklage", Amount: -500, DueDate: current, TargetAccountID: &savingsID},
+		},
+	}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+
+	overview := handler.CreateOverview(workspaceID, defaultHorizon())
+
+	entry := overview.Entries[0]
+	if entry.SumFixedCosts != -800 || entry.SumSpecialCosts != 0 || entry.CurrentAmount != 1200 {
+		t.Errorf("Expected transfers to be neutral in total, got %+v", entry)
+	}
+
+	checking, savings := overview.Accounts[0].Entries[0], overview.Accounts[1].Entries[0]
+	if checking.SumTransfers != -800 || checking.CurrentAmount != 400 {
+		t.Errorf("Unexpected checking account entry %+v", checking)
+	}
+	if savings.SumTransfers != 800 || savings.CurrentAmount != 800 {
+		t.Errorf("Unexpected savings account entry %+v", savings)
+	}
+
+	detail := handler.createOverviewDetail(types.CurrentYearMonth(), workspaceID)
+	if len(detail.FixedCosts) != 1 || len(detail.SpecialCosts) != 0 || len(detail.Transfers) != 2 {
+		t.Errorf("Expected transfers to be listed separately, got %+v", detail)
+	}
+	if len(detail.Categories) != 1 || detail.Categories[0].Total != -800 {
+		t.Errorf("Expected transfers to be left out of the categories, got %+v", detail.Categories)
+	}
+}
+
+func TestCreateOverviewForHorizon(t *testing.T) {
+	var workspaceID uint = 1
+	current := types.CurrentYearMonth()
+	ended := types.AddMonths(current, -1)
+
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: workspaceID, CurrentAmount: 1000}},
+		FixedCosts: []cost.FixedCost{
+			{WorkspaceID: workspaceID, Name: "Salary", Amount: 100, DueMonth: cost.ALL_MONTHS},
+			{WorkspaceID: workspaceID, Name: "Old contract", Amount: -50, DueMonth: cost.ALL_MONTHS, To: ended},
+		},
+	}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+
+	horizon := Horizon{From: *types.AddMonths(current, 12), To: *types.AddMonths(current, MAX_MONTHS-1)}
+	overview := handler.CreateOverview(workspaceID, horizon)
+
+	if len(overview.Entries) != MAX_MONTHS-12 {
+		t.Fatalf("Expected %d entries, got %d", MAX_MONTHS-12, len(overview.Entries))
+	}
+	first, last := overview.Entries[0], overview.Entries[len(overview.Entries)-1]
+	if first.YearMonth != horizon.From || last.YearMonth != horizon.To {
+		t.Errorf("Expected entries from %v to %v, got %v to %v", horizon.From, horizon.To, first.YearMonth, last.YearMonth)
+	}
+	// The months before the horizon still count towards the balance
+	if first.CurrentAmount != 1000+13*100 {
+		t.Errorf("Expected %d, got %d", 1000+13*100, first.CurrentAmount)
+	}
+	if last.CurrentAmount != 1000+MAX_MONTHS*100 {
+		t.Errorf("Expected %d, got %d", 1000+MAX_MONTHS*100, last.CurrentAmount)
+	}
+}
+
+func TestParseHorizon(t *testing.T) {
+	current := types.CurrentYearMonth()
+	format := func(ym *types.YearMonth) string { return fmt.Sprintf("%04d-%02d", ym.Year, ym.Month) }
+
+	tests := []struct {
+		name     string
+		query    string
+		expected *Horizon
+	}{
+		{"default", "", &Horizon{From: *current, To: *types.AddMonths(current, DEFAULT_MONTHS-1)}},
+		{"months", "months=60", &Horizon{From: *current, To: *types.AddMonths(current, 59)}},
+		{"from and months", "from=" + format(types.AddMonths(current, 6)) + "&months=12", &Horizon{From: *types.AddMonths(current, 6), To: *types.AddMonths(current, 17)}},
+		{"from and to", "from=" + format(types.AddMonths(current, 1)) + "&to=" + format(types.AddMonths(current, 48)), &Horizon{From: *types.AddMonths(current, 1), To: *types.AddMonths(current, 48)}},
+		{"from in the past", "from=" + format(types.AddMonths(current, -1)), nil},
+		{"to before from", "from=" + format(types.AddMonths(current, 3)) + "&to=" + format(types.AddMonths(current, 2)), nil},
+		{"too far ahead", "months=" + fmt.Sprint(MAX_MONTHS+1), nil},
+		{"to and months", "to=" + format(current) + "&months=3", nil},
+		{"invalid month", "from=2025-13", nil},
+		{"invalid months", "months=0", nil},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/overview/all?"+tt.query, nil)
+
+			horizon, err := ParseHorizon(c)
+			if tt.expected == nil {
+				if err == nil {
+					t.Errorf("Expected error, got %+v", horizon)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if horizon != *tt.expected {
+				t.Errorf("Expected %+v, got %+v", *tt.expected, horizon)
+			}
+		})
+	}
+}
+
+func TestGetOverviewDetailByMonth(t *testing.T) {
+	var workspaceID uint = 1
+	dueDate := &types.YearMonth{Year: 2031, Month: 4}
+
+	mockRepo := &storage.MockRepository{
+		SpecialCosts: []cost.SpecialCost{
+			{ID: 20, WorkspaceID: workspaceID, Name: "New roof", Amount: -15000, DueDate: dueDate},
+		},
+	}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("workspace_id", workspaceID)
+		c.Next()
+	})
+	router.GET("/overview/detail", handler.GetOverviewDetail)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/overview/detail?month=2031-04", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var detail OverviewDetail
+	if err := json.Unmarshal(w.Body.Bytes(), &detail); err != nil {
+		t.Fatal(err)
+	}
+	if len(detail.SpecialCosts) != 1 || detail.SpecialCosts[0].ID != 20 {
+		t.Errorf("Expected the roof in the detail, got %+v", detail.SpecialCosts)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/overview/detail?month=April", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid month, got %d", w.Code)
+	}
+}
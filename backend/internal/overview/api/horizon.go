@@ -0,0 +1,101 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"wondee/finance-app-backend/internal/platform/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+const monthLayout = "2006-01"
+
+const (
+	DEFAULT_MONTHS = 30
+	MAX_MONTHS     = 120 // ten years from the current month
+)
+
+// Horizon is the range of months shown in the overview, both inclusive.
+// The projection always starts with the current month, so From must not lie
+// before it.
+type Horizon struct {
+	From types.YearMonth
+	To   types.YearMonth
+}
+
+// defaultHorizon covers DEFAULT_MONTHS starting with the current month.
+func defaultHorizon() Horizon {
+	from := *types.CurrentYearMonth()
+	return Horizon{From: from, To: *types.AddMonths(&from, DEFAULT_MONTHS-1)}
+}
+
+// Months returns the number of months in the horizon.
+func (h Horizon) Months() int {
+	return types.MonthsBetween(&h.From, &h.To) + 1
+}
+
+// Contains reports whether ym lies within the horizon.
+func (h Horizon) Contains(ym *types.YearMonth) bool {
+	return types.MonthsBetween(&h.From, ym) >= 0 && types.MonthsBetween(ym, &h.To) >= 0
+}
+
+// ParseHorizon reads the horizon from "from" and either "to" (both
+// YYYY-MM) or a number of "months". Without parameters DEFAULT_MONTHS
+// starting with the current month are shown; the horizon may reach up to
+// MAX_MONTHS months ahead.
+func ParseHorizon(c *gin.Context) (Horizon, error) {
+	current := types.CurrentYearMonth()
+	horizon := Horizon{From: *current}
+
+	if value := c.Query("from"); value != "" {
+		from, err := parseYearMonth(value)
+		if err != nil {
+			return Horizon{}, errors.New("from must be formatted as YYYY-MM")
+		}
+		if types.MonthsBetween(current, from) < 0 {
+			return Horizon{}, errors.New("from must not be before the current month")
+		}
+		horizon.From = *from
+	}
+
+	to, months := c.Query("to"), c.Query("months")
+	switch {
+	case to != "" && months != "":
+		return Horizon{}, errors.New("either to or months can be given")
+	case to != "":
+		parsed, err := parseYearMonth(to)
+		if err != nil {
+			return Horizon{}, errors.New("to must be formatted as YYYY-MM")
+		}
+		horizon.To = *parsed
+	case months != "":
+		n, err := strconv.Atoi(months)
+		if err != nil || n < 1 {
+			return Horizon{}, errors.New("months must be a positive number")
+		}
+		horizon.To = *types.AddMonths(&horizon.From, n-1)
+	default:
+		horizon.To = *types.AddMonths(&horizon.From, DEFAULT_MONTHS-1)
+	}
+
+	if types.MonthsBetween(&horizon.From, &horizon.To) < 0 {
+		return Horizon{}, errors.New("to must not be before from")
+	}
+	if types.MonthsBetween(current, &horizon.To) >= MAX_MONTHS {
+		return Horizon{}, fmt.Errorf("the overview is limited to %d months ahead", MAX_MONTHS)
+	}
+
+	return horizon, nil
+}
+
+// parseYearMonth parses a month formatted as YYYY-MM.
+func parseYearMonth(value string) (*types.YearMonth, error) {
+	t, err := time.Parse(monthLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	return types.New(t.Year(), int(t.Month()))
+}
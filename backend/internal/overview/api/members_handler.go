@@ -0,0 +1,141 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"sort"
+
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/currency"
+	"wondee/finance-app-backend/internal/platform/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MemberOverview struct {
+	Currency     string            `json:"currency"`
+	MissingRates []string          `json:"missingRates,omitempty"`
+	Members      []MemberBreakdown `json:"members"`
+}
+
+// MemberBreakdown is the part of the overview and of the current surplus
+// statistics a single member carries according to the splits of the costs.
+type MemberBreakdown struct {
+	UserID          uint          `json:"userId"`
+	Name            string        `json:"name"`
+	MonthlyIncome   float64       `json:"monthlyIncome"`
+	MonthlyExpenses float64       `json:"monthlyExpenses"`
+	CurrentSurplus  float64       `json:"currentSurplus"`
+	Entries         []MemberEntry `json:"entries"`
+}
+
+type MemberEntry struct {
+	YearMonth       types.YearMonth `json:"yearMonth"`
+	SumFixedCosts   int             `json:"sumFixedCosts"`
+	SumSpecialCosts int             `json:"sumSpecialCosts"`
+}
+
+// GetMemberOverview breaks the overview and the surplus statistics down to
+// the members of the workspace, so each partner sees their contribution to
+// the joint account.
+func (h *Handler) GetMemberOverview(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+	c.JSON(http.StatusOK, h.createMemberOverview(types.CurrentYearMonth(), workspaceID))
+}
+
+func (h *Handler) createMemberOverview(current *types.YearMonth, workspaceID uint) MemberOverview {
+	converter, _ := currency.LoadConverter(h.Repo, workspaceID)
+	fixedCosts := h.CostRepo.LoadFixedCosts(workspaceID)
+	specialCostMap := h.createSpecialCostMap(workspaceID)
+
+	members := make([]MemberBreakdown, 0)
+	if workspace, err := h.Repo.GetWorkspaceByID(workspaceID); err == nil {
+		for _, u := range workspace.Users {
+			members = append(members, MemberBreakdown{UserID: u.ID, Name: u.Name})
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].UserID < members[j].UserID })
+
+	memberIDs := make([]uint, len(members))
+	for i, member := range members {
+		memberIDs[i] = member.UserID
+	}
+
+	// Surplus statistics of the current month
+	for _, fc := range *fixedCosts {
+		if !types.IsRelevant(current, fc.From, fc.To) || fc.IsTransfer() {
+			continue
+		}
+		monthly := converter.ConvertFloat(fc.MonthlyAverage(current), fc.Currency, current)
+		parts := divide(fc.Split, float64(fc.AmountAt(current)), monthly, memberIDs)
+
+		for i := range members {
+			part := parts[members[i].UserID]
+			if part > 0 {
+				members[i].MonthlyIncome += part
+			} else {
+				members[i].MonthlyExpenses -= part
+			}
+			members[i].CurrentSurplus += part
+		}
+	}
+
+	// Overview entries
+	yearMonth := current
+	for n := 0; n < DEFAULT_MONTHS; n++ {
+		fixedSums := make(map[uint]float64)
+		for _, fc := range *fixedCosts {
+			if due := fc.DueAmount(yearMonth); due != 0 && !fc.IsTransfer() {
+				converted := converter.Convert(due, fc.Currency, yearMonth)
+				addParts(fixedSums, divide(fc.Split, float64(fc.AmountAt(yearMonth)), float64(converted), memberIDs))
+			}
+		}
+
+		specialSums := make(map[uint]float64)
+		for _, sc := range specialCostMap[*yearMonth] {
+			if sc.IsTransfer() {
+				continue
+			}
+			amount := sc.ExpectedAmount()
+			converted := converter.Convert(amount, sc.Currency, yearMonth)
+			addParts(specialSums, divide(sc.Split, float64(amount), float64(converted), memberIDs))
+		}
+
+		for i := range members {
+			members[i].Entries = append(members[i].Entries, MemberEntry{
+				YearMonth:       *yearMonth,
+				SumFixedCosts:   int(math.Round(fixedSums[members[i].UserID])),
+				SumSpecialCosts: int(math.Round(specialSums[members[i].UserID])),
+			})
+		}
+
+		yearMonth = types.NextYearMonth(yearMonth)
+	}
+
+	return MemberOverview{
+		Currency:     converter.Base(),
+		MissingRates: converter.Missing(),
+		Members:      members,
+	}
+}
+
+// divide splits a total made up of payments of the given amount. Fixed
+// shares apply per payment, so the split is calculated for one payment and
+// scaled to the total.
+func divide(split cost.Split, payment, total float64, members []uint) map[uint]float64 {
+	if payment == 0 {
+		return nil
+	}
+
+	parts := split.Divide(payment, members)
+	for member, part := range parts {
+		parts[member] = part * total / payment
+	}
+	return parts
+}
+
+func addParts(sums, parts map[uint]float64) {
+	for member, part := range parts {
+		sums[member] += part
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"testing"
+
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/storage"
+	"wondee/finance-app-backend/internal/user"
+	"wondee/finance-app-backend/internal/workspace"
+)
+
+func TestCreateMemberOverview(t *testing.T) {
+	var workspaceID uint = 1
+	current := &types.YearMonth{Year: 2025, Month: 1}
+
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{
+			ID:           workspaceID,
+			BaseCurrency: "EUR",
+			Users:        []user.User{{ID: 2, Name: "Bob"}, {ID: 1, Name: "Alice"}},
+		}},
+		FixedCosts: []cost.FixedCost{
+			{WorkspaceID: workspaceID, Name: "Salary Alice", Amount: 3000, DueMonth: cost.ALL_MONTHS,
+				Split: cost.Split{{UserID: 1, Percent: 100}}},
+			{WorkspaceID: workspaceID, Name: "Rent", Amount: -1200, DueMonth: cost.ALL_MONTHS,
+				Split: cost.Split{{UserID: 1, Percent: 75}, {UserID: 2, Percent: 25}}},
+			{WorkspaceID: workspaceID, Name: "Insurance", Amount: -240, DueMonth: []int{1}},
+			{WorkspaceID: workspaceID, Name: "Mobile", Amount: -40, DueMonth: cost.ALL_MONTHS,
+				Split: cost.Split{{UserID: 2, Amount: 30}}},
+		},
+		SpecialCosts: []cost.SpecialCost{
+			{WorkspaceID: workspaceID, Name: "Holiday", Amount: -1000, DueDate: &types.YearMonth{Year: 2025, Month: 2},
+				Split: cost.Split{{UserID: 2, Percent: 100}}},
+		},
+	}
+
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+	overview := handler.createMemberOverview(current, workspaceID)
+
+	if len(overview.Members) != 2 || overview.Members[0].Name != "Alice" || overview.Members[1].Name != "Bob" {
+		t.Fatalf("Expected members ordered by ID, got %+v", overview.Members)
+	}
+	alice, bob := overview.Members[0], overview.Members[1]
+
+	// Rent 900 + insurance 240 / 12 / 2 + mobile 10 / 2
+	if alice.MonthlyIncome != 3000 || alice.MonthlyExpenses != 915 || alice.CurrentSurplus != 2085 {
+		t.Errorf("Unexpected statistics for Alice: %+v", alice)
+	}
+	// Rent 300 + insurance 10 + mobile 30 + 5
+	if bob.MonthlyIncome != 0 || bob.MonthlyExpenses != 345 {
+		t.Errorf("Unexpected statistics for Bob: %+v", bob)
+	}
+
+	if len(alice.Entries) != DEFAULT_MONTHS {
+		t.Fatalf("Expected %d entries, got %d", DEFAULT_MONTHS, len(alice.Entries))
+	}
+	// January: salary 3000 - rent 900 - insurance 120 - mobile 5
+	if alice.Entries[0].SumFixedCosts != 1975 || bob.Entries[0].SumFixedCosts != -455 {
+		t.Errorf("Unexpected January entries: %+v / %+v", alice.Entries[0], bob.Entries[0])
+	}
+	if alice.Entries[1].SumSpecialCosts != 0 || bob.Entries[1].SumSpecialCosts != -1000 {
+		t.Errorf("Unexpected February entries: %+v / %+v", alice.Entries[1], bob.Entries[1])
+	}
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"wondee/finance-app-backend/internal/currency"
+	"wondee/finance-app-backend/internal/notification"
+	"wondee/finance-app-backend/internal/platform/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Risk describes how low the projected balance gets, expected and when every
+// estimated special cost turns out at the worst end of its range.
+// Contributors are the special expenses due from the current month up to the
+// lowest point, largest first.
+type Risk struct {
+	Currency                string          `json:"currency"`
+	MissingRates            []string        `json:"missingRates,omitempty"`
+	Threshold               int             `json:"threshold"`
+	LowestAmount            int             `json:"lowestAmount"`
+	LowestMonth             types.YearMonth `json:"lowestMonth"`
+	PessimisticLowestAmount int             `json:"pessimisticLowestAmount"`
+	PessimisticLowestMonth  types.YearMonth `json:"pessimisticLowestMonth"`
+	MonthsBelow             []RiskMonth     `json:"monthsBelow"`
+	Contributors            []CostDetail    `json:"contributors"`
+}
+
+// RiskMonth is a month whose projected balance lies below the threshold, at
+// least in the pessimistic case. Shortfall is zero if only the pessimistic
+// balance does.
+type RiskMonth struct {
+	YearMonth            types.YearMonth `json:"yearMonth"`
+	CurrentAmount        int             `json:"currentAmount"`
+	PessimisticAmount    int             `json:"pessimisticAmount"`
+	Shortfall            int             `json:"shortfall"`
+	PessimisticShortfall int             `json:"pessimisticShortfall"`
+}
+
+type UpdateThresholdRequest struct {
+	Threshold *int `json:"threshold" binding:"required"`
+}
+
+// GetRisk analyses the projected balance over the same horizon as
+// GetOverview.
+func (h *Handler) GetRisk(c *gin.Context) {
+	horizon, err := ParseHorizon(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.createRisk(h.getWorkspaceID(c), horizon))
+}
+
+// UpdateThreshold sets the balance the projection should not fall below.
+func (h *Handler) UpdateThreshold(c *gin.Context) {
+	var req UpdateThresholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workspaceID := h.getWorkspaceID(c)
+	if err := h.Repo.UpdateWorkspaceLowBalanceThreshold(workspaceID, *req.Threshold); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update threshold"})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.createRisk(workspaceID, defaultHorizon()))
+}
+
+func (h *Handler) createRisk(workspaceID uint, horizon Horizon) Risk {
+	overview := h.CreateOverview(workspaceID, horizon)
+	threshold := h.loadThreshold(workspaceID)
+
+	risk := Risk{
+		Currency:     overview.Currency,
+		MissingRates: overview.MissingRates,
+		Threshold:    threshold,
+		MonthsBelow:  monthsBelow(overview.Entries, threshold),
+		Contributors: make([]CostDetail, 0),
+	}
+
+	lowest := lowestEntry(overview.Entries, expected)
+	if lowest == nil {
+		return risk
+	}
+	risk.LowestAmount = lowest.CurrentAmount
+	risk.LowestMonth = lowest.YearMonth
+
+	pessimisticLowest := lowestEntry(overview.Entries, pessimistic)
+	risk.PessimisticLowestAmount = pessimisticLowest.PessimisticAmount
+	risk.PessimisticLowestMonth = pessimisticLowest.YearMonth
+
+	converter, _ := currency.LoadConverter(h.Repo, workspaceID)
+	specialCostMap := h.createSpecialCostMap(workspaceID)
+	current := types.CurrentYearMonth()
+	for ym := current; types.MonthsBetween(ym, &lowest.YearMonth) >= 0; ym = types.NextYearMonth(ym) {
+		for _, sc := range specialCostMap[*ym] {
+			amount := sc.ExpectedAmount()
+			if sc.IsTransfer() || amount >= 0 {
+				continue
+			}
+			converted := converter.Convert(amount, sc.Currency, ym)
+			risk.Contributors = append(risk.Contributors, toCostDetail(converter, sc.ID, sc.Name, amount, converted, sc.Currency))
+		}
+	}
+	sort.SliceStable(risk.Contributors, func(i, j int) bool {
+		return risk.Contributors[i].Amount < risk.Contributors[j].Amount
+	})
+
+	return risk
+}
+
+// Watch captures the projection before a cost is changed. The returned
+// function notifies the workspace if the change lets the projection fall
+// below the threshold; a projection already below it does not notify again.
+func (h *Handler) Watch(workspaceID uint) func(actor uint, costName string) {
+	if h.Notifications == nil {
+		return func(uint, string) {}
+	}
+
+	threshold := h.loadThreshold(workspaceID)
+	if len(monthsBelow(h.CreateOverview(workspaceID, defaultHorizon()).Entries, threshold)) > 0 {
+		return func(uint, string) {}
+	}
+
+	return func(actor uint, costName string) {
+		entries := h.CreateOverview(workspaceID, defaultHorizon()).Entries
+		below := monthsBelow(entries, threshold)
+		if len(below) == 0 {
+			return
+		}
+
+		err := h.Notifications.CreateNotification(&notification.Notification{
+			WorkspaceID: workspaceID,
+			Kind:        notification.KindLowBalance,
+			Subject:     costName,
+			Month:       below[0].YearMonth,
+			Amount:      lowestEntry(entries, pessimistic).PessimisticAmount,
+			Threshold:   threshold,
+			CreatedBy:   actor,
+		})
+		if err != nil {
+			fmt.Printf("Warning: Failed to notify workspace %d about low balance: %v\n", workspaceID, err)
+		}
+	}
+}
+
+func (h *Handler) loadThreshold(workspaceID uint) int {
+	if workspace, err := h.Repo.GetWorkspaceByID(workspaceID); err == nil {
+		return workspace.LowBalanceThreshold
+	}
+	return 0
+}
+
+// monthsBelow returns the entries whose projected balance lies below the
+// threshold, at least in the pessimistic case.
+func monthsBelow(entries []OverviewEntry, threshold int) []RiskMonth {
+	result := make([]RiskMonth, 0)
+	for _, entry := range entries {
+		if entry.PessimisticAmount < threshold || entry.CurrentAmount < threshold {
+			result = append(result, RiskMonth{
+				YearMonth:            entry.YearMonth,
+				CurrentAmount:        entry.CurrentAmount,
+				PessimisticAmount:    entry.PessimisticAmount,
+				Shortfall:            max(threshold-entry.CurrentAmount, 0),
+				PessimisticShortfall: max(threshold-entry.PessimisticAmount, 0),
+			})
+		}
+	}
+	return result
+}
+
+func expected(entry *OverviewEntry) int {
+	return entry.CurrentAmount
+}
+
+func pessimistic(entry *OverviewEntry) int {
+	return entry.PessimisticAmount
+}
+
+// lowestEntry returns the first entry with the lowest amount, nil without
+// entries.
+func lowestEntry(entries []OverviewEntry, amount func(*OverviewEntry) int) *OverviewEntry {
+	var lowest *OverviewEntry
+	for i := range entries {
+		if lowest == nil || amount(&entries[i]) < amount(lowest) {
+			lowest = &entries[i]
+		}
+	}
+	return lowest
+}
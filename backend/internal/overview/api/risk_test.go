@@ -0,0 +1,231 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"wondee/finance-app-backend/internal/account"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/notification"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/storage"
+	"wondee/finance-app-backend/internal/workspace"
+
+	"github.com/gin-gonic/gin"
+)
+
+// notificationRecorder implements notification repository.Repository
+type notificationRecorder struct {
+	created []notification.Notification
+}
+
+func (r *notificationRecorder) CreateNotification(n *notification.Notification) error {
+	r.created = append(r.created, *n)
+	return nil
+}
+
+func (r *notificationRecorder) ListNotifications(workspaceID uint, unreadOnly bool) ([]notification.Notification, error) {
+	return r.created, nil
+}
+
+func (r *notificationRecorder) GetNotification(id uint, workspaceID uint) (*notification.Notification, error) {
+	return nil, nil
+}
+
+func (r *notificationRecorder) MarkRead(id uint, workspaceID uint) error {
+	return nil
+}
+
+func TestCreateRisk(t *testing.T) {
+	var workspaceID uint = 1
+	current := types.CurrentYearMonth()
+
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: workspaceID, CurrentAmount: 1000, LowBalanceThreshold: 500}},
+		FixedCosts: []cost.FixedCost{
+			{WorkspaceID: workspaceID, Name: "Surplus", Amount: 100, DueMonth: cost.ALL_MONTHS},
+		},
+		SpecialCosts: []cost.SpecialCost{
+			{ID: 1, WorkspaceID: workspaceID, Name: "Car repair", Amount: -400, DueDate: types.AddMonths(current, 1)},
+			{ID: 2, WorkspaceID: workspaceID, Name: "Holiday", Amount: -900, DueDate: types.AddMonths(current, 2)},
+			{ID: 3, WorkspaceID: workspaceID, Name: "Bonus", Amount: 300, DueDate: types.AddMonths(current, 2)},
+			{ID: 4, WorkspaceID: workspaceID, Name: "Laptop", Amount: -200, DueDate: types.AddMonths(current, 6)},
+		},
+	}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+
+	risk := handler.createRisk(workspaceID, defaultHorizon())
+
+	// 1100, 800, 300, 400, 500, 600, 500, ...
+	if risk.Threshold != 500 || risk.LowestAmount != 300 || risk.LowestMonth != *types.AddMonths(current, 2) {
+		t.Errorf("Unexpected lowest point %d in %v", risk.LowestAmount, risk.LowestMonth)
+	}
+	if len(risk.MonthsBelow) != 2 {
+		t.Fatalf("Expected 2 months below the threshold, got %+v", risk.MonthsBelow)
+	}
+	if risk.MonthsBelow[0].CurrentAmount != 300 || risk.MonthsBelow[0].Shortfall != 200 || risk.MonthsBelow[1].Shortfall != 100 {
+		t.Errorf("Unexpected months below the threshold %+v", risk.MonthsBelow)
+	}
+	// Income and expenses after the lowest point do not contribute
+	if len(risk.Contributors) != 2 || risk.Contributors[0].Name != "Holiday" || risk.Contributors[1].Name != "Car repair" {
+		t.Errorf("Unexpected contributors %+v", risk.Contributors)
+	}
+}
+
+func TestCreateRiskReportsPessimisticMinimum(t *testing.T) {
+	var workspaceID uint = 1
+	current := types.CurrentYearMonth()
+	minAmount := -800
+
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: workspaceID, CurrentAmount: 1000, LowBalanceThreshold: 500}},
+		SpecialCosts: []cost.SpecialCost{
+			{ID: 1, WorkspaceID: workspaceID, Name: "Car repair", Amount: -300, MinAmount: &minAmount, DueDate: types.AddMonths(current, 1)},
+		},
+	}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+
+	risk := handler.createRisk(workspaceID, defaultHorizon())
+
+	// Expected 700 from the second month on, 200 at worst
+	if risk.LowestAmount != 700 || risk.PessimisticLowestAmount != 200 || risk.PessimisticLowestMonth != *types.AddMonths(current, 1) {
+		t.Errorf("Unexpected lowest points %d and %d in %v", risk.LowestAmount, risk.PessimisticLowestAmount, risk.PessimisticLowestMonth)
+	}
+	if len(risk.MonthsBelow) == 0 {
+		t.Fatalf("Expected months below the threshold in the pessimistic case")
+	}
+	below := risk.MonthsBelow[0]
+	if below.YearMonth != *types.AddMonths(current, 1) || below.Shortfall != 0 || below.PessimisticShortfall != 300 {
+		t.Errorf("Unexpected month below the threshold %+v", below)
+	}
+}
+
+func TestWatchNotifiesWhenBalanceFallsBelowThreshold(t *testing.T) {
+	var workspaceID uint = 1
+	current := types.CurrentYearMonth()
+
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: workspaceID, CurrentAmount: 1000, LowBalanceThreshold: 200}},
+	}
+	notifications := &notificationRecorder{}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo, Notifications: notifications}
+
+	// A cost that keeps the balance above the threshold
+	notify := handler.Watch(workspaceID)
+	mockRepo.SaveSpecialCost(&cost.SpecialCost{WorkspaceID: workspaceID, Name: "Bike", Amount: -500, DueDate: current})
+	notify(1, "Bike")
+	if len(notifications.created) != 0 {
+		t.Fatalf("Expected no notification, got %+v", notifications.created)
+	}
+
+	// A cost that pushes it below
+	notify = handler.Watch(workspaceID)
+	mockRepo.SaveSpecialCost(&cost.SpecialCost{WorkspaceID: workspaceID, Name: "Sofa", Amount: -400, DueDate: types.AddMonths(current, 3)})
+	notify(2, "Sofa")
+	if len(notifications.created) != 1 {
+		t.Fatalf("Expected 1 notification, got %+v", notifications.created)
+	}
+	n := notifications.created[0]
+	if n.Kind != notification.KindLowBalance || n.Subject != "Sofa" || n.Month != *types.AddMonths(current, 3) ||
+		n.Amount != 100 || n.Threshold != 200 || n.CreatedBy != 2 {
+		t.Errorf("Unexpected notification %+v", n)
+	}
+
+	// Already below the threshold, further costs do not notify again
+	notify = handler.Watch(workspaceID)
+	mockRepo.SaveSpecialCost(&cost.SpecialCost{WorkspaceID: workspaceID, Name: "Lamp", Amount: -50, DueDate: current})
+	notify(2, "Lamp")
+	if len(notifications.created) != 1 {
+		t.Errorf("Expected no further notification, got %+v", notifications.created)
+	}
+}
+
+func TestWatchNotifiesWhenIncomeIsDeleted(t *testing.T) {
+	var workspaceID uint = 1
+	current := types.CurrentYearMonth()
+
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: workspaceID, CurrentAmount: 100, LowBalanceThreshold: 200}},
+		SpecialCosts: []cost.SpecialCost{
+			{ID: 1, WorkspaceID: workspaceID, Name: "Tax refund", Amount: 300, DueDate: current},
+		},
+	}
+	notifications := &notificationRecorder{}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo, Notifications: notifications}
+
+	notify := handler.Watch(workspaceID)
+	mockRepo.DeleteSpecialCost(1, workspaceID)
+	notify(1, "Tax refund")
+
+	if len(notifications.created) != 1 || notifications.created[0].Subject != "Tax refund" || notifications.created[0].Amount != 100 {
+		t.Fatalf("Expected a notification about the deleted income, got %+v", notifications.created)
+	}
+}
+
+func TestWatchNotifiesAfterUnwatchedAccountChange(t *testing.T) {
+	var workspaceID uint = 1
+	current := types.CurrentYearMonth()
+
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: workspaceID, LowBalanceThreshold: 200}},
+		Accounts: []account.Account{
+			{ID: 1, WorkspaceID: workspaceID, Name: "Girokonto", Type: account.TypeChecking, Balance: 100},
+		},
+	}
+	notifications := &notificationRecorder{}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo, Notifications: notifications}
+
+	// Below the threshold from the start, no notification
+	notify := handler.Watch(workspaceID)
+	mockRepo.SaveSpecialCost(&cost.SpecialCost{WorkspaceID: workspaceID, Name: "Lamp", Amount: -50, DueDate: current})
+	notify(1, "Lamp")
+
+	// The account balance is raised without a watched change
+	mockRepo.Accounts[0].Balance = 1000
+
+	notify = handler.Watch(workspaceID)
+	mockRepo.SaveSpecialCost(&cost.SpecialCost{WorkspaceID: workspaceID, Name: "Sofa", Amount: -900, DueDate: types.AddMonths(current, 1)})
+	notify(1, "Sofa")
+
+	if len(notifications.created) != 1 || notifications.created[0].Subject != "Sofa" {
+		t.Fatalf("Expected a notification about the sofa, got %+v", notifications.created)
+	}
+}
+
+func TestUpdateThreshold(t *testing.T) {
+	var workspaceID uint = 1
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: workspaceID, CurrentAmount: 1000}},
+	}
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("workspace_id", workspaceID)
+		c.Next()
+	})
+	router.PUT("/overview/risk/threshold", handler.UpdateThreshold)
+
+	req := httptest.NewRequest(http.MethodPut, "/overview/risk/threshold", bytes.NewBufferString(`{"threshold": 1500}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if mockRepo.Workspaces[0].LowBalanceThreshold != 1500 {
+		t.Errorf("Expected threshold 1500, got %d", mockRepo.Workspaces[0].LowBalanceThreshold)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/overview/risk/threshold", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 without threshold, got %d", w.Code)
+	}
+}
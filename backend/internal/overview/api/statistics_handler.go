@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/currency"
 	"wondee/finance-app-backend/internal/overview/model"
 	"wondee/finance-app-backend/internal/platform/types"
 
@@ -20,6 +21,7 @@ func (h *Handler) GetSurplusStatistics(c *gin.Context) {
 
 func (h *Handler) CalculateSurplusStatistics(current *types.YearMonth, workspaceID uint) model.SurplusStatistics {
 	costs := h.CostRepo.LoadFixedCosts(workspaceID)
+	converter, _ := currency.LoadConverter(h.Repo, workspaceID)
 
 	// 1. Calculate History (Past 6 months including current)
 	history := make([]model.SurplusPoint, 0, 6)
@@ -32,7 +34,7 @@ func (h *Handler) CalculateSurplusStatistics(current *types.YearMonth, workspace
 
 	iter := start
 	for i := 0; i < 6; i++ {
-		surplus := h.calculateMonthlySurplus(costs, &iter)
+		surplus := h.calculateMonthlySurplus(converter, costs, &iter)
 
 		point := model.SurplusPoint{
 			Month:     fmt.Sprintf("%04d-%02d", iter.Year, iter.Month),
@@ -45,30 +47,43 @@ func (h *Handler) CalculateSurplusStatistics(current *types.YearMonth, workspace
 	}
 
 	// 2. Calculate Current Statistics (based on Current Month)
-	currentIncome, currentExpenses := h.calculateMonthlyBreakdown(costs, current)
+	currentIncome, currentExpenses := h.calculateMonthlyBreakdown(converter, costs, current)
 	currentSurplus := currentIncome + currentExpenses
 
+	// 3. Group the current month by category
+	categoryTree := cost.LoadCategoryTree(h.CostRepo, workspaceID)
+	amounts := make(categoryAmounts)
+	for _, cost := range *costs {
+		if types.IsRelevant(current, cost.From, cost.To) && !cost.IsTransfer() {
+			amounts.add(categoryTree, cost.CategoryID, converter.ConvertFloat(cost.MonthlyAverage(current), cost.Currency, current))
+		}
+	}
+
 	return model.SurplusStatistics{
 		CurrentSurplus:  currentSurplus,
 		MonthlyIncome:   currentIncome,
 		MonthlyExpenses: -currentExpenses, // Display as positive
 		History:         history,
+		Categories:      groupByCategory(categoryTree, amounts),
+		MissingRates:    converter.Missing(),
 	}
 }
 
-func (h *Handler) calculateMonthlySurplus(costs *[]cost.FixedCost, month *types.YearMonth) float64 {
-	income, expenses := h.calculateMonthlyBreakdown(costs, month)
+func (h *Handler) calculateMonthlySurplus(converter *currency.Converter, costs *[]cost.FixedCost, month *types.YearMonth) float64 {
+	income, expenses := h.calculateMonthlyBreakdown(converter, costs, month)
 	return income + expenses
 }
 
-func (h *Handler) calculateMonthlyBreakdown(costs *[]cost.FixedCost, month *types.YearMonth) (float64, float64) {
+// calculateMonthlyBreakdown sums the income and expenses of a month.
+// Transfers between accounts are neither.
+func (h *Handler) calculateMonthlyBreakdown(converter *currency.Converter, costs *[]cost.FixedCost, month *types.YearMonth) (float64, float64) {
 	var income float64
 	var expenses float64
 
 	if costs != nil {
 		for _, cost := range *costs {
-			if types.IsRelevant(month, cost.From, cost.To) {
-				monthlyAmount := float64(cost.Amount*len(cost.DueMonth)) / 12.0
+			if types.IsRelevant(month, cost.From, cost.To) && !cost.IsTransfer() {
+				monthlyAmount := converter.ConvertFloat(cost.MonthlyAverage(month), cost.Currency, month)
 
 				if monthlyAmount > 0 {
 					income += monthlyAmount
@@ -93,4 +108,4 @@ func getNextMonth(ym types.YearMonth) types.YearMonth {
 		return types.YearMonth{Year: ym.Year + 1, Month: 1}
 	}
 	return types.YearMonth{Year: ym.Year, Month: ym.Month + 1}
-}
\ No newline at end of file
+}
@@ -137,4 +137,47 @@ func TestCalculateSurplusHistory(t *testing.T) {
 			t.Errorf("Apr: Expected month 2023-04, got %s", stats.History[3].Month)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestCalculateSurplusStatisticsByCategory(t *testing.T) {
+	var workspaceID uint = 1
+	insurance := uint(1)
+
+	mockRepo := &storage.MockRepository{
+		Categories: []cost.Category{{ID: insurance, WorkspaceID: workspaceID, Name: "Versicherungen"}},
+		FixedCosts: []cost.FixedCost{
+			{WorkspaceID: workspaceID, Name: "Liability", Amount: -120, DueMonth: []int{1}, CategoryID: &insurance},
+			{WorkspaceID: workspaceID, Name: "Household", Amount: -30, DueMonth: []int{1, 4, 7, 10}, CategoryID: &insurance},
+		},
+	}
+
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+	stats := handler.CalculateSurplusStatistics(&types.YearMonth{Year: 2023, Month: 6}, workspaceID)
+
+	// 120 / 12 + 30 * 4 / 12 = 20
+	if len(stats.Categories) != 1 || stats.Categories[0].Total != -20 {
+		t.Errorf("Expected monthly insurance total of -20, got %+v", stats.Categories)
+	}
+}
+
+func TestCalculateSurplusStatisticsIgnoresTransfers(t *testing.T) {
+	var workspaceID uint = 1
+	savings := uint(2)
+
+	mockRepo := &storage.MockRepository{
+		FixedCosts: []cost.FixedCost{
+			{WorkspaceID: workspaceID, Name: "Salary", Amount: 3000, DueMonth: cost.ALL_MONTHS},
+			{WorkspaceID: workspaceID, Name: "Sparplan", Amount: -500, DueMonth: cost.ALL_MONTHS, TargetAccountID: &savings},
+		},
+	}
+
+	handler := &Handler{Repo: mockRepo, CostRepo: mockRepo}
+	stats := handler.CalculateSurplusStatistics(&types.YearMonth{Year: 2023, Month: 6}, workspaceID)
+
+	if stats.MonthlyIncome != 3000 || stats.MonthlyExpenses != 0 || stats.CurrentSurplus != 3000 {
+		t.Errorf("Expected transfer to be neutral, got %+v", stats)
+	}
+	if len(stats.Categories) != 1 || stats.Categories[0].Total != 3000 {
+		t.Errorf("Expected transfer to be left out of the categories, got %+v", stats.Categories)
+	}
+}
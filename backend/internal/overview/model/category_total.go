@@ -0,0 +1,12 @@
+package model
+
+// CategoryTotal is the sum of all costs booked on a category. Total includes
+// the amounts of all subcategories, Amount only the costs assigned directly.
+// Costs without category are reported with a nil CategoryID.
+type CategoryTotal struct {
+	CategoryID *uint   `json:"categoryId"`
+	ParentID   *uint   `json:"parentId"`
+	Name       string  `json:"name"`
+	Amount     float64 `json:"amount"`
+	Total      float64 `json:"total"`
+}
@@ -7,8 +7,10 @@ type SurplusPoint struct {
 }
 
 type SurplusStatistics struct {
-	CurrentSurplus  float64        `json:"current_surplus"`
-	MonthlyIncome   float64        `json:"monthly_income"`
-	MonthlyExpenses float64        `json:"monthly_expenses"`
-	History         []SurplusPoint `json:"history"`
+	CurrentSurplus  float64         `json:"current_surplus"`
+	MonthlyIncome   float64         `json:"monthly_income"`
+	MonthlyExpenses float64         `json:"monthly_expenses"`
+	History         []SurplusPoint  `json:"history"`
+	Categories      []CategoryTotal `json:"categories"`
+	MissingRates    []string        `json:"missing_rates,omitempty"`
 }
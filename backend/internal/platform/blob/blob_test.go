@@ -0,0 +1,54 @@
+package blob
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStore(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "attachments")
+	store := NewLocalStore(root)
+
+	if err := store.Put("7/contract", strings.NewReader("policy")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	reader, err := store.Get("7/contract")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	content, _ := io.ReadAll(reader)
+	reader.Close()
+	if string(content) != "policy" {
+		t.Errorf("Expected stored content, got %q", content)
+	}
+
+	if err := store.Delete("7/contract"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := store.Get("7/contract"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+	if err := store.Delete("7/contract"); err != nil {
+		t.Errorf("Expected deleting twice to succeed, got %v", err)
+	}
+
+	entries, _ := os.ReadDir(filepath.Join(root, "7"))
+	if len(entries) != 0 {
+		t.Errorf("Expected no leftover temporary files, got %v", entries)
+	}
+}
+
+func TestLocalStore_RejectsKeysOutsideRoot(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "7/../../secret", "7//x", `7\x`} {
+		if err := store.Put(key, strings.NewReader("x")); err == nil {
+			t.Errorf("Expected key %q to be rejected", key)
+		}
+	}
+}
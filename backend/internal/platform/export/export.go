@@ -0,0 +1,183 @@
+// Package export writes tabular data as CSV, JSON or XLSX so it can be
+// loaded into spreadsheets. Tables are written row by row straight into the
+// response.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+	FormatXLSX Format = "xlsx"
+)
+
+// ParseFormat accepts the supported formats case-insensitively; an empty
+// value means CSV.
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(value)) {
+	case "", FormatCSV:
+		return FormatCSV, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	}
+	return "", fmt.Errorf("unsupported format %q, use csv, json or xlsx", value)
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatJSON:
+		return "application/json"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Table is a named list of rows. Columns double as CSV/XLSX header and as
+// JSON keys.
+type Table struct {
+	Name    string
+	Columns []string
+	Rows    [][]any
+}
+
+func (t *Table) Append(values ...any) {
+	t.Rows = append(t.Rows, values)
+}
+
+// Respond streams the table in the format given by the "format" query
+// parameter as a file download.
+func Respond(c *gin.Context, table *Table) {
+	format, err := ParseFormat(c.Query("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", table.Name+"."+string(format)))
+	c.Status(http.StatusOK)
+
+	if err := Write(c.Writer, format, table); err != nil {
+		c.Error(err)
+	}
+}
+
+func Write(w io.Writer, format Format, table *Table) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, table)
+	case FormatXLSX:
+		return writeXLSX(w, table)
+	default:
+		return writeCSV(w, table)
+	}
+}
+
+func writeCSV(w io.Writer, table *Table) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(table.Columns); err != nil {
+		return err
+	}
+
+	record := make([]string, len(table.Columns))
+	for _, row := range table.Rows {
+		for i := range record {
+			record[i] = ""
+			if i < len(row) {
+				record[i] = text(row[i])
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeJSON(w io.Writer, table *Table) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	for i, row := range table.Rows {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+
+		object := make(map[string]any, len(table.Columns))
+		for j, column := range table.Columns {
+			if j < len(row) {
+				object[column] = jsonValue(row[j])
+			}
+		}
+
+		data, err := json.Marshal(object)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, "]")
+	return err
+}
+
+// text renders a value for CSV and string cells.
+func text(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case *types.YearMonth:
+		if v == nil {
+			return ""
+		}
+		return yearMonth(*v)
+	case types.YearMonth:
+		return yearMonth(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func jsonValue(value any) any {
+	switch v := value.(type) {
+	case *types.YearMonth, types.YearMonth:
+		if s := text(v); s != "" {
+			return s
+		}
+		return nil
+	default:
+		return v
+	}
+}
+
+func yearMonth(ym types.YearMonth) string {
+	return fmt.Sprintf("%04d-%02d", ym.Year, ym.Month)
+}
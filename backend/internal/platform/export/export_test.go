@@ -0,0 +1,111 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+func sampleTable() *Table {
+	table := &Table{Name: "costs", Columns: []string{"name", "amount", "from", "isSaving"}}
+	table.Append("Miete, warm", -900, &types.YearMonth{Year: 2025, Month: 3}, false)
+	table.Append("ETF <Welt>", -250.5, (*types.YearMonth)(nil), true)
+	return table
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := Write(&buffer, FormatCSV, sampleTable()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "name,amount,from,isSaving\n" +
+		"\"Miete, warm\",-900,2025-03,false\n" +
+		"ETF <Welt>,-250.5,,true\n"
+	if buffer.String() != expected {
+		t.Errorf("Unexpected CSV:\n%s", buffer.String())
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := Write(&buffer, FormatJSON, sampleTable()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var rows []map[string]any
+	if err := json.Unmarshal(buffer.Bytes(), &rows); err != nil {
+		t.Fatalf("Invalid JSON %s: %v", buffer.String(), err)
+	}
+
+	if len(rows) != 2 || rows[0]["from"] != "2025-03" || rows[0]["amount"] != -900.0 || rows[1]["from"] != nil {
+		t.Errorf("Unexpected rows: %v", rows)
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := Write(&buffer, FormatXLSX, sampleTable()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatalf("Expected a zip archive, got %v", err)
+	}
+
+	files := make(map[string]string)
+	for _, file := range archive.File {
+		reader, _ := file.Open()
+		content, _ := io.ReadAll(reader)
+		files[file.Name] = string(content)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("Missing part %s", name)
+		}
+	}
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	for _, expected := range []string{
+		`<c r="A1" t="inlineStr"><is><t xml:space="preserve">name</t></is></c>`,
+		`<c r="B2"><v>-900</v></c>`,
+		`<c r="C2" t="inlineStr"><is><t xml:space="preserve">2025-03</t></is></c>`,
+		`<c r="D3" t="b"><v>1</v></c>`,
+		`ETF &lt;Welt&gt;`,
+	} {
+		if !strings.Contains(sheet, expected) {
+			t.Errorf("Expected sheet to contain %s", expected)
+		}
+	}
+	if strings.Contains(sheet, `r="C3"`) {
+		t.Error("Expected empty cells to be omitted")
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for index, expected := range tests {
+		if got := columnName(index); got != expected {
+			t.Errorf("columnName(%d) = %s, expected %s", index, got, expected)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if format, err := ParseFormat(""); err != nil || format != FormatCSV {
+		t.Errorf("Expected CSV as default, got %s, %v", format, err)
+	}
+	if format, err := ParseFormat("XLSX"); err != nil || format != FormatXLSX {
+		t.Errorf("Expected XLSX, got %s, %v", format, err)
+	}
+	if _, err := ParseFormat("pdf"); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
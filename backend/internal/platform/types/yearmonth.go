@@ -63,16 +63,19 @@ func NextYearMonth(yearMonth *YearMonth) *YearMonth {
 	return AddMonths(yearMonth, 1)
 }
 
+// AddMonths returns the month n months after the given one; n may be
+// negative.
 func AddMonths(yearMonth *YearMonth, n int) *YearMonth {
-	nextMonth := yearMonth.Month + n
-	nextYear := yearMonth.Year
+	months := yearMonth.Year*12 + yearMonth.Month - 1 + n
 
-	if nextMonth > 12 {
-		nextYear = nextYear + (nextMonth / 12)
-		nextMonth = nextMonth % 12
+	year := months / 12
+	month := months % 12
+	if month < 0 {
+		year--
+		month += 12
 	}
 
-	return &YearMonth{Year: nextYear, Month: nextMonth}
+	return &YearMonth{Year: year, Month: month + 1}
 }
 
 func compare(this, other *YearMonth) int {
@@ -107,3 +110,9 @@ func IsRelevant(this, from, to *YearMonth) bool {
 
 	return isGreaterThanOrEqual(this, from) && isLessThanOrEqual(this, to)
 }
+
+// MonthsBetween returns the number of months from "from" to "to", negative if
+// "to" lies before "from".
+func MonthsBetween(from, to *YearMonth) int {
+	return (to.Year-from.Year)*12 + to.Month - from.Month
+}
@@ -34,6 +34,10 @@ func TestAddMonths(t *testing.T) {
 		{"Add within year", &YearMonth{2023, 1}, 5, &YearMonth{2023, 6}},
 		{"Add wrap year", &YearMonth{2023, 10}, 3, &YearMonth{2024, 1}},
 		{"Add multiple years", &YearMonth{2023, 1}, 25, &YearMonth{2025, 2}},
+		{"Add to december", &YearMonth{2023, 12}, 12, &YearMonth{2024, 12}},
+		{"Add into december", &YearMonth{2023, 6}, 6, &YearMonth{2023, 12}},
+		{"Subtract wrap year", &YearMonth{2024, 1}, -1, &YearMonth{2023, 12}},
+		{"Subtract multiple years", &YearMonth{2024, 3}, -27, &YearMonth{2021, 12}},
 	}
 
 	for _, tt := range tests {
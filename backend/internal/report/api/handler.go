@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/report/service"
+)
+
+// Handler handles HTTP requests for reports comparing plan and reality
+type Handler struct {
+	service *service.ReportService
+}
+
+// NewHandler creates a new Handler instance
+func NewHandler(reportService *service.ReportService) *Handler {
+	return &Handler{service: reportService}
+}
+
+// GetPlanVsActual compares the planned with the actual amounts per month and
+// category of the year given as ?year=YYYY, defaulting to the current one.
+// Months still ahead are left out.
+func (h *Handler) GetPlanVsActual(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+	current := types.CurrentYearMonth()
+
+	year := current.Year
+	if value := c.Query("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err == nil {
+			_, err = types.New(parsed, 1)
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year"})
+			return
+		}
+		year = parsed
+	}
+
+	result, err := h.service.PlanVsActual(workspaceID, year, *current)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) getWorkspaceID(c *gin.Context) uint {
+	workspaceID, exists := c.Get("workspace_id")
+	if !exists {
+		return 0
+	}
+	return workspaceID.(uint)
+}
@@ -0,0 +1,179 @@
+package report
+
+import (
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+const UNCATEGORIZED = "Ohne Kategorie"
+
+// Line is an amount planned or actually booked on a category in a month, in
+// the base currency. Lines without category have a nil CategoryID.
+type Line struct {
+	Month      types.YearMonth
+	CategoryID *uint
+	Amount     int
+}
+
+// CategoryVariance compares plan and reality of a category. The amounts
+// include all subcategories. Like costs, expenses are negative, so a
+// negative variance means less money than planned.
+type CategoryVariance struct {
+	CategoryID *uint  `json:"categoryId"`
+	ParentID   *uint  `json:"parentId"`
+	Name       string `json:"name"`
+	Planned    int    `json:"planned"`
+	Actual     int    `json:"actual"`
+	Variance   int    `json:"variance"`
+}
+
+type Totals struct {
+	Planned    int                `json:"planned"`
+	Actual     int                `json:"actual"`
+	Variance   int                `json:"variance"`
+	Categories []CategoryVariance `json:"categories"`
+}
+
+// Month compares plan and reality of a single month. A month is tracked if
+// anything was actually booked in it; untracked months are reported but
+// left out of the year to date.
+type Month struct {
+	Month   types.YearMonth `json:"month"`
+	Tracked bool            `json:"tracked"`
+	Totals
+}
+
+type Report struct {
+	Year         int      `json:"year"`
+	Currency     string   `json:"currency"`
+	MissingRates []string `json:"missingRates,omitempty"`
+	Months       []Month  `json:"months"`
+	YearToDate   Totals   `json:"yearToDate"`
+}
+
+// Build compares the planned with the actual lines for each of the months
+// and sums the tracked months up to the year to date.
+func Build(year int, months []types.YearMonth, planned, actual []Line, tree *cost.CategoryTree) Report {
+	report := Report{
+		Year:       year,
+		Months:     make([]Month, 0, len(months)),
+		YearToDate: Totals{Categories: make([]CategoryVariance, 0)},
+	}
+
+	plannedByMonth := groupByMonth(tree, planned)
+	actualByMonth := groupByMonth(tree, actual)
+
+	ytdPlanned := make(categoryAmounts)
+	ytdActual := make(categoryAmounts)
+	for _, ym := range months {
+		_, tracked := actualByMonth[ym]
+		month := Month{
+			Month:   ym,
+			Tracked: tracked,
+			Totals:  compare(tree, plannedByMonth[ym], actualByMonth[ym]),
+		}
+		report.Months = append(report.Months, month)
+
+		if tracked {
+			ytdPlanned.merge(plannedByMonth[ym])
+			ytdActual.merge(actualByMonth[ym])
+		}
+	}
+	report.YearToDate = compare(tree, ytdPlanned, ytdActual)
+
+	return report
+}
+
+// categoryAmounts collects the amounts booked on each category; lines
+// without (or with an unknown) category are collected under key 0.
+type categoryAmounts map[uint]int
+
+func (a categoryAmounts) add(tree *cost.CategoryTree, categoryID *uint, amount int) {
+	if categoryID == nil || !tree.Contains(*categoryID) {
+		a[0] += amount
+		return
+	}
+	a[*categoryID] += amount
+}
+
+func (a categoryAmounts) merge(other categoryAmounts) {
+	for id, amount := range other {
+		a[id] += amount
+	}
+}
+
+func (a categoryAmounts) total() int {
+	total := 0
+	for _, amount := range a {
+		total += amount
+	}
+	return total
+}
+
+func groupByMonth(tree *cost.CategoryTree, lines []Line) map[types.YearMonth]categoryAmounts {
+	result := make(map[types.YearMonth]categoryAmounts)
+	for _, line := range lines {
+		if result[line.Month] == nil {
+			result[line.Month] = make(categoryAmounts)
+		}
+		result[line.Month].add(tree, line.CategoryID, line.Amount)
+	}
+	return result
+}
+
+func compare(tree *cost.CategoryTree, planned, actual categoryAmounts) Totals {
+	totals := Totals{
+		Planned:    planned.total(),
+		Actual:     actual.total(),
+		Categories: make([]CategoryVariance, 0),
+	}
+	totals.Variance = totals.Actual - totals.Planned
+
+	for _, root := range tree.Roots() {
+		appendCategoryVariances(tree, root, planned, actual, &totals.Categories)
+	}
+
+	if planned[0] != 0 || actual[0] != 0 {
+		totals.Categories = append(totals.Categories, CategoryVariance{
+			Name:     UNCATEGORIZED,
+			Planned:  planned[0],
+			Actual:   actual[0],
+			Variance: actual[0] - planned[0],
+		})
+	}
+
+	return totals
+}
+
+// appendCategoryVariances rolls the amounts up the category tree. Categories
+// are listed depth first, categories without any amount are omitted.
+func appendCategoryVariances(
+	tree *cost.CategoryTree,
+	category cost.Category,
+	planned, actual categoryAmounts,
+	result *[]CategoryVariance,
+) (int, int) {
+	index := len(*result)
+	*result = append(*result, CategoryVariance{
+		CategoryID: &category.ID,
+		ParentID:   category.ParentID,
+		Name:       category.Name,
+	})
+
+	plannedTotal, actualTotal := planned[category.ID], actual[category.ID]
+	for _, child := range tree.Children(category.ID) {
+		childPlanned, childActual := appendCategoryVariances(tree, child, planned, actual, result)
+		plannedTotal += childPlanned
+		actualTotal += childActual
+	}
+
+	if plannedTotal == 0 && actualTotal == 0 && len(*result) == index+1 {
+		*result = (*result)[:index]
+		return 0, 0
+	}
+
+	(*result)[index].Planned = plannedTotal
+	(*result)[index].Actual = actualTotal
+	(*result)[index].Variance = actualTotal - plannedTotal
+	return plannedTotal, actualTotal
+}
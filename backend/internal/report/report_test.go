@@ -0,0 +1,78 @@
+package report
+
+import (
+	"testing"
+
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+func TestBuild(t *testing.T) {
+	parentID := uint(1)
+	tree := cost.NewCategoryTree([]cost.Category{
+		{ID: 1, Name: "Living"},
+		{ID: 2, Name: "Rent", ParentID: &parentID},
+		{ID: 3, Name: "Food", ParentID: &parentID},
+	})
+	rent, food := uint(2), uint(3)
+
+	january := types.YearMonth{Year: 2025, Month: 1}
+	february := types.YearMonth{Year: 2025, Month: 2}
+	months := []types.YearMonth{january, february}
+
+	planned := []Line{
+		{Month: january, CategoryID: &rent, Amount: -1000},
+		{Month: january, CategoryID: &food, Amount: -400},
+		{Month: february, CategoryID: &rent, Amount: -1000},
+		{Month: february, CategoryID: &food, Amount: -400},
+	}
+	actual := []Line{
+		{Month: january, CategoryID: &rent, Amount: -1000},
+		{Month: january, CategoryID: &food, Amount: -470},
+		{Month: january, Amount: -30},
+	}
+
+	report := Build(2025, months, planned, actual, tree)
+
+	if len(report.Months) != 2 {
+		t.Fatalf("Expected 2 months, got %d", len(report.Months))
+	}
+
+	jan := report.Months[0]
+	if !jan.Tracked || jan.Planned != -1400 || jan.Actual != -1500 || jan.Variance != -100 {
+		t.Errorf("Unexpected January totals %+v", jan.Totals)
+	}
+	expected := []CategoryVariance{
+		{Name: "Living", Planned: -1400, Actual: -1470, Variance: -70},
+		{Name: "Rent", Planned: -1000, Actual: -1000, Variance: 0},
+		{Name: "Food", Planned: -400, Actual: -470, Variance: -70},
+		{Name: UNCATEGORIZED, Planned: 0, Actual: -30, Variance: -30},
+	}
+	if len(jan.Categories) != len(expected) {
+		t.Fatalf("Expected %d categories, got %+v", len(expected), jan.Categories)
+	}
+	for i, category := range jan.Categories {
+		category.CategoryID, category.ParentID = nil, nil
+		if category != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], category)
+		}
+	}
+
+	// Nothing was recorded in February yet, so it does not count towards
+	// the year to date
+	feb := report.Months[1]
+	if feb.Tracked || feb.Planned != -1400 || feb.Actual != 0 {
+		t.Errorf("Unexpected February totals %+v", feb.Totals)
+	}
+	if report.YearToDate.Planned != -1400 || report.YearToDate.Actual != -1500 || report.YearToDate.Variance != -100 {
+		t.Errorf("Unexpected year to date %+v", report.YearToDate)
+	}
+}
+
+func TestBuildWithoutMonths(t *testing.T) {
+	report := Build(2030, nil, nil, nil, cost.NewCategoryTree(nil))
+
+	if len(report.Months) != 0 || report.YearToDate.Categories == nil {
+		t.Errorf("Expected an empty report, got %+v", report)
+	}
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"time"
+
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/settlement"
+	"wondee/finance-app-backend/internal/spend"
+	"wondee/finance-app-backend/internal/transaction"
+)
+
+func (r *PostgresRepository) GetPaidStatuses(workspaceID uint, months []types.YearMonth) ([]spend.MonthlyPaymentStatus, error) {
+	var statuses []spend.MonthlyPaymentStatus
+	if len(months) == 0 {
+		return statuses, nil
+	}
+
+	values := make([]interface{}, 0, len(months))
+	for _, month := range months {
+		value, _ := month.Value()
+		values = append(values, value)
+	}
+
+	result := r.DB.Where("workspace_id = ? AND is_paid = ? AND month IN ?", workspaceID, true, values).Find(&statuses)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return statuses, nil
+}
+
+func (r *PostgresRepository) GetTransactions(workspaceID uint, ids []uint) ([]transaction.Transaction, error) {
+	var transactions []transaction.Transaction
+	if len(ids) == 0 {
+		return transactions, nil
+	}
+
+	result := r.DB.Where("workspace_id = ? AND id IN ?", workspaceID, ids).Find(&transactions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return transactions, nil
+}
+
+func (r *PostgresRepository) GetSpecialCostExpenses(workspaceID uint, from, to time.Time) ([]settlement.Entry, error) {
+	var entries []settlement.Entry
+	result := r.DB.Where("workspace_id = ? AND kind = ? AND cost_type = ? AND cost_id IS NOT NULL AND date >= ? AND date <= ?",
+		workspaceID, settlement.KindExpense, settlement.CostSpecial, from, to).
+		Find(&entries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return entries, nil
+}
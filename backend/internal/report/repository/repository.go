@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"time"
+
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/settlement"
+	"wondee/finance-app-backend/internal/spend"
+	"wondee/finance-app-backend/internal/transaction"
+
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for reading what was actually paid
+type Repository interface {
+	// GetPaidStatuses returns the fixed costs marked paid in the months.
+	GetPaidStatuses(workspaceID uint, months []types.YearMonth) ([]spend.MonthlyPaymentStatus, error)
+	GetTransactions(workspaceID uint, ids []uint) ([]transaction.Transaction, error)
+	// GetSpecialCostExpenses returns the shared expenses linked to a special
+	// cost that were paid between from and to, both inclusive.
+	GetSpecialCostExpenses(workspaceID uint, from, to time.Time) ([]settlement.Entry, error)
+}
+
+// PostgresRepository implements Repository using GORM
+type PostgresRepository struct {
+	DB *gorm.DB
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"wondee/finance-app-backend/internal/budget"
+	budget_repo "wondee/finance-app-backend/internal/budget/repository"
+	"wondee/finance-app-backend/internal/cost"
+	cost_repo "wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/currency"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/report"
+	"wondee/finance-app-backend/internal/report/repository"
+	"wondee/finance-app-backend/internal/storage"
+)
+
+// ReportService compares the planned costs with what was actually paid
+type ReportService struct {
+	repo     repository.Repository
+	budgets  budget_repo.Repository
+	costRepo cost_repo.Repository
+	settings storage.Repository
+}
+
+// NewReportService creates a new ReportService instance
+func NewReportService(repo repository.Repository, budgets budget_repo.Repository, costRepo cost_repo.Repository, settings storage.Repository) *ReportService {
+	return &ReportService{
+		repo:     repo,
+		budgets:  budgets,
+		costRepo: costRepo,
+		settings: settings,
+	}
+}
+
+// PlanVsActual reports the months of year up to current.
+//
+// The plan consists of the fixed costs due, the special costs due with
+// their expected amount and the category budgets of a month. Actually paid
+// are the fixed costs marked paid, with the amount of their bank transaction
+// if one is linked, the spending booked against the budgets and the shared
+// expenses linked to a special cost. Transfers between accounts are left out.
+func (s *ReportService) PlanVsActual(workspaceID uint, year int, current types.YearMonth) (*report.Report, error) {
+	months := monthsOf(year, current)
+	converter, err := currency.LoadConverter(s.settings, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	fixedCosts := s.costRepo.LoadFixedCosts(workspaceID)
+	specialCosts := s.costRepo.LoadSpecialCosts(workspaceID)
+	budgets, err := s.budgets.GetBudgets(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	planned := plannedLines(converter, months, *fixedCosts, *specialCosts, budgets)
+
+	actual, err := s.actualLines(converter, workspaceID, months, *fixedCosts, *specialCosts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := report.Build(year, months, planned, actual, cost.LoadCategoryTree(s.costRepo, workspaceID))
+	result.Currency = converter.Base()
+	result.MissingRates = converter.Missing()
+	return &result, nil
+}
+
+func plannedLines(converter *currency.Converter, months []types.YearMonth, fixedCosts []cost.FixedCost, specialCosts []cost.SpecialCost, budgets []budget.Budget) []report.Line {
+	var lines []report.Line
+
+	for _, ym := range months {
+		for _, fc := range fixedCosts {
+			if fc.IsTransfer() {
+				continue
+			}
+			if amount := fc.DueAmount(&ym); amount != 0 {
+				lines = append(lines, report.Line{Month: ym, CategoryID: fc.CategoryID, Amount: converter.Convert(amount, fc.Currency, &ym)})
+			}
+		}
+
+		for _, b := range budget.Active(budgets, ym) {
+			categoryID := b.CategoryID
+			lines = append(lines, report.Line{Month: ym, CategoryID: &categoryID, Amount: -b.Amount})
+		}
+	}
+
+	for _, sc := range specialCosts {
+		if sc.IsTransfer() {
+			continue
+		}
+		for _, part := range sc.Expand() {
+			if !slices.Contains(months, *part.DueDate) {
+				continue
+			}
+			lines = append(lines, report.Line{Month: *part.DueDate, CategoryID: sc.CategoryID, Amount: converter.Convert(part.ExpectedAmount(), sc.Currency, part.DueDate)})
+		}
+	}
+
+	return lines
+}
+
+func (s *ReportService) actualLines(converter *currency.Converter, workspaceID uint, months []types.YearMonth, fixedCosts []cost.FixedCost, specialCosts []cost.SpecialCost) ([]report.Line, error) {
+	var lines []report.Line
+	if len(months) == 0 {
+		return lines, nil
+	}
+
+	// Fixed costs marked paid
+	statuses, err := s.repo.GetPaidStatuses(workspaceID, months)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactionIDs []uint
+	for _, status := range statuses {
+		if status.TransactionID != nil {
+			transactionIDs = append(transactionIDs, *status.TransactionID)
+		}
+	}
+	transactions, err := s.repo.GetTransactions(workspaceID, transactionIDs)
+	if err != nil {
+		return nil, err
+	}
+	paidAmounts := make(map[uint]int, len(transactions))
+	for _, t := range transactions {
+		ym := t.Month()
+		paidAmounts[t.ID] = converter.Convert(t.Amount(), t.Currency, &ym)
+	}
+
+	fixedCostsByID := make(map[int]*cost.FixedCost, len(fixedCosts))
+	for i := range fixedCosts {
+		fixedCostsByID[fixedCosts[i].ID] = &fixedCosts[i]
+	}
+
+	for _, status := range statuses {
+		fc, ok := fixedCostsByID[status.FixedCostID]
+		if !ok || fc.IsTransfer() {
+			continue
+		}
+
+		month := status.Month
+		// Without a transaction the cost was paid as planned; costs included
+		// manually outside their due month count once
+		amount := converter.Convert(fc.AmountAt(&month)*max(fc.Occurrences(&month), 1), fc.Currency, &month)
+		if status.TransactionID != nil {
+			if paid, ok := paidAmounts[*status.TransactionID]; ok {
+				amount = paid
+			}
+		}
+		lines = append(lines, report.Line{Month: month, CategoryID: fc.CategoryID, Amount: amount})
+	}
+
+	from, to := firstDay(months[0]), lastDay(months[len(months)-1])
+
+	// Spending booked against the budgets
+	entries, err := s.budgets.ListEntries(workspaceID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		month := entry.Month()
+		categoryID := entry.CategoryID
+		lines = append(lines, report.Line{Month: month, CategoryID: &categoryID, Amount: converter.Convert(entry.Amount, entry.Currency, &month)})
+	}
+
+	// Shared expenses paying a special cost, recorded in the base currency
+	expenses, err := s.repo.GetSpecialCostExpenses(workspaceID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	specialCostsByID := make(map[int]*cost.SpecialCost, len(specialCosts))
+	for i := range specialCosts {
+		specialCostsByID[specialCosts[i].ID] = &specialCosts[i]
+	}
+
+	for _, expense := range expenses {
+		sc, ok := specialCostsByID[int(*expense.CostID)]
+		if !ok || sc.IsTransfer() {
+			continue
+		}
+		month := types.YearMonth{Year: expense.Date.Year(), Month: int(expense.Date.Month())}
+		amount := -int(math.Round(float64(expense.AmountCents) / 100))
+		lines = append(lines, report.Line{Month: month, CategoryID: sc.CategoryID, Amount: amount})
+	}
+
+	return lines, nil
+}
+
+// monthsOf returns the months of year, but none after current.
+func monthsOf(year int, current types.YearMonth) []types.YearMonth {
+	months := make([]types.YearMonth, 0, 12)
+	for month := 1; month <= 12; month++ {
+		ym := types.YearMonth{Year: year, Month: month}
+		if types.MonthsBetween(&ym, &current) < 0 {
+			break
+		}
+		months = append(months, ym)
+	}
+	return months
+}
+
+func firstDay(month types.YearMonth) time.Time {
+	return time.Date(month.Year, time.Month(month.Month), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func lastDay(month types.YearMonth) time.Time {
+	return firstDay(month).AddDate(0, 1, -1)
+}
@@ -0,0 +1,185 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"wondee/finance-app-backend/internal/budget"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/report"
+	"wondee/finance-app-backend/internal/report/service"
+	"wondee/finance-app-backend/internal/settlement"
+	"wondee/finance-app-backend/internal/spend"
+	"wondee/finance-app-backend/internal/storage"
+	"wondee/finance-app-backend/internal/transaction"
+	"wondee/finance-app-backend/internal/workspace"
+)
+
+// MockReportRepository implements report repository.Repository
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) GetPaidStatuses(workspaceID uint, months []types.YearMonth) ([]spend.MonthlyPaymentStatus, error) {
+	args := m.Called(workspaceID, months)
+	return args.Get(0).([]spend.MonthlyPaymentStatus), args.Error(1)
+}
+
+func (m *MockReportRepository) GetTransactions(workspaceID uint, ids []uint) ([]transaction.Transaction, error) {
+	args := m.Called(workspaceID, ids)
+	return args.Get(0).([]transaction.Transaction), args.Error(1)
+}
+
+func (m *MockReportRepository) GetSpecialCostExpenses(workspaceID uint, from, to time.Time) ([]settlement.Entry, error) {
+	args := m.Called(workspaceID, from, to)
+	return args.Get(0).([]settlement.Entry), args.Error(1)
+}
+
+// MockBudgetRepository implements budget repository.Repository
+type MockBudgetRepository struct {
+	mock.Mock
+}
+
+func (m *MockBudgetRepository) GetBudgets(workspaceID uint) ([]budget.Budget, error) {
+	args := m.Called(workspaceID)
+	return args.Get(0).([]budget.Budget), args.Error(1)
+}
+
+func (m *MockBudgetRepository) GetBudget(id uint, workspaceID uint) (*budget.Budget, error) {
+	args := m.Called(id, workspaceID)
+	return args.Get(0).(*budget.Budget), args.Error(1)
+}
+
+func (m *MockBudgetRepository) SaveBudget(b *budget.Budget) error {
+	return m.Called(b).Error(0)
+}
+
+func (m *MockBudgetRepository) DeleteBudget(id uint, workspaceID uint) error {
+	return m.Called(id, workspaceID).Error(0)
+}
+
+func (m *MockBudgetRepository) ListEntries(workspaceID uint, from, to time.Time) ([]budget.Entry, error) {
+	args := m.Called(workspaceID, from, to)
+	return args.Get(0).([]budget.Entry), args.Error(1)
+}
+
+func (m *MockBudgetRepository) GetEntry(id uint, workspaceID uint) (*budget.Entry, error) {
+	args := m.Called(id, workspaceID)
+	return args.Get(0).(*budget.Entry), args.Error(1)
+}
+
+func (m *MockBudgetRepository) CreateEntry(entry *budget.Entry) error {
+	return m.Called(entry).Error(0)
+}
+
+func (m *MockBudgetRepository) DeleteEntry(id uint, workspaceID uint) error {
+	return m.Called(id, workspaceID).Error(0)
+}
+
+func (m *MockBudgetRepository) GetTransaction(id uint, workspaceID uint) (*transaction.Transaction, error) {
+	args := m.Called(id, workspaceID)
+	return args.Get(0).(*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockBudgetRepository) IsTransactionBooked(workspaceID uint, transactionID uint) (bool, error) {
+	args := m.Called(workspaceID, transactionID)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestPlanVsActual(t *testing.T) {
+	mockRepo := new(MockReportRepository)
+	mockBudgets := new(MockBudgetRepository)
+
+	housing, food, travel := uint(1), uint(2), uint(3)
+	transactionID := uint(40)
+	costs := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: 1, BaseCurrency: "EUR"}},
+		Categories: []cost.Category{
+			{ID: 1, WorkspaceID: 1, Name: "Housing"},
+			{ID: 2, WorkspaceID: 1, Name: "Food"},
+			{ID: 3, WorkspaceID: 1, Name: "Travel"},
+		},
+		FixedCosts: []cost.FixedCost{
+			{ID: 1, WorkspaceID: 1, Name: "Rent", Amount: -1000, DueMonth: cost.ALL_MONTHS, CategoryID: &housing},
+			{ID: 2, WorkspaceID: 1, Name: "Electricity", Amount: -80, DueMonth: cost.ALL_MONTHS, CategoryID: &housing},
+			{ID: 3, WorkspaceID: 1, Name: "Savings plan", Amount: -300, DueMonth: cost.ALL_MONTHS, TargetAccountID: &housing},
+		},
+		SpecialCosts: []cost.SpecialCost{
+			{ID: 7, WorkspaceID: 1, Name: "Holiday", Amount: -600, DueDate: &types.YearMonth{Year: 2025, Month: 2}, CategoryID: &travel},
+		},
+	}
+	svc := service.NewReportService(mockRepo, mockBudgets, costs, costs)
+
+	january := types.YearMonth{Year: 2025, Month: 1}
+	february := types.YearMonth{Year: 2025, Month: 2}
+	months := []types.YearMonth{january, february}
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC)
+
+	mockBudgets.On("GetBudgets", uint(1)).Return([]budget.Budget{
+		{CategoryID: food, Amount: 400, ValidFrom: january, Rollover: budget.RolloverNone},
+	}, nil)
+	mockRepo.On("GetPaidStatuses", uint(1), months).Return([]spend.MonthlyPaymentStatus{
+		{FixedCostID: 1, Month: january, IsPaid: true},
+		{FixedCostID: 2, Month: january, IsPaid: true, TransactionID: &transactionID},
+		{FixedCostID: 3, Month: january, IsPaid: true},
+	}, nil)
+	mockRepo.On("GetTransactions", uint(1), []uint{transactionID}).Return([]transaction.Transaction{
+		{ID: transactionID, BookingDate: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), AmountCents: -9550},
+	}, nil)
+	mockBudgets.On("ListEntries", uint(1), from, to).Return([]budget.Entry{
+		{CategoryID: food, Date: time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC), Amount: -430},
+	}, nil)
+	costID := uint(7)
+	mockRepo.On("GetSpecialCostExpenses", uint(1), from, to).Return([]settlement.Entry{
+		{Kind: settlement.KindExpense, AmountCents: 55000, Date: time.Date(2025, 2, 14, 0, 0, 0, 0, time.UTC), CostType: settlement.CostSpecial, CostID: &costID},
+	}, nil)
+
+	result, err := svc.PlanVsActual(1, 2025, february)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", result.Currency)
+	assert.Len(t, result.Months, 2)
+
+	// January: rent as planned, electricity as paid by the bank, food as
+	// booked against the budget; the savings plan is a transfer
+	jan := result.Months[0]
+	assert.True(t, jan.Tracked)
+	assert.Equal(t, -1480, jan.Planned)
+	assert.Equal(t, -1526, jan.Actual)
+	assert.Equal(t, []report.CategoryVariance{
+		{CategoryID: &food, Name: "Food", Planned: -400, Actual: -430, Variance: -30},
+		{CategoryID: &housing, Name: "Housing", Planned: -1080, Actual: -1096, Variance: -16},
+	}, jan.Categories)
+
+	// February: only the holiday was paid, cheaper than planned
+	feb := result.Months[1]
+	assert.True(t, feb.Tracked)
+	assert.Equal(t, -2080, feb.Planned)
+	assert.Equal(t, -550, feb.Actual)
+
+	assert.Equal(t, -3560, result.YearToDate.Planned)
+	assert.Equal(t, -2076, result.YearToDate.Actual)
+	assert.Equal(t, 1484, result.YearToDate.Variance)
+
+	mockRepo.AssertExpectations(t)
+	mockBudgets.AssertExpectations(t)
+}
+
+func TestPlanVsActual_FutureYear(t *testing.T) {
+	mockRepo := new(MockReportRepository)
+	mockBudgets := new(MockBudgetRepository)
+	costs := &storage.MockRepository{}
+	svc := service.NewReportService(mockRepo, mockBudgets, costs, costs)
+
+	mockBudgets.On("GetBudgets", uint(1)).Return([]budget.Budget{}, nil)
+
+	result, err := svc.PlanVsActual(1, 2026, types.YearMonth{Year: 2025, Month: 6})
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Months)
+	mockRepo.AssertNotCalled(t, "GetPaidStatuses", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	cost_repo "wondee/finance-app-backend/internal/cost/repository"
+	overview_api "wondee/finance-app-backend/internal/overview/api"
+	"wondee/finance-app-backend/internal/overview/model"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/scenario"
+	"wondee/finance-app-backend/internal/storage"
+	"wondee/finance-app-backend/internal/wealth"
+	wealth_service "wondee/finance-app-backend/internal/wealth/service"
+)
+
+// Evaluation is the plan as the overview, surplus statistics and wealth
+// forecast show it. Forecast is nil as long as there is no wealth profile.
+type Evaluation struct {
+	Overview overview_api.Overview    `json:"overview"`
+	Surplus  model.SurplusStatistics  `json:"surplus"`
+	Forecast *wealth.ForecastResponse `json:"forecast"`
+}
+
+// Comparison sets a scenario against the real plan.
+type Comparison struct {
+	Baseline Evaluation `json:"baseline"`
+	Scenario Evaluation `json:"scenario"`
+	Delta    Delta      `json:"delta"`
+}
+
+// Delta reports by how much the scenario changes the plan; positive values
+// mean more money with the scenario.
+type Delta struct {
+	Months         []MonthDelta    `json:"months"`
+	EndAmount      int             `json:"endAmount"`
+	LowestAmount   int             `json:"lowestAmount"`
+	MonthlySurplus float64         `json:"monthlySurplus"`
+	Forecast       []ForecastDelta `json:"forecast"`
+}
+
+type MonthDelta struct {
+	YearMonth  types.YearMonth `json:"yearMonth"`
+	Baseline   int             `json:"baseline"`
+	Scenario   int             `json:"scenario"`
+	Difference int             `json:"difference"`
+}
+
+type ForecastDelta struct {
+	Year    int     `json:"year"`
+	Worst   float64 `json:"worst"`
+	Average float64 `json:"average"`
+	Best    float64 `json:"best"`
+}
+
+// GetEvaluation evaluates the plan with the scenario over the horizon given
+// like for the overview.
+func (h *Handler) GetEvaluation(c *gin.Context) {
+	horizon, err := overview_api.ParseHorizon(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	s, ok := h.loadScenario(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, h.evaluate(s.WorkspaceID, h.getUserID(c), s, horizon))
+}
+
+// GetComparison evaluates the real plan and the plan with the scenario and
+// reports the difference.
+func (h *Handler) GetComparison(c *gin.Context) {
+	horizon, err := overview_api.ParseHorizon(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	s, ok := h.loadScenario(c)
+	if !ok {
+		return
+	}
+
+	userID := h.getUserID(c)
+	baseline := h.evaluate(s.WorkspaceID, userID, nil, horizon)
+	withScenario := h.evaluate(s.WorkspaceID, userID, s, horizon)
+
+	c.JSON(http.StatusOK, Comparison{
+		Baseline: baseline,
+		Scenario: withScenario,
+		Delta:    compare(baseline, withScenario),
+	})
+}
+
+// evaluate runs the calculations of the overview and wealth endpoints, on
+// the real data or, with a scenario, on top of its changes.
+func (h *Handler) evaluate(workspaceID, userID uint, s *scenario.Scenario, horizon overview_api.Horizon) Evaluation {
+	var settings storage.Repository = h.settings
+	var costRepo cost_repo.Repository = h.costRepo
+	if s != nil {
+		settings = &settingsOverlay{Repository: h.settings, scenario: s}
+		costRepo = &costOverlay{Repository: h.costRepo, scenario: s}
+	}
+
+	overview := &overview_api.Handler{Repo: settings, CostRepo: costRepo}
+	evaluation := Evaluation{
+		Overview: overview.CreateOverview(workspaceID, horizon),
+		Surplus:  overview.CalculateSurplusStatistics(types.CurrentYearMonth(), workspaceID),
+	}
+
+	if forecast, err := wealth_service.NewForecastService(settings, costRepo).CalculateForecast(userID, workspaceID); err == nil {
+		evaluation.Forecast = forecast
+	}
+
+	return evaluation
+}
+
+// compare computes the difference between both evaluations month by month
+// and year by year.
+func compare(baseline, withScenario Evaluation) Delta {
+	delta := Delta{
+		Months:         make([]MonthDelta, 0, len(withScenario.Overview.Entries)),
+		MonthlySurplus: withScenario.Surplus.CurrentSurplus - baseline.Surplus.CurrentSurplus,
+		Forecast:       make([]ForecastDelta, 0),
+	}
+
+	// Both overviews cover the same months
+	entries := baseline.Overview.Entries
+	for i, entry := range withScenario.Overview.Entries {
+		if i >= len(entries) {
+			break
+		}
+		delta.Months = append(delta.Months, MonthDelta{
+			YearMonth:  entry.YearMonth,
+			Baseline:   entries[i].CurrentAmount,
+			Scenario:   entry.CurrentAmount,
+			Difference: entry.CurrentAmount - entries[i].CurrentAmount,
+		})
+	}
+
+	if n := len(delta.Months); n > 0 {
+		delta.EndAmount = delta.Months[n-1].Difference
+		delta.LowestAmount = lowest(withScenario.Overview.Entries) - lowest(baseline.Overview.Entries)
+	}
+
+	if baseline.Forecast != nil && withScenario.Forecast != nil {
+		points := baseline.Forecast.Points
+		for i, point := range withScenario.Forecast.Points {
+			if i >= len(points) {
+				break
+			}
+			delta.Forecast = append(delta.Forecast, ForecastDelta{
+				Year:    point.Year,
+				Worst:   point.Worst - points[i].Worst,
+				Average: point.Average - points[i].Average,
+				Best:    point.Best - points[i].Best,
+			})
+		}
+	}
+
+	return delta
+}
+
+// lowest returns the lowest projected balance of the entries.
+func lowest(entries []overview_api.OverviewEntry) int {
+	result := 0
+	for i, entry := range entries {
+		if i == 0 || entry.CurrentAmount < result {
+			result = entry.CurrentAmount
+		}
+	}
+	return result
+}
@@ -0,0 +1,539 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"wondee/finance-app-backend/internal/audit"
+	"wondee/finance-app-backend/internal/cost"
+	cost_api "wondee/finance-app-backend/internal/cost/api"
+	cost_repo "wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/scenario"
+	"wondee/finance-app-backend/internal/scenario/repository"
+	"wondee/finance-app-backend/internal/storage"
+	wealth_service "wondee/finance-app-backend/internal/wealth/service"
+)
+
+// Handler handles HTTP requests for what-if scenarios
+type Handler struct {
+	repo     repository.Repository
+	settings storage.Repository
+	costRepo cost_repo.Repository
+	audit    *audit.Log
+	balance  cost_api.BalanceMonitor
+}
+
+// NewHandler creates a new Handler instance; changes made by applying a
+// scenario are recorded in auditLog and watched by balance, both may be nil.
+func NewHandler(repo repository.Repository, settings storage.Repository, costRepo cost_repo.Repository, auditLog *audit.Log, balance cost_api.BalanceMonitor) *Handler {
+	return &Handler{
+		repo:     repo,
+		settings: settings,
+		costRepo: costRepo,
+		audit:    auditLog,
+		balance:  balance,
+	}
+}
+
+// Request and response types
+
+type ScenarioRequest struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+type ScenarioDTO struct {
+	ID          uint        `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	CreatedBy   uint        `json:"createdBy"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	AppliedAt   *time.Time  `json:"appliedAt"`
+	Changes     []ChangeDTO `json:"changes"`
+}
+
+// ChangeDTO describes a change; depending on the target fixedCost,
+// specialCost or wealthProfile is set. Costs are given like to the cost
+// endpoints, removed costs only by costId.
+type ChangeDTO struct {
+	ID            uint                      `json:"id"`
+	Target        string                    `json:"target" binding:"required"`
+	Action        string                    `json:"action" binding:"required"`
+	CostID        int                       `json:"costId"`
+	FixedCost     *cost_api.JsonFixedCost   `json:"fixedCost,omitempty"`
+	SpecialCost   *cost_api.JsonSpecialCost `json:"specialCost,omitempty"`
+	WealthProfile *scenario.ProfileChange   `json:"wealthProfile,omitempty"`
+}
+
+// GetScenarios lists the scenarios of the workspace, newest first.
+func (h *Handler) GetScenarios(c *gin.Context) {
+	scenarios, err := h.repo.GetScenarios(h.getWorkspaceID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load scenarios"})
+		return
+	}
+
+	result := make([]ScenarioDTO, 0, len(scenarios))
+	for i := range scenarios {
+		result = append(result, ToScenarioDTO(&scenarios[i]))
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetScenario returns a scenario with its changes.
+func (h *Handler) GetScenario(c *gin.Context) {
+	s, ok := h.loadScenario(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, ToScenarioDTO(s))
+}
+
+// SaveScenario creates a scenario or renames an existing one.
+func (h *Handler) SaveScenario(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	var req ScenarioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+
+	s := &scenario.Scenario{WorkspaceID: workspaceID, CreatedBy: h.getUserID(c)}
+	if req.ID != 0 {
+		existing, err := h.repo.GetScenario(req.ID, workspaceID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Scenario not found"})
+			return
+		}
+		s = existing
+	}
+	s.Name = name
+	s.Description = req.Description
+
+	if err := h.repo.SaveScenario(s); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save scenario"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ToScenarioDTO(s))
+}
+
+// DeleteScenario discards a scenario; the real data is not affected.
+func (h *Handler) DeleteScenario(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scenario ID"})
+		return
+	}
+
+	if err := h.repo.DeleteScenario(uint(id), h.getWorkspaceID(c)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Scenario not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete scenario"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddChange adds, modifies or removes a cost in the scenario, or overrides
+// parameters of the wealth profile. Costs are checked like when they are
+// saved for real.
+func (h *Handler) AddChange(c *gin.Context) {
+	s, ok := h.loadScenario(c)
+	if !ok {
+		return
+	}
+	if s.IsApplied() {
+		c.JSON(http.StatusConflict, gin.H{"error": "scenario has already been applied"})
+		return
+	}
+
+	var req ChangeDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	change, err := h.toChange(s.WorkspaceID, &req)
+	if err == nil {
+		err = h.checkChange(s.WorkspaceID, change)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	change.ScenarioID = s.ID
+	if err := h.repo.AddChange(change); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save change"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ToChangeDTO(change))
+}
+
+// DeleteChange takes a change back out of the scenario.
+func (h *Handler) DeleteChange(c *gin.Context) {
+	s, ok := h.loadScenario(c)
+	if !ok {
+		return
+	}
+	if s.IsApplied() {
+		c.JSON(http.StatusConflict, gin.H{"error": "scenario has already been applied"})
+		return
+	}
+
+	changeID, err := strconv.ParseUint(c.Param("changeId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid change ID"})
+		return
+	}
+
+	if err := h.repo.DeleteChange(uint(changeID), s.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Change not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete change"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ApplyScenario carries the changes over to the real data. All changes are
+// checked first, so a scenario whose costs were deleted or whose categories
+// no longer exist is not applied at all. Removed costs go to the trash.
+func (h *Handler) ApplyScenario(c *gin.Context) {
+	s, ok := h.loadScenario(c)
+	if !ok {
+		return
+	}
+	if s.IsApplied() {
+		c.JSON(http.StatusConflict, gin.H{"error": "scenario has already been applied"})
+		return
+	}
+
+	for i := range s.Changes {
+		if err := h.checkChange(s.WorkspaceID, &s.Changes[i]); err != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	actor := h.getUserID(c)
+	notify := cost_api.WatchBalance(h.balance, s.WorkspaceID)
+	records := make([]audit.Change, 0, len(s.Changes))
+
+	now := time.Now()
+	err := h.repo.Apply(s.ID, s.WorkspaceID, now, func(costRepo cost_repo.Repository, settings storage.Repository) error {
+		for i := range s.Changes {
+			record, err := apply(costRepo, settings, s.WorkspaceID, actor, &s.Changes[i])
+			if err != nil {
+				return err
+			}
+			records = append(records, record)
+		}
+		return nil
+	})
+	if errors.Is(err, repository.ErrAlreadyApplied) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply scenario"})
+		return
+	}
+	s.AppliedAt = &now
+
+	// Only changes that were stored are recorded
+	for _, record := range records {
+		h.audit.Record(record)
+	}
+	notify(actor, s.Name)
+
+	c.JSON(http.StatusOK, ToScenarioDTO(s))
+}
+
+// toChange converts the request with the conversions of the cost endpoints.
+func (h *Handler) toChange(workspaceID uint, req *ChangeDTO) (*scenario.Change, error) {
+	var payload interface{}
+	if req.Action != scenario.ActionRemove {
+		switch req.Target {
+		case scenario.TargetFixedCost:
+			if req.FixedCost == nil {
+				return nil, errors.New("fixedCost is required")
+			}
+			fc, err := cost_api.ToDBStructWithRecurrence(req.FixedCost)
+			if err != nil {
+				return nil, err
+			}
+			fc.ID, fc.WorkspaceID = 0, workspaceID
+			payload = fc
+		case scenario.TargetSpecialCost:
+			if req.SpecialCost == nil {
+				return nil, errors.New("specialCost is required")
+			}
+			sc, err := cost_api.ToDBSpecialCost(req.SpecialCost)
+			if err != nil {
+				return nil, err
+			}
+			sc.ID, sc.WorkspaceID = 0, workspaceID
+			payload = sc
+		case scenario.TargetWealthProfile:
+			if req.WealthProfile == nil {
+				return nil, errors.New("wealthProfile is required")
+			}
+			payload = req.WealthProfile
+		}
+	}
+
+	return scenario.NewChange(req.Target, req.Action, req.CostID, payload)
+}
+
+// checkChange verifies that a change can be applied to the current data.
+func (h *Handler) checkChange(workspaceID uint, change *scenario.Change) error {
+	switch change.Target {
+	case scenario.TargetFixedCost:
+		if change.Action != scenario.ActionAdd {
+			if _, err := h.costRepo.GetFixedCost(change.CostID, workspaceID); err != nil {
+				return errors.New("fixed cost not found")
+			}
+		}
+		if change.Action == scenario.ActionRemove {
+			return nil
+		}
+		fc, err := change.FixedCost()
+		if err != nil {
+			return err
+		}
+		return cost_api.ValidateAssignments(h.costRepo, h.settings, workspaceID, fc.CategoryID, fc.AccountID, fc.TargetAccountID, fc.Split)
+
+	case scenario.TargetSpecialCost:
+		if change.Action != scenario.ActionAdd && findSpecialCost(h.costRepo, change.CostID, workspaceID) == nil {
+			return errors.New("special cost not found")
+		}
+		if change.Action == scenario.ActionRemove {
+			return nil
+		}
+		sc, err := change.SpecialCost()
+		if err != nil {
+			return err
+		}
+		return cost_api.ValidateAssignments(h.costRepo, h.settings, workspaceID, sc.CategoryID, sc.AccountID, sc.TargetAccountID, sc.Split)
+
+	case scenario.TargetWealthProfile:
+		p, err := change.Profile()
+		if err != nil {
+			return err
+		}
+		profile, err := wealth_service.NewProfileService(h.settings).GetProfile(workspaceID)
+		if err != nil {
+			return err
+		}
+		p.ApplyTo(profile)
+		return wealth_service.ValidateProfile(profile)
+	}
+
+	return change.Validate()
+}
+
+// apply carries a checked change over to the real data and returns the
+// entry for the audit log, like the cost and profile endpoints record it.
+func apply(costRepo cost_repo.Repository, settings storage.Repository, workspaceID, actor uint, change *scenario.Change) (audit.Change, error) {
+	record := audit.Change{WorkspaceID: workspaceID, Actor: actor, EntityID: uint(change.CostID)}
+
+	switch change.Target {
+	case scenario.TargetFixedCost:
+		record.EntityType = audit.EntityFixedCost
+		before, _ := costRepo.GetFixedCost(change.CostID, workspaceID)
+		if before != nil {
+			record.Before = cost_api.ToJsonStruct(before)
+		}
+
+		if change.Action == scenario.ActionRemove {
+			if err := costRepo.DeleteFixedCost(change.CostID, workspaceID); err != nil {
+				return record, err
+			}
+			record.Action = audit.ActionDelete
+			break
+		}
+
+		fc, err := change.FixedCost()
+		if err != nil {
+			return record, err
+		}
+		fc.ID, fc.WorkspaceID, fc.UserID = change.CostID, workspaceID, actor
+		if err := costRepo.SaveFixedObject(fc); err != nil {
+			return record, err
+		}
+		record.EntityID = uint(fc.ID)
+		record.After = cost_api.ToJsonStruct(fc)
+
+	case scenario.TargetSpecialCost:
+		record.EntityType = audit.EntitySpecialCost
+		if before := findSpecialCost(costRepo, change.CostID, workspaceID); before != nil {
+			record.Before = cost_api.ToJsonSpecialCost(before)
+		}
+
+		if change.Action == scenario.ActionRemove {
+			if err := costRepo.DeleteSpecialCost(change.CostID, workspaceID); err != nil {
+				return record, err
+			}
+			record.Action = audit.ActionDelete
+			break
+		}
+
+		sc, err := change.SpecialCost()
+		if err != nil {
+			return record, err
+		}
+		sc.ID, sc.WorkspaceID, sc.UserID = change.CostID, workspaceID, actor
+		if err := costRepo.SaveSpecialCost(sc); err != nil {
+			return record, err
+		}
+		record.EntityID = uint(sc.ID)
+		record.After = cost_api.ToJsonSpecialCost(sc)
+
+	case scenario.TargetWealthProfile:
+		p, err := change.Profile()
+		if err != nil {
+			return record, err
+		}
+		profiles := wealth_service.NewProfileService(settings)
+		profile, err := profiles.GetProfile(workspaceID)
+		if err != nil {
+			return record, err
+		}
+		if profile.ID != 0 {
+			record.Before = *profile
+		}
+
+		p.ApplyTo(profile)
+		profile.UserID, profile.WorkspaceID = actor, workspaceID
+		if err := profiles.UpdateProfile(profile); err != nil {
+			return record, err
+		}
+		record.EntityType = audit.EntityWealthProfile
+		record.EntityID = profile.ID
+		record.After = profile
+	}
+
+	if record.Action == "" {
+		record.Action = audit.ActionCreate
+		if record.Before != nil {
+			record.Action = audit.ActionUpdate
+		}
+	}
+	return record, nil
+}
+
+// loadScenario loads the scenario given by the id parameter and answers the
+// request if it does not exist.
+func (h *Handler) loadScenario(c *gin.Context) (*scenario.Scenario, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scenario ID"})
+		return nil, false
+	}
+
+	s, err := h.repo.GetScenario(uint(id), h.getWorkspaceID(c))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Scenario not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load scenario"})
+		return nil, false
+	}
+	return s, true
+}
+
+// findSpecialCost returns the stored cost; nil if it does not exist.
+func findSpecialCost(costRepo cost_repo.Repository, id int, workspaceID uint) *cost.SpecialCost {
+	for _, sc := range *costRepo.LoadSpecialCosts(workspaceID) {
+		if sc.ID == id {
+			return &sc
+		}
+	}
+	return nil
+}
+
+func ToScenarioDTO(s *scenario.Scenario) ScenarioDTO {
+	changes := make([]ChangeDTO, 0, len(s.Changes))
+	for i := range s.Changes {
+		changes = append(changes, ToChangeDTO(&s.Changes[i]))
+	}
+
+	return ScenarioDTO{
+		ID:          s.ID,
+		Name:        s.Name,
+		Description: s.Description,
+		CreatedBy:   s.CreatedBy,
+		CreatedAt:   s.CreatedAt,
+		AppliedAt:   s.AppliedAt,
+		Changes:     changes,
+	}
+}
+
+func ToChangeDTO(change *scenario.Change) ChangeDTO {
+	dto := ChangeDTO{
+		ID:     change.ID,
+		Target: change.Target,
+		Action: change.Action,
+		CostID: change.CostID,
+	}
+	if change.Action == scenario.ActionRemove {
+		return dto
+	}
+
+	switch change.Target {
+	case scenario.TargetFixedCost:
+		if fc, err := change.FixedCost(); err == nil {
+			jsonCost := cost_api.ToJsonStruct(fc)
+			dto.FixedCost = &jsonCost
+		}
+	case scenario.TargetSpecialCost:
+		if sc, err := change.SpecialCost(); err == nil {
+			jsonCost := cost_api.ToJsonSpecialCost(sc)
+			dto.SpecialCost = &jsonCost
+		}
+	case scenario.TargetWealthProfile:
+		if p, err := change.Profile(); err == nil {
+			dto.WealthProfile = p
+		}
+	}
+	return dto
+}
+
+func (h *Handler) getWorkspaceID(c *gin.Context) uint {
+	workspaceID, exists := c.Get("workspace_id")
+	if !exists {
+		return 0
+	}
+	return workspaceID.(uint)
+}
+
+func (h *Handler) getUserID(c *gin.Context) uint {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return 0
+	}
+	return userID.(uint)
+}
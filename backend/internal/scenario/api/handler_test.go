@@ -0,0 +1,316 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"wondee/finance-app-backend/internal/cost"
+	cost_repo "wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/scenario"
+	"wondee/finance-app-backend/internal/scenario/repository"
+	"wondee/finance-app-backend/internal/storage"
+	"wondee/finance-app-backend/internal/wealth"
+	"wondee/finance-app-backend/internal/workspace"
+)
+
+// MockScenarioRepository implements scenario repository.Repository. Apply
+// runs on data, using costs as cost repository.
+type MockScenarioRepository struct {
+	mock.Mock
+	data  *storage.MockRepository
+	costs cost_repo.Repository
+}
+
+func (m *MockScenarioRepository) GetScenarios(workspaceID uint) ([]scenario.Scenario, error) {
+	args := m.Called(workspaceID)
+	return args.Get(0).([]scenario.Scenario), args.Error(1)
+}
+
+func (m *MockScenarioRepository) GetScenario(id uint, workspaceID uint) (*scenario.Scenario, error) {
+	args := m.Called(id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*scenario.Scenario), args.Error(1)
+}
+
+func (m *MockScenarioRepository) SaveScenario(s *scenario.Scenario) error {
+	args := m.Called(s)
+	return args.Error(0)
+}
+
+func (m *MockScenarioRepository) DeleteScenario(id uint, workspaceID uint) error {
+	args := m.Called(id, workspaceID)
+	return args.Error(0)
+}
+
+func (m *MockScenarioRepository) AddChange(change *scenario.Change) error {
+	args := m.Called(change)
+	return args.Error(0)
+}
+
+func (m *MockScenarioRepository) DeleteChange(id uint, scenarioID uint) error {
+	args := m.Called(id, scenarioID)
+	return args.Error(0)
+}
+
+// Apply resets the data if apply fails, like a rolled back transaction.
+func (m *MockScenarioRepository) Apply(id uint, workspaceID uint, at time.Time, apply repository.ApplyFunc) error {
+	args := m.Called(id, workspaceID, at)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+
+	fixedCosts := slices.Clone(m.data.FixedCosts)
+	specialCosts := slices.Clone(m.data.SpecialCosts)
+	profiles := slices.Clone(m.data.WealthProfiles)
+	if err := apply(m.costs, m.data); err != nil {
+		m.data.FixedCosts, m.data.SpecialCosts, m.data.WealthProfiles = fixedCosts, specialCosts, profiles
+		return err
+	}
+	return nil
+}
+
+// failingCostRepository cannot delete special costs
+type failingCostRepository struct {
+	*storage.MockRepository
+}
+
+func (r failingCostRepository) DeleteSpecialCost(id int, workspaceID uint) error {
+	return errors.New("connection lost")
+}
+
+func newMockData() *storage.MockRepository {
+	return &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: 1, CurrentAmount: 1000}},
+		FixedCosts: []cost.FixedCost{
+			{ID: 1, WorkspaceID: 1, Name: "Salary", Amount: 500, DueMonth: cost.ALL_MONTHS},
+		},
+		SpecialCosts: []cost.SpecialCost{
+			{ID: 2, WorkspaceID: 1, Name: "Holiday", Amount: -800, DueDate: types.AddMonths(types.CurrentYearMonth(), 2)},
+		},
+		WealthProfiles: []wealth.WealthProfile{
+			{ID: 1, WorkspaceID: 1, CurrentWealth: 10000, ForecastDurationYears: 2},
+		},
+	}
+}
+
+func setupTestRouter(mockRepo *MockScenarioRepository, data *storage.MockRepository) *gin.Engine {
+	mockRepo.data = data
+	if mockRepo.costs == nil {
+		mockRepo.costs = data
+	}
+	handler := NewHandler(mockRepo, data, data, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+	router.POST("/scenarios/:id/changes", handler.AddChange)
+	router.GET("/scenarios/:id/comparison", handler.GetComparison)
+	router.POST("/scenarios/:id/apply", handler.ApplyScenario)
+	return router
+}
+
+func request(router *gin.Engine, method, path string, body interface{}) *httptest.ResponseRecorder {
+	var data []byte
+	if body != nil {
+		data, _ = json.Marshal(body)
+	}
+	req, _ := http.NewRequest(method, path, bytes.NewBuffer(data))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func testScenario(t *testing.T) *scenario.Scenario {
+	leasing, err := scenario.NewChange(scenario.TargetFixedCost, scenario.ActionAdd, 0, &cost.FixedCost{
+		WorkspaceID: 1, Name: "Leasing", Amount: -200,
+		Recurrence: cost.Recurrence{Frequency: cost.FrequencyMonthly, Interval: 1},
+	})
+	assert.NoError(t, err)
+	leasing.ID = 1
+
+	holiday, err := scenario.NewChange(scenario.TargetSpecialCost, scenario.ActionRemove, 2, nil)
+	assert.NoError(t, err)
+	holiday.ID = 2
+
+	wealthAmount := 15000.0
+	profile, err := scenario.NewChange(scenario.TargetWealthProfile, scenario.ActionModify, 0, &scenario.ProfileChange{CurrentWealth: &wealthAmount})
+	assert.NoError(t, err)
+	profile.ID = 3
+
+	return &scenario.Scenario{ID: 5, WorkspaceID: 1, Name: "Neues Auto", Changes: []scenario.Change{*leasing, *holiday, *profile}}
+}
+
+func TestAddChange(t *testing.T) {
+	mockRepo := new(MockScenarioRepository)
+	data := newMockData()
+	router := setupTestRouter(mockRepo, data)
+
+	mockRepo.On("GetScenario", uint(5), uint(1)).Return(&scenario.Scenario{ID: 5, WorkspaceID: 1}, nil)
+	mockRepo.On("AddChange", mock.MatchedBy(func(change *scenario.Change) bool {
+		fc, err := change.FixedCost()
+		return err == nil && change.ScenarioID == 5 && change.Target == scenario.TargetFixedCost &&
+			change.Action == scenario.ActionModify && change.CostID == 1 && fc.Amount == 650
+	})).Return(nil)
+
+	w := request(router, http.MethodPost, "/scenarios/5/changes", map[string]interface{}{
+		"target": "fixedCost",
+		"action": "modify",
+		"costId": 1,
+		"fixedCost": map[string]interface{}{
+			"name":       "Salary",
+			"amount":     650,
+			"recurrence": map[string]interface{}{"frequency": "MONTHLY", "interval": 1},
+		},
+	})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockRepo.AssertExpectations(t)
+	// The real cost is untouched
+	assert.Equal(t, 500, data.FixedCosts[0].Amount)
+}
+
+func TestAddChange_UnknownCost(t *testing.T) {
+	mockRepo := new(MockScenarioRepository)
+	router := setupTestRouter(mockRepo, newMockData())
+
+	mockRepo.On("GetScenario", uint(5), uint(1)).Return(&scenario.Scenario{ID: 5, WorkspaceID: 1}, nil)
+
+	w := request(router, http.MethodPost, "/scenarios/5/changes", map[string]interface{}{
+		"target": "specialCost",
+		"action": "remove",
+		"costId": 42,
+	})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockRepo.AssertNotCalled(t, "AddChange", mock.Anything)
+}
+
+func TestGetComparison(t *testing.T) {
+	mockRepo := new(MockScenarioRepository)
+	data := newMockData()
+	router := setupTestRouter(mockRepo, data)
+
+	mockRepo.On("GetScenario", uint(5), uint(1)).Return(testScenario(t), nil)
+
+	w := request(router, http.MethodGet, "/scenarios/5/comparison?months=4", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var result Comparison
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+
+	// Leasing costs 200 a month, the holiday of 800 in the third month is
+	// dropped
+	differences := make([]int, 0)
+	for _, month := range result.Delta.Months {
+		differences = append(differences, month.Difference)
+	}
+	assert.Equal(t, []int{-200, -400, 200, 0}, differences)
+	assert.Equal(t, 0, result.Delta.EndAmount)
+	assert.Equal(t, 1000+500*4-800, result.Baseline.Overview.Entries[3].CurrentAmount)
+	assert.Equal(t, -200, result.Delta.LowestAmount)
+	assert.Equal(t, -200.0, result.Delta.MonthlySurplus)
+
+	// The forecast starts from the wealth of the scenario
+	assert.Len(t, result.Delta.Forecast, 2)
+	assert.Equal(t, 5000.0, result.Delta.Forecast[0].Average)
+	assert.Equal(t, 15000.0, result.Scenario.Forecast.StartCapital)
+	assert.Equal(t, 10000.0, result.Baseline.Forecast.StartCapital)
+
+	// Nothing was changed for real
+	assert.Len(t, data.FixedCosts, 1)
+	assert.Len(t, data.SpecialCosts, 1)
+	assert.Equal(t, 10000.0, data.WealthProfiles[0].CurrentWealth)
+}
+
+func TestApplyScenario(t *testing.T) {
+	mockRepo := new(MockScenarioRepository)
+	data := newMockData()
+	router := setupTestRouter(mockRepo, data)
+
+	s := testScenario(t)
+	mockRepo.On("GetScenario", uint(5), uint(1)).Return(s, nil)
+	mockRepo.On("Apply", uint(5), uint(1), mock.Anything).Return(nil)
+
+	w := request(router, http.MethodPost, "/scenarios/5/apply", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockRepo.AssertExpectations(t)
+
+	assert.Len(t, data.FixedCosts, 2)
+	assert.Equal(t, "Leasing", data.FixedCosts[1].Name)
+	assert.Equal(t, uint(1), data.FixedCosts[1].UserID)
+	assert.Len(t, data.SpecialCosts, 0)
+	assert.Equal(t, 15000.0, data.WealthProfiles[0].CurrentWealth)
+	assert.True(t, s.IsApplied())
+
+	// An applied scenario cannot be applied again
+	w = request(router, http.MethodPost, "/scenarios/5/apply", nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Len(t, data.FixedCosts, 2)
+}
+
+func TestApplyScenario_CostDeletedMeanwhile(t *testing.T) {
+	mockRepo := new(MockScenarioRepository)
+	data := newMockData()
+	data.SpecialCosts = nil
+	router := setupTestRouter(mockRepo, data)
+
+	mockRepo.On("GetScenario", uint(5), uint(1)).Return(testScenario(t), nil)
+
+	w := request(router, http.MethodPost, "/scenarios/5/apply", nil)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockRepo.AssertNotCalled(t, "Apply", mock.Anything, mock.Anything, mock.Anything)
+	// Nothing is applied, not even the changes before the failing one
+	assert.Len(t, data.FixedCosts, 1)
+}
+
+func TestApplyScenario_RepositoryError(t *testing.T) {
+	data := newMockData()
+	mockRepo := &MockScenarioRepository{costs: failingCostRepository{data}}
+	router := setupTestRouter(mockRepo, data)
+
+	s := testScenario(t)
+	mockRepo.On("GetScenario", uint(5), uint(1)).Return(s, nil)
+	mockRepo.On("Apply", uint(5), uint(1), mock.Anything).Return(nil)
+
+	w := request(router, http.MethodPost, "/scenarios/5/apply", nil)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.False(t, s.IsApplied())
+	// The leasing added before the failing change is rolled back
+	assert.Len(t, data.FixedCosts, 1)
+	assert.Len(t, data.SpecialCosts, 1)
+	assert.Equal(t, 10000.0, data.WealthProfiles[0].CurrentWealth)
+}
+
+func TestApplyScenario_AppliedMeanwhile(t *testing.T) {
+	mockRepo := new(MockScenarioRepository)
+	data := newMockData()
+	router := setupTestRouter(mockRepo, data)
+
+	mockRepo.On("GetScenario", uint(5), uint(1)).Return(testScenario(t), nil)
+	mockRepo.On("Apply", uint(5), uint(1), mock.Anything).Return(repository.ErrAlreadyApplied)
+
+	w := request(router, http.MethodPost, "/scenarios/5/apply", nil)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Len(t, data.FixedCosts, 1)
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"errors"
+
+	"wondee/finance-app-backend/internal/cost"
+	cost_repo "wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/scenario"
+	"wondee/finance-app-backend/internal/storage"
+	"wondee/finance-app-backend/internal/wealth"
+	wealth_service "wondee/finance-app-backend/internal/wealth/service"
+
+	"gorm.io/gorm"
+)
+
+// costOverlay shows the costs of a workspace as they would be with a
+// scenario, so the overview, surplus and forecast calculations run
+// unchanged on top of it. It is only meant for reading: everything else
+// goes to the real repository.
+type costOverlay struct {
+	cost_repo.Repository
+	scenario *scenario.Scenario
+}
+
+func (o *costOverlay) LoadFixedCosts(workspaceID uint) *[]cost.FixedCost {
+	var costs []cost.FixedCost
+	if loaded := o.Repository.LoadFixedCosts(workspaceID); loaded != nil {
+		costs = *loaded
+	}
+	result := o.scenario.FixedCosts(costs)
+	return &result
+}
+
+func (o *costOverlay) LoadSpecialCosts(workspaceID uint) *[]cost.SpecialCost {
+	var costs []cost.SpecialCost
+	if loaded := o.Repository.LoadSpecialCosts(workspaceID); loaded != nil {
+		costs = *loaded
+	}
+	result := o.scenario.SpecialCosts(costs)
+	return &result
+}
+
+// settingsOverlay replaces the wealth profile with the one of the scenario;
+// without a stored profile the scenario starts from the defaults.
+type settingsOverlay struct {
+	storage.Repository
+	scenario *scenario.Scenario
+}
+
+func (o *settingsOverlay) GetWealthProfile(workspaceID uint) (*wealth.WealthProfile, error) {
+	profile, err := o.Repository.GetWealthProfile(workspaceID)
+	if !o.scenario.ChangesProfile() {
+		return profile, err
+	}
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if profile, err = wealth_service.NewProfileService(o.Repository).GetProfile(workspaceID); err != nil {
+			return nil, err
+		}
+	}
+
+	changed := o.scenario.Profile(*profile)
+	return &changed, nil
+}
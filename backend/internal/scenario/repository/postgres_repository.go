@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"time"
+
+	cost_repo "wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/scenario"
+	"wondee/finance-app-backend/internal/storage"
+
+	"gorm.io/gorm"
+)
+
+func (r *PostgresRepository) GetScenarios(workspaceID uint) ([]scenario.Scenario, error) {
+	var scenarios []scenario.Scenario
+	result := r.DB.Where("workspace_id = ?", workspaceID).
+		Preload("Changes", orderChanges).
+		Order("created_at DESC, id DESC").
+		Find(&scenarios)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return scenarios, nil
+}
+
+func (r *PostgresRepository) GetScenario(id uint, workspaceID uint) (*scenario.Scenario, error) {
+	var s scenario.Scenario
+	result := r.DB.Where("id = ? AND workspace_id = ?", id, workspaceID).
+		Preload("Changes", orderChanges).
+		First(&s)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &s, nil
+}
+
+func (r *PostgresRepository) SaveScenario(s *scenario.Scenario) error {
+	return r.DB.Omit("Changes").Save(s).Error
+}
+
+func (r *PostgresRepository) DeleteScenario(id uint, workspaceID uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND workspace_id = ?", id, workspaceID).Delete(&scenario.Scenario{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("scenario_id = ?", id).Delete(&scenario.Change{}).Error
+	})
+}
+
+func (r *PostgresRepository) AddChange(change *scenario.Change) error {
+	return r.DB.Create(change).Error
+}
+
+func (r *PostgresRepository) DeleteChange(id uint, scenarioID uint) error {
+	result := r.DB.Where("id = ? AND scenario_id = ?", id, scenarioID).Delete(&scenario.Change{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) Apply(id uint, workspaceID uint, at time.Time, apply ApplyFunc) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&scenario.Scenario{}).
+			Where("id = ? AND workspace_id = ? AND applied_at IS NULL", id, workspaceID).
+			Update("applied_at", at)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrAlreadyApplied
+		}
+
+		return apply(&cost_repo.PostgresRepository{DB: tx}, &storage.GormRepository{DB: tx})
+	})
+}
+
+// orderChanges applies the changes in the order they were made
+func orderChanges(db *gorm.DB) *gorm.DB {
+	return db.Order("id ASC")
+}
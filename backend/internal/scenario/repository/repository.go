@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	cost_repo "wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/scenario"
+	"wondee/finance-app-backend/internal/storage"
+
+	"gorm.io/gorm"
+)
+
+// ErrAlreadyApplied is returned by Apply for scenarios applied meanwhile.
+var ErrAlreadyApplied = errors.New("scenario has already been applied")
+
+// ApplyFunc carries the changes of a scenario over to the real data using
+// the given repositories.
+type ApplyFunc func(costRepo cost_repo.Repository, settings storage.Repository) error
+
+// Repository defines the interface for scenario data access. Scenarios are
+// always loaded with their changes, oldest change first.
+type Repository interface {
+	GetScenarios(workspaceID uint) ([]scenario.Scenario, error)
+	GetScenario(id uint, workspaceID uint) (*scenario.Scenario, error)
+	// SaveScenario stores name and description; changes are added
+	// separately.
+	SaveScenario(s *scenario.Scenario) error
+	DeleteScenario(id uint, workspaceID uint) error
+
+	AddChange(change *scenario.Change) error
+	DeleteChange(id uint, scenarioID uint) error
+
+	// Apply runs apply with repositories bound to a single database
+	// transaction and marks the scenario applied at the given time in the
+	// same transaction. If apply fails or the scenario has been applied
+	// meanwhile, nothing is stored.
+	Apply(id uint, workspaceID uint, at time.Time, apply ApplyFunc) error
+}
+
+// PostgresRepository implements Repository using GORM
+type PostgresRepository struct {
+	DB *gorm.DB
+}
@@ -0,0 +1,284 @@
+package scenario
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/wealth"
+)
+
+// What a change applies to
+const (
+	TargetFixedCost     = "fixedCost"
+	TargetSpecialCost   = "specialCost"
+	TargetWealthProfile = "wealthProfile"
+)
+
+// Actions of a change
+const (
+	ActionAdd    = "add"
+	ActionModify = "modify"
+	ActionRemove = "remove"
+)
+
+// Scenario is a named set of changes laid over the plan of a workspace, e.g.
+// a new car lease or a move. The real data stays untouched until the
+// scenario is applied.
+type Scenario struct {
+	ID          uint   `gorm:"primaryKey"`
+	WorkspaceID uint   `gorm:"not null;index"`
+	Name        string `gorm:"size:100;not null"`
+	Description string `gorm:"type:text"`
+	CreatedBy   uint   `gorm:"not null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	// AppliedAt is set once the changes were carried over to the real data;
+	// applied scenarios can no longer be changed.
+	AppliedAt *time.Time
+
+	Changes []Change `gorm:"foreignKey:ScenarioID;constraint:OnDelete:CASCADE"`
+}
+
+// Change adds, modifies or removes a cost, or overrides parameters of the
+// wealth profile. CostID refers to the real cost modified or removed.
+// Payload holds the added or modified cost, or the ProfileChange, as JSON.
+type Change struct {
+	ID         uint   `gorm:"primaryKey"`
+	ScenarioID uint   `gorm:"not null;index"`
+	Target     string `gorm:"size:20;not null"`
+	Action     string `gorm:"size:20;not null"`
+	CostID     int
+	Payload    string `gorm:"type:text"`
+	CreatedAt  time.Time
+}
+
+// TableName specifies the table name for GORM
+func (Change) TableName() string {
+	return "scenario_changes"
+}
+
+// ProfileChange overrides the parameters of the wealth profile that are set.
+type ProfileChange struct {
+	CurrentWealth         *float64 `json:"current_wealth,omitempty"`
+	ForecastDurationYears *int     `json:"forecast_duration_years,omitempty"`
+	RateWorstCase         *float64 `json:"rate_worst_case,omitempty"`
+	RateAverageCase       *float64 `json:"rate_average_case,omitempty"`
+	RateBestCase          *float64 `json:"rate_best_case,omitempty"`
+}
+
+// ApplyTo overrides the parameters of profile.
+func (p *ProfileChange) ApplyTo(profile *wealth.WealthProfile) {
+	if p.CurrentWealth != nil {
+		profile.CurrentWealth = *p.CurrentWealth
+	}
+	if p.ForecastDurationYears != nil {
+		profile.ForecastDurationYears = *p.ForecastDurationYears
+	}
+	if p.RateWorstCase != nil {
+		profile.RateWorstCase = *p.RateWorstCase
+	}
+	if p.RateAverageCase != nil {
+		profile.RateAverageCase = *p.RateAverageCase
+	}
+	if p.RateBestCase != nil {
+		profile.RateBestCase = *p.RateBestCase
+	}
+}
+
+// NewChange creates a change of target; payload is nil for removals.
+func NewChange(target, action string, costID int, payload interface{}) (*Change, error) {
+	change := &Change{Target: target, Action: action, CostID: costID}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		change.Payload = string(data)
+	}
+
+	if err := change.Validate(); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// Validate checks that the action fits the target. The wealth profile can
+// only be modified; modified and removed costs need the ID of the real cost.
+func (c *Change) Validate() error {
+	switch c.Target {
+	case TargetFixedCost, TargetSpecialCost:
+	case TargetWealthProfile:
+		if c.Action != ActionModify {
+			return errors.New("the wealth profile can only be modified")
+		}
+		if c.Payload == "" {
+			return errors.New("a change of the wealth profile needs parameters")
+		}
+		return nil
+	default:
+		return errors.New("target must be fixedCost, specialCost or wealthProfile")
+	}
+
+	switch c.Action {
+	case ActionAdd:
+		if c.CostID != 0 {
+			return errors.New("an added cost cannot refer to an existing one")
+		}
+	case ActionModify, ActionRemove:
+		if c.CostID <= 0 {
+			return errors.New("costId is required to modify or remove a cost")
+		}
+	default:
+		return errors.New("action must be add, modify or remove")
+	}
+
+	if c.Action != ActionRemove && c.Payload == "" {
+		return errors.New("an added or modified cost is required")
+	}
+	return nil
+}
+
+// FixedCost decodes the added or modified fixed cost.
+func (c *Change) FixedCost() (*cost.FixedCost, error) {
+	var fc cost.FixedCost
+	if err := json.Unmarshal([]byte(c.Payload), &fc); err != nil {
+		return nil, err
+	}
+	return &fc, nil
+}
+
+// SpecialCost decodes the added or modified special cost.
+func (c *Change) SpecialCost() (*cost.SpecialCost, error) {
+	var sc cost.SpecialCost
+	if err := json.Unmarshal([]byte(c.Payload), &sc); err != nil {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+// Profile decodes the parameters of the wealth profile.
+func (c *Change) Profile() (*ProfileChange, error) {
+	var p ProfileChange
+	if err := json.Unmarshal([]byte(c.Payload), &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// IsApplied reports whether the scenario was carried over to the real data.
+func (s *Scenario) IsApplied() bool {
+	return s.AppliedAt != nil
+}
+
+// FixedCosts returns the fixed costs as they would be with the scenario.
+// Added costs get the negative ID of their change so they cannot be mistaken
+// for real ones; modified costs keep the amount revisions of the original.
+// Changes of costs that no longer exist are ignored.
+func (s *Scenario) FixedCosts(costs []cost.FixedCost) []cost.FixedCost {
+	result := make([]cost.FixedCost, len(costs))
+	copy(result, costs)
+
+	for _, change := range s.Changes {
+		if change.Target != TargetFixedCost {
+			continue
+		}
+
+		index := -1
+		for i := range result {
+			if result[i].ID == change.CostID {
+				index = i
+				break
+			}
+		}
+
+		switch change.Action {
+		case ActionAdd:
+			if fc, err := change.FixedCost(); err == nil {
+				fc.ID = -int(change.ID)
+				fc.WorkspaceID = s.WorkspaceID
+				result = append(result, *fc)
+			}
+		case ActionModify:
+			if fc, err := change.FixedCost(); err == nil && index >= 0 {
+				original := result[index]
+				fc.ID, fc.WorkspaceID, fc.UserID = original.ID, original.WorkspaceID, original.UserID
+				fc.Revisions = original.Revisions
+				result[index] = *fc
+			}
+		case ActionRemove:
+			if index >= 0 {
+				result = append(result[:index], result[index+1:]...)
+			}
+		}
+	}
+
+	return result
+}
+
+// SpecialCosts returns the special costs as they would be with the
+// scenario, see FixedCosts.
+func (s *Scenario) SpecialCosts(costs []cost.SpecialCost) []cost.SpecialCost {
+	result := make([]cost.SpecialCost, len(costs))
+	copy(result, costs)
+
+	for _, change := range s.Changes {
+		if change.Target != TargetSpecialCost {
+			continue
+		}
+
+		index := -1
+		for i := range result {
+			if result[i].ID == change.CostID {
+				index = i
+				break
+			}
+		}
+
+		switch change.Action {
+		case ActionAdd:
+			if sc, err := change.SpecialCost(); err == nil {
+				sc.ID = -int(change.ID)
+				sc.WorkspaceID = s.WorkspaceID
+				result = append(result, *sc)
+			}
+		case ActionModify:
+			if sc, err := change.SpecialCost(); err == nil && index >= 0 {
+				original := result[index]
+				sc.ID, sc.WorkspaceID, sc.UserID = original.ID, original.WorkspaceID, original.UserID
+				result[index] = *sc
+			}
+		case ActionRemove:
+			if index >= 0 {
+				result = append(result[:index], result[index+1:]...)
+			}
+		}
+	}
+
+	return result
+}
+
+// ChangesProfile reports whether the scenario overrides the wealth profile.
+func (s *Scenario) ChangesProfile() bool {
+	for _, change := range s.Changes {
+		if change.Target == TargetWealthProfile {
+			return true
+		}
+	}
+	return false
+}
+
+// Profile returns a copy of profile with the parameters of the scenario.
+func (s *Scenario) Profile(profile wealth.WealthProfile) wealth.WealthProfile {
+	for _, change := range s.Changes {
+		if change.Target != TargetWealthProfile {
+			continue
+		}
+		if p, err := change.Profile(); err == nil {
+			p.ApplyTo(&profile)
+		}
+	}
+	return profile
+}
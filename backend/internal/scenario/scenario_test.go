@@ -0,0 +1,146 @@
+package scenario
+
+import (
+	"testing"
+
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/wealth"
+)
+
+func mustChange(t *testing.T, id uint, target, action string, costID int, payload interface{}) Change {
+	t.Helper()
+	change, err := NewChange(target, action, costID, payload)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	change.ID = id
+	return *change
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  Change
+		wantErr bool
+	}{
+		{"add cost", Change{Target: TargetFixedCost, Action: ActionAdd, Payload: "{}"}, false},
+		{"add cost without payload", Change{Target: TargetFixedCost, Action: ActionAdd}, true},
+		{"add cost referring to existing one", Change{Target: TargetSpecialCost, Action: ActionAdd, CostID: 3, Payload: "{}"}, true},
+		{"modify cost", Change{Target: TargetSpecialCost, Action: ActionModify, CostID: 3, Payload: "{}"}, false},
+		{"modify cost without id", Change{Target: TargetSpecialCost, Action: ActionModify, Payload: "{}"}, true},
+		{"remove cost", Change{Target: TargetFixedCost, Action: ActionRemove, CostID: 3}, false},
+		{"unknown action", Change{Target: TargetFixedCost, Action: "rename", CostID: 3}, true},
+		{"unknown target", Change{Target: "budget", Action: ActionAdd, Payload: "{}"}, true},
+		{"modify profile", Change{Target: TargetWealthProfile, Action: ActionModify, Payload: "{}"}, false},
+		{"remove profile", Change{Target: TargetWealthProfile, Action: ActionRemove}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.change.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFixedCosts(t *testing.T) {
+	from := types.YearMonth{Year: 2026, Month: 1}
+	costs := []cost.FixedCost{
+		{ID: 1, WorkspaceID: 1, UserID: 2, Name: "Rent", Amount: -900,
+			Revisions: []cost.AmountRevision{{FixedCostID: 1, Amount: -950, ValidFrom: from}}},
+		{ID: 2, WorkspaceID: 1, Name: "Car", Amount: -250},
+		{ID: 3, WorkspaceID: 1, Name: "Salary", Amount: 3000},
+	}
+
+	s := &Scenario{
+		WorkspaceID: 1,
+		Changes: []Change{
+			mustChange(t, 7, TargetFixedCost, ActionAdd, 0, &cost.FixedCost{
+				Name: "Leasing", Amount: -389, From: &from,
+				Recurrence: cost.Recurrence{Frequency: cost.FrequencyMonthly, Interval: 1},
+				Tags:       cost.Tags{"car"},
+			}),
+			mustChange(t, 8, TargetFixedCost, ActionModify, 1, &cost.FixedCost{Name: "Rent", Amount: -1200}),
+			mustChange(t, 9, TargetFixedCost, ActionRemove, 2, nil),
+			mustChange(t, 10, TargetFixedCost, ActionRemove, 99, nil),
+			mustChange(t, 11, TargetSpecialCost, ActionRemove, 3, nil),
+		},
+	}
+
+	result := s.FixedCosts(costs)
+
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 costs, got %+v", result)
+	}
+	if rent := result[0]; rent.ID != 1 || rent.Amount != -1200 || rent.UserID != 2 || len(rent.Revisions) != 1 {
+		t.Errorf("Expected modified rent keeping its revisions, got %+v", rent)
+	}
+	if salary := result[1]; salary.ID != 3 {
+		t.Errorf("Expected salary to be kept, got %+v", salary)
+	}
+	leasing := result[2]
+	if leasing.ID != -7 || leasing.WorkspaceID != 1 || leasing.Amount != -389 ||
+		leasing.From == nil || *leasing.From != from || leasing.Schedule().Frequency != cost.FrequencyMonthly || leasing.Tags[0] != "car" {
+		t.Errorf("Unexpected added cost %+v", leasing)
+	}
+
+	// The real costs are untouched
+	if costs[0].Amount != -900 || costs[1].ID != 2 {
+		t.Errorf("Expected the real costs to stay unchanged, got %+v", costs)
+	}
+}
+
+func TestSpecialCosts(t *testing.T) {
+	due := types.YearMonth{Year: 2026, Month: 6}
+	costs := []cost.SpecialCost{
+		{ID: 4, WorkspaceID: 1, Name: "Holiday", Amount: -2000, DueDate: &due},
+		{ID: 5, WorkspaceID: 1, Name: "Bike", Amount: -800, DueDate: &due},
+	}
+
+	s := &Scenario{
+		WorkspaceID: 1,
+		Changes: []Change{
+			mustChange(t, 3, TargetSpecialCost, ActionModify, 4, &cost.SpecialCost{Name: "Holiday", Amount: -3500, DueDate: &due}),
+			mustChange(t, 4, TargetSpecialCost, ActionRemove, 5, nil),
+			mustChange(t, 5, TargetSpecialCost, ActionAdd, 0, &cost.SpecialCost{Name: "Moving", Amount: -1500, DueDate: &due, Installments: 3}),
+		},
+	}
+
+	result := s.SpecialCosts(costs)
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 costs, got %+v", result)
+	}
+	if result[0].ID != 4 || result[0].Amount != -3500 {
+		t.Errorf("Expected modified holiday, got %+v", result[0])
+	}
+	if result[1].ID != -5 || result[1].Name != "Moving" || len(result[1].Expand()) != 3 {
+		t.Errorf("Expected added installment plan, got %+v", result[1])
+	}
+}
+
+func TestProfile(t *testing.T) {
+	wealthAmount := 15000.0
+	years := 20
+	s := &Scenario{}
+	if s.ChangesProfile() {
+		t.Error("Expected no profile change")
+	}
+
+	s.Changes = []Change{
+		mustChange(t, 1, TargetWealthProfile, ActionModify, 0, &ProfileChange{CurrentWealth: &wealthAmount}),
+		mustChange(t, 2, TargetWealthProfile, ActionModify, 0, &ProfileChange{ForecastDurationYears: &years}),
+	}
+	base := wealth.WealthProfile{CurrentWealth: 10000, ForecastDurationYears: 10, RateAverageCase: 5}
+
+	profile := s.Profile(base)
+
+	if !s.ChangesProfile() || profile.CurrentWealth != 15000 || profile.ForecastDurationYears != 20 || profile.RateAverageCase != 5 {
+		t.Errorf("Unexpected profile %+v", profile)
+	}
+	if base.CurrentWealth != 10000 {
+		t.Errorf("Expected the real profile to stay unchanged, got %+v", base)
+	}
+}
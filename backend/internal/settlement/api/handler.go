@@ -0,0 +1,439 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"wondee/finance-app-backend/internal/audit"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/settlement"
+	"wondee/finance-app-backend/internal/settlement/repository"
+	"wondee/finance-app-backend/internal/storage"
+)
+
+const dateLayout = "2006-01-02"
+
+// settlementDescription is used for transfers recorded by settle-up.
+const settlementDescription = "Ausgleich"
+
+// Handler handles HTTP requests for the settlement ledger between workspace
+// members
+type Handler struct {
+	repo       repository.Repository
+	workspaces storage.WorkspaceRepository
+	audit      *audit.Log
+}
+
+// NewHandler creates a new Handler instance. Recorded and deleted entries
+// are written to auditLog, which may be nil.
+func NewHandler(repo repository.Repository, workspaces storage.WorkspaceRepository, auditLog *audit.Log) *Handler {
+	return &Handler{
+		repo:       repo,
+		workspaces: workspaces,
+		audit:      auditLog,
+	}
+}
+
+// Request types
+
+// ExpenseRequest records a payment made for others. Amount is in currency
+// units; linked to a cost, description and amount default to the cost's.
+type ExpenseRequest struct {
+	Description string       `json:"description"`
+	Amount      float64      `json:"amount"`
+	PaidBy      uint         `json:"paidBy" binding:"required"`
+	Date        string       `json:"date"`
+	Split       []cost.Share `json:"split"`
+	CostType    string       `json:"costType"`
+	CostID      *uint        `json:"costId"`
+}
+
+// SettleUpRequest records a single transfer between two members. Without
+// members all open balances are settled.
+type SettleUpRequest struct {
+	From   uint    `json:"from"`
+	To     uint    `json:"to"`
+	Amount float64 `json:"amount"`
+	Date   string  `json:"date"`
+}
+
+// Response types
+
+type LedgerResponse struct {
+	Currency  string        `json:"currency"`
+	Entries   []EntryDTO    `json:"entries"`
+	Balances  []BalanceDTO  `json:"balances"`
+	Transfers []TransferDTO `json:"transfers"`
+}
+
+type EntryDTO struct {
+	ID          uint       `json:"id"`
+	Kind        string     `json:"kind"`
+	Description string     `json:"description"`
+	Amount      float64    `json:"amount"`
+	PaidBy      uint       `json:"paidBy"`
+	Date        string     `json:"date"`
+	CostType    string     `json:"costType,omitempty"`
+	CostID      *uint      `json:"costId,omitempty"`
+	Shares      []ShareDTO `json:"shares"`
+}
+
+type ShareDTO struct {
+	UserID uint    `json:"userId"`
+	Amount float64 `json:"amount"`
+}
+
+// BalanceDTO is positive if the member gets money back.
+type BalanceDTO struct {
+	UserID  uint    `json:"userId"`
+	Name    string  `json:"name"`
+	Balance float64 `json:"balance"`
+}
+
+type TransferDTO struct {
+	From   uint    `json:"from"`
+	To     uint    `json:"to"`
+	Amount float64 `json:"amount"`
+}
+
+func ToEntryDTO(e *settlement.Entry) EntryDTO {
+	shares := make([]ShareDTO, 0, len(e.Shares))
+	for _, share := range e.Shares {
+		shares = append(shares, ShareDTO{UserID: share.UserID, Amount: toUnits(share.AmountCents)})
+	}
+
+	return EntryDTO{
+		ID:          e.ID,
+		Kind:        e.Kind,
+		Description: e.Description,
+		Amount:      toUnits(e.AmountCents),
+		PaidBy:      e.PaidBy,
+		Date:        e.Date.Format(dateLayout),
+		CostType:    e.CostType,
+		CostID:      e.CostID,
+		Shares:      shares,
+	}
+}
+
+// GetLedger returns all entries together with the balances of the members
+// and the transfers that would settle them.
+func (h *Handler) GetLedger(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	entries, err := h.repo.ListEntries(workspaceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load settlements"})
+		return
+	}
+
+	currency := ""
+	names := make(map[uint]string)
+	if workspace, err := h.workspaces.GetWorkspaceByID(workspaceID); err == nil {
+		currency = workspace.BaseCurrency
+		for _, u := range workspace.Users {
+			names[u.ID] = u.Name
+		}
+	}
+
+	balances := settlement.Balances(entries)
+	for member := range names {
+		if _, ok := balances[member]; !ok {
+			balances[member] = 0
+		}
+	}
+
+	response := LedgerResponse{
+		Currency:  currency,
+		Entries:   make([]EntryDTO, 0, len(entries)),
+		Balances:  make([]BalanceDTO, 0, len(balances)),
+		Transfers: make([]TransferDTO, 0),
+	}
+	for i := range entries {
+		response.Entries = append(response.Entries, ToEntryDTO(&entries[i]))
+	}
+	for member, balance := range balances {
+		response.Balances = append(response.Balances, BalanceDTO{UserID: member, Name: names[member], Balance: toUnits(balance)})
+	}
+	sort.Slice(response.Balances, func(i, j int) bool {
+		return response.Balances[i].UserID < response.Balances[j].UserID
+	})
+	for _, transfer := range settlement.SettleUp(balances) {
+		response.Transfers = append(response.Transfers, toTransferDTO(transfer))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RecordExpense records a payment a member made for the workspace, divided
+// between the current members according to the split.
+func (h *Handler) RecordExpense(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	var req ExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	members, ok := h.loadMembers(c, workspaceID)
+	if !ok {
+		return
+	}
+
+	date, err := parseDate(req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date must be formatted as YYYY-MM-DD"})
+		return
+	}
+
+	entry := settlement.Entry{
+		WorkspaceID: workspaceID,
+		Kind:        settlement.KindExpense,
+		Description: strings.TrimSpace(req.Description),
+		AmountCents: toCents(req.Amount),
+		PaidBy:      req.PaidBy,
+		Date:        date,
+		CreatedBy:   h.getUserID(c),
+	}
+
+	if req.CostID != nil {
+		linked, err := h.repo.FindCost(workspaceID, req.CostType, *req.CostID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cost not found"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		entry.CostType = req.CostType
+		entry.CostID = req.CostID
+		if entry.Description == "" {
+			entry.Description = linked.Name
+		}
+		if entry.AmountCents == 0 {
+			entry.AmountCents = toCents(math.Abs(float64(linked.Amount)))
+		}
+	}
+
+	if entry.Description == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "description is required"})
+		return
+	}
+	if entry.AmountCents <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "amount must be positive"})
+		return
+	}
+	if !slices.Contains(members, req.PaidBy) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "paidBy must be a member of the workspace"})
+		return
+	}
+
+	split := cost.Split(req.Split)
+	if err := split.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	for _, share := range split {
+		if !slices.Contains(members, share.UserID) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "split must only contain members of the workspace"})
+			return
+		}
+	}
+
+	entry.Shares, err = settlement.Divide(entry.AmountCents, split, members)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries := []settlement.Entry{entry}
+	if err := h.repo.CreateEntries(entries); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save expense"})
+		return
+	}
+
+	result := ToEntryDTO(&entries[0])
+	h.record(c, result.ID, audit.ActionCreate, nil, result)
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// SettleUp records the compensating transfers: either the single transfer
+// given or all transfers needed to even out the balances.
+func (h *Handler) SettleUp(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	var req SettleUpRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	date, err := parseDate(req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date must be formatted as YYYY-MM-DD"})
+		return
+	}
+
+	var transfers []settlement.Transfer
+	if req.From != 0 || req.To != 0 {
+		members, ok := h.loadMembers(c, workspaceID)
+		if !ok {
+			return
+		}
+		if !slices.Contains(members, req.From) || !slices.Contains(members, req.To) || req.From == req.To {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from and to must be two members of the workspace"})
+			return
+		}
+		if toCents(req.Amount) <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "amount must be positive"})
+			return
+		}
+		transfers = []settlement.Transfer{{From: req.From, To: req.To, AmountCents: toCents(req.Amount)}}
+	} else {
+		entries, err := h.repo.ListEntries(workspaceID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load settlements"})
+			return
+		}
+		transfers = settlement.SettleUp(settlement.Balances(entries))
+	}
+
+	entries := make([]settlement.Entry, 0, len(transfers))
+	for _, transfer := range transfers {
+		entries = append(entries, settlement.Entry{
+			WorkspaceID: workspaceID,
+			Kind:        settlement.KindSettlement,
+			Description: settlementDescription,
+			AmountCents: transfer.AmountCents,
+			PaidBy:      transfer.From,
+			Date:        date,
+			CreatedBy:   h.getUserID(c),
+			Shares:      []settlement.Share{{UserID: transfer.To, AmountCents: transfer.AmountCents}},
+		})
+	}
+
+	if len(entries) > 0 {
+		if err := h.repo.CreateEntries(entries); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save settlement"})
+			return
+		}
+	}
+
+	result := make([]EntryDTO, 0, len(entries))
+	for i := range entries {
+		dto := ToEntryDTO(&entries[i])
+		h.record(c, dto.ID, audit.ActionCreate, nil, dto)
+		result = append(result, dto)
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+func (h *Handler) DeleteEntry(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entry ID"})
+		return
+	}
+
+	before, err := h.repo.GetEntry(uint(id), workspaceID)
+	if err == nil {
+		err = h.repo.DeleteEntry(uint(id), workspaceID)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Entry not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete entry"})
+		return
+	}
+
+	h.record(c, before.ID, audit.ActionDelete, ToEntryDTO(before), nil)
+
+	c.Status(http.StatusNoContent)
+}
+
+// Helper functions
+
+// record adds a change of a ledger entry to the audit log.
+func (h *Handler) record(c *gin.Context, id uint, action string, before, after interface{}) {
+	h.audit.Record(audit.Change{
+		WorkspaceID: h.getWorkspaceID(c),
+		Actor:       h.getUserID(c),
+		EntityType:  audit.EntitySettlementEntry,
+		EntityID:    id,
+		Action:      action,
+		Before:      before,
+		After:       after,
+	})
+}
+
+// loadMembers returns the IDs of the workspace members in ascending order;
+// otherwise it responds with an error.
+func (h *Handler) loadMembers(c *gin.Context, workspaceID uint) ([]uint, bool) {
+	workspace, err := h.workspaces.GetWorkspaceByID(workspaceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load workspace"})
+		return nil, false
+	}
+
+	members := make([]uint, 0, len(workspace.Users))
+	for _, u := range workspace.Users {
+		members = append(members, u.ID)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i] < members[j] })
+
+	return members, true
+}
+
+func toTransferDTO(transfer settlement.Transfer) TransferDTO {
+	return TransferDTO{From: transfer.From, To: transfer.To, Amount: toUnits(transfer.AmountCents)}
+}
+
+// parseDate reads a YYYY-MM-DD date; empty means today.
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Parse(dateLayout, value)
+}
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func toUnits(cents int64) float64 {
+	return float64(cents) / 100
+}
+
+func (h *Handler) getUserID(c *gin.Context) uint {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return 0
+	}
+	return userID.(uint)
+}
+
+func (h *Handler) getWorkspaceID(c *gin.Context) uint {
+	workspaceID, exists := c.Get("workspace_id")
+	if !exists {
+		return 0
+	}
+	return workspaceID.(uint)
+}
@@ -0,0 +1,276 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+	"wondee/finance-app-backend/internal/audit"
+	"wondee/finance-app-backend/internal/settlement"
+	"wondee/finance-app-backend/internal/settlement/repository"
+	"wondee/finance-app-backend/internal/storage"
+	"wondee/finance-app-backend/internal/user"
+	"wondee/finance-app-backend/internal/workspace"
+)
+
+// MockSettlementRepository implements settlement repository.Repository
+type MockSettlementRepository struct {
+	mock.Mock
+}
+
+func (m *MockSettlementRepository) ListEntries(workspaceID uint) ([]settlement.Entry, error) {
+	args := m.Called(workspaceID)
+	return args.Get(0).([]settlement.Entry), args.Error(1)
+}
+
+func (m *MockSettlementRepository) GetEntry(id uint, workspaceID uint) (*settlement.Entry, error) {
+	args := m.Called(id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*settlement.Entry), args.Error(1)
+}
+
+func (m *MockSettlementRepository) CreateEntries(entries []settlement.Entry) error {
+	args := m.Called(entries)
+	return args.Error(0)
+}
+
+func (m *MockSettlementRepository) DeleteEntry(id uint, workspaceID uint) error {
+	args := m.Called(id, workspaceID)
+	return args.Error(0)
+}
+
+func (m *MockSettlementRepository) FindCost(workspaceID uint, costType string, costID uint) (*repository.LinkedCost, error) {
+	args := m.Called(workspaceID, costType, costID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.LinkedCost), args.Error(1)
+}
+
+type auditWriter struct {
+	entries []audit.Entry
+}
+
+func (w *auditWriter) CreateEntry(entry *audit.Entry) error {
+	w.entries = append(w.entries, *entry)
+	return nil
+}
+
+func setupTestRouter(mockRepo *MockSettlementRepository) *gin.Engine {
+	return setupAuditedRouter(mockRepo, nil)
+}
+
+func setupAuditedRouter(mockRepo *MockSettlementRepository, auditLog *audit.Log) *gin.Engine {
+	workspaces := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{
+			ID:           1,
+			BaseCurrency: "EUR",
+			Users:        []user.User{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}},
+		}},
+	}
+	handler := NewHandler(mockRepo, workspaces, auditLog)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+	router.GET("/settlements", handler.GetLedger)
+	router.POST("/settlements/expenses", handler.RecordExpense)
+	router.POST("/settlements/settle-up", handler.SettleUp)
+	router.DELETE("/settlements/:id", handler.DeleteEntry)
+	return router
+}
+
+func postJSON(router *gin.Engine, path string, body interface{}) *httptest.ResponseRecorder {
+	var reader *bytes.Buffer
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewBuffer(data)
+	} else {
+		reader = &bytes.Buffer{}
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetLedger(t *testing.T) {
+	mockRepo := new(MockSettlementRepository)
+	router := setupTestRouter(mockRepo)
+
+	date := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("ListEntries", uint(1)).Return([]settlement.Entry{
+		{ID: 1, Kind: settlement.KindExpense, Description: "Groceries", AmountCents: 8000, PaidBy: 2, Date: date,
+			Shares: []settlement.Share{{UserID: 1, AmountCents: 4000}, {UserID: 2, AmountCents: 4000}}},
+	}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/settlements", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response LedgerResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "EUR", response.Currency)
+	assert.Len(t, response.Entries, 1)
+	assert.Equal(t, "2025-03-01", response.Entries[0].Date)
+	assert.Equal(t, []BalanceDTO{{UserID: 1, Name: "Alice", Balance: -40}, {UserID: 2, Name: "Bob", Balance: 40}}, response.Balances)
+	assert.Equal(t, []TransferDTO{{From: 1, To: 2, Amount: 40}}, response.Transfers)
+}
+
+func TestRecordExpense(t *testing.T) {
+	mockRepo := new(MockSettlementRepository)
+	router := setupTestRouter(mockRepo)
+
+	mockRepo.On("CreateEntries", mock.MatchedBy(func(entries []settlement.Entry) bool {
+		entry := entries[0]
+		return len(entries) == 1 && entry.Kind == settlement.KindExpense && entry.AmountCents == 4999 &&
+			entry.PaidBy == 2 && len(entry.Shares) == 2 && entry.Shares[0].AmountCents == 2499 && entry.Shares[1].AmountCents == 2500
+	})).Return(nil)
+
+	w := postJSON(router, "/settlements/expenses", map[string]interface{}{
+		"description": "Dinner",
+		"amount":      49.99,
+		"paidBy":      2,
+		"date":        "2025-03-14",
+	})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response EntryDTO
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 49.99, response.Amount)
+	assert.Equal(t, "2025-03-14", response.Date)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRecordExpense_LinkedCost(t *testing.T) {
+	mockRepo := new(MockSettlementRepository)
+	router := setupTestRouter(mockRepo)
+
+	mockRepo.On("FindCost", uint(1), settlement.CostSpecial, uint(7)).Return(&repository.LinkedCost{Name: "Flights", Amount: -600}, nil)
+	mockRepo.On("CreateEntries", mock.MatchedBy(func(entries []settlement.Entry) bool {
+		entry := entries[0]
+		return entry.Description == "Flights" && entry.AmountCents == 60000 && *entry.CostID == 7 &&
+			len(entry.Shares) == 1 && entry.Shares[0].UserID == 2
+	})).Return(nil)
+
+	w := postJSON(router, "/settlements/expenses", map[string]interface{}{
+		"paidBy":   1,
+		"costType": settlement.CostSpecial,
+		"costId":   7,
+		"split":    []map[string]interface{}{{"userId": 2, "percent": 100}},
+	})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRecordExpense_Invalid(t *testing.T) {
+	mockRepo := new(MockSettlementRepository)
+	router := setupTestRouter(mockRepo)
+
+	mockRepo.On("FindCost", uint(1), settlement.CostOneTime, uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	tests := []struct {
+		name   string
+		body   map[string]interface{}
+		status int
+	}{
+		{"payer is no member", map[string]interface{}{"description": "Dinner", "amount": 20, "paidBy": 3}, http.StatusBadRequest},
+		{"share of no member", map[string]interface{}{"description": "Dinner", "amount": 20, "paidBy": 1,
+			"split": []map[string]interface{}{{"userId": 3, "percent": 100}}}, http.StatusBadRequest},
+		{"negative amount", map[string]interface{}{"description": "Dinner", "amount": -20, "paidBy": 1}, http.StatusBadRequest},
+		{"missing description", map[string]interface{}{"amount": 20, "paidBy": 1}, http.StatusBadRequest},
+		{"unknown cost", map[string]interface{}{"paidBy": 1, "costType": settlement.CostOneTime, "costId": 9}, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := postJSON(router, "/settlements/expenses", tt.body)
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+	mockRepo.AssertNotCalled(t, "CreateEntries", mock.Anything)
+}
+
+func TestSettleUp_All(t *testing.T) {
+	mockRepo := new(MockSettlementRepository)
+	router := setupTestRouter(mockRepo)
+
+	mockRepo.On("ListEntries", uint(1)).Return([]settlement.Entry{
+		{Kind: settlement.KindExpense, AmountCents: 3000, PaidBy: 1, Shares: []settlement.Share{{UserID: 2, AmountCents: 3000}}},
+	}, nil)
+	mockRepo.On("CreateEntries", mock.MatchedBy(func(entries []settlement.Entry) bool {
+		entry := entries[0]
+		return len(entries) == 1 && entry.Kind == settlement.KindSettlement && entry.PaidBy == 2 &&
+			entry.AmountCents == 3000 && entry.Shares[0].UserID == 1
+	})).Return(nil)
+
+	w := postJSON(router, "/settlements/settle-up", nil)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response []EntryDTO
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSettleUp_SingleTransfer(t *testing.T) {
+	mockRepo := new(MockSettlementRepository)
+	router := setupTestRouter(mockRepo)
+
+	mockRepo.On("CreateEntries", mock.MatchedBy(func(entries []settlement.Entry) bool {
+		return len(entries) == 1 && entries[0].PaidBy == 1 && entries[0].AmountCents == 1250
+	})).Return(nil)
+
+	w := postJSON(router, "/settlements/settle-up", map[string]interface{}{"from": 1, "to": 2, "amount": 12.5})
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = postJSON(router, "/settlements/settle-up", map[string]interface{}{"from": 1, "to": 1, "amount": 12.5})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockRepo.AssertNumberOfCalls(t, "CreateEntries", 1)
+	mockRepo.AssertNotCalled(t, "ListEntries", mock.Anything)
+}
+
+func TestDeleteEntry(t *testing.T) {
+	mockRepo := new(MockSettlementRepository)
+	writer := &auditWriter{}
+	router := setupAuditedRouter(mockRepo, audit.NewLog(writer))
+
+	mockRepo.On("GetEntry", uint(5), uint(1)).Return(&settlement.Entry{ID: 5, WorkspaceID: 1, Description: "Pizza"}, nil)
+	mockRepo.On("GetEntry", uint(6), uint(1)).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("DeleteEntry", uint(5), uint(1)).Return(nil)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/settlements/5", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	req, _ = http.NewRequest(http.MethodDelete, "/settlements/6", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	if assert.Len(t, writer.entries, 1) {
+		assert.Equal(t, audit.EntitySettlementEntry, writer.entries[0].EntityType)
+		assert.Equal(t, audit.ActionDelete, writer.entries[0].Action)
+		assert.Equal(t, uint(5), writer.entries[0].EntityID)
+		assert.Contains(t, writer.entries[0].Before, "Pizza")
+	}
+}
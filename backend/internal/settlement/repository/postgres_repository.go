@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"fmt"
+
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/settlement"
+	"wondee/finance-app-backend/internal/spend"
+
+	"gorm.io/gorm"
+)
+
+func (r *PostgresRepository) ListEntries(workspaceID uint) ([]settlement.Entry, error) {
+	var entries []settlement.Entry
+	result := r.DB.Preload("Shares").
+		Where("workspace_id = ?", workspaceID).
+		Order("date DESC, id DESC").
+		Find(&entries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return entries, nil
+}
+
+func (r *PostgresRepository) GetEntry(id uint, workspaceID uint) (*settlement.Entry, error) {
+	var entry settlement.Entry
+	result := r.DB.Preload("Shares").Where("id = ? AND workspace_id = ?", id, workspaceID).First(&entry)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &entry, nil
+}
+
+func (r *PostgresRepository) CreateEntries(entries []settlement.Entry) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		for i := range entries {
+			if err := tx.Create(&entries[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *PostgresRepository) DeleteEntry(id uint, workspaceID uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("entry_id IN (?)",
+			tx.Model(&settlement.Entry{}).Select("id").Where("id = ? AND workspace_id = ?", id, workspaceID),
+		).Delete(&settlement.Share{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ? AND workspace_id = ?", id, workspaceID).Delete(&settlement.Entry{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *PostgresRepository) FindCost(workspaceID uint, costType string, costID uint) (*LinkedCost, error) {
+	switch costType {
+	case settlement.CostSpecial:
+		var specialCost cost.SpecialCost
+		if err := r.DB.Where("id = ? AND workspace_id = ?", costID, workspaceID).First(&specialCost).Error; err != nil {
+			return nil, err
+		}
+		return &LinkedCost{Name: specialCost.Name, Amount: specialCost.Amount}, nil
+	case settlement.CostOneTime:
+		var oneTimeCost spend.OneTimePendingCost
+		if err := r.DB.Where("id = ? AND workspace_id = ?", costID, workspaceID).First(&oneTimeCost).Error; err != nil {
+			return nil, err
+		}
+		return &LinkedCost{Name: oneTimeCost.Name, Amount: oneTimeCost.Amount}, nil
+	default:
+		return nil, fmt.Errorf("unknown cost type %q", costType)
+	}
+}
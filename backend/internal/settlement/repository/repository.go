@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"wondee/finance-app-backend/internal/settlement"
+
+	"gorm.io/gorm"
+)
+
+// LinkedCost is the cost an expense paid for.
+type LinkedCost struct {
+	Name   string
+	Amount int
+}
+
+// Repository defines the interface for settlement ledger data access
+type Repository interface {
+	// ListEntries returns all entries with their shares, newest first.
+	ListEntries(workspaceID uint) ([]settlement.Entry, error)
+	GetEntry(id uint, workspaceID uint) (*settlement.Entry, error)
+	// CreateEntries stores the entries and their shares in one database
+	// transaction.
+	CreateEntries(entries []settlement.Entry) error
+	DeleteEntry(id uint, workspaceID uint) error
+
+	// FindCost loads the special or one-time cost an expense is linked to.
+	FindCost(workspaceID uint, costType string, costID uint) (*LinkedCost, error)
+}
+
+// PostgresRepository implements Repository using GORM
+type PostgresRepository struct {
+	DB *gorm.DB
+}
@@ -0,0 +1,169 @@
+package settlement
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"wondee/finance-app-backend/internal/cost"
+)
+
+// Kinds of ledger entries
+const (
+	KindExpense    = "expense"
+	KindSettlement = "settlement"
+)
+
+// Kinds of costs an expense can be linked to
+const (
+	CostSpecial = "specialCost"
+	CostOneTime = "oneTimeCost"
+)
+
+var ErrNoMembers = errors.New("workspace has no members to split with")
+
+// Entry is a payment one member made on behalf of others. For an expense the
+// shares are what every member consumed; a settlement is a transfer from
+// PaidBy to the single member of its shares. Shares are resolved when the
+// entry is recorded, so later changes to the workspace do not rewrite
+// history.
+type Entry struct {
+	ID          uint      `gorm:"primaryKey"`
+	WorkspaceID uint      `gorm:"not null;index"`
+	Kind        string    `gorm:"size:20;not null"`
+	Description string    `gorm:"not null"`
+	AmountCents int64     `gorm:"not null"`
+	PaidBy      uint      `gorm:"not null"`
+	Date        time.Time `gorm:"type:date;not null"`
+	CostType    string    `gorm:"size:20"`
+	CostID      *uint
+	CreatedBy   uint
+	CreatedAt   time.Time
+
+	Shares []Share `gorm:"foreignKey:EntryID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (Entry) TableName() string {
+	return "settlement_entries"
+}
+
+// Share is the part of an entry a member owes to the payer.
+type Share struct {
+	ID          uint  `gorm:"primaryKey"`
+	EntryID     uint  `gorm:"not null;index"`
+	UserID      uint  `gorm:"not null"`
+	AmountCents int64 `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (Share) TableName() string {
+	return "settlement_shares"
+}
+
+// Transfer is a payment that evens out balances between two members.
+type Transfer struct {
+	From        uint
+	To          uint
+	AmountCents int64
+}
+
+// Divide resolves a split of the given amount into shares of the members,
+// using the same rules as for costs: fixed amounts first, then percentages,
+// the rest equally. Rounding differences go to the first members, so the
+// shares always add up to the amount.
+func Divide(amountCents int64, split cost.Split, members []uint) ([]Share, error) {
+	if len(members) == 0 {
+		return nil, ErrNoMembers
+	}
+
+	parts := split.Divide(float64(amountCents)/100, members)
+
+	shares := make([]Share, 0, len(members))
+	var total int64
+	for _, member := range members {
+		cents := int64(math.Round(parts[member] * 100))
+		shares = append(shares, Share{UserID: member, AmountCents: cents})
+		total += cents
+	}
+
+	for i := 0; total != amountCents; i = (i + 1) % len(shares) {
+		if total < amountCents {
+			shares[i].AmountCents++
+			total++
+		} else {
+			shares[i].AmountCents--
+			total--
+		}
+	}
+
+	result := shares[:0]
+	for _, share := range shares {
+		if share.AmountCents != 0 {
+			result = append(result, share)
+		}
+	}
+	return result, nil
+}
+
+// Balances returns the net balance of every member involved in the entries:
+// positive if the others owe them money, negative if they owe.
+func Balances(entries []Entry) map[uint]int64 {
+	result := make(map[uint]int64)
+	for _, entry := range entries {
+		result[entry.PaidBy] += entry.AmountCents
+		for _, share := range entry.Shares {
+			result[share.UserID] -= share.AmountCents
+		}
+	}
+	return result
+}
+
+// SettleUp suggests the transfers that even out the balances, always paying
+// the largest debt to the largest creditor, which keeps the number of
+// transfers low.
+func SettleUp(balances map[uint]int64) []Transfer {
+	type position struct {
+		user   uint
+		amount int64
+	}
+
+	var creditors, debtors []position
+	for user, balance := range balances {
+		switch {
+		case balance > 0:
+			creditors = append(creditors, position{user, balance})
+		case balance < 0:
+			debtors = append(debtors, position{user, -balance})
+		}
+	}
+
+	byAmount := func(positions []position) func(i, j int) bool {
+		return func(i, j int) bool {
+			if positions[i].amount != positions[j].amount {
+				return positions[i].amount > positions[j].amount
+			}
+			return positions[i].user < positions[j].user
+		}
+	}
+	sort.Slice(creditors, byAmount(creditors))
+	sort.Slice(debtors, byAmount(debtors))
+
+	transfers := make([]Transfer, 0)
+	for i, j := 0, 0; i < len(debtors) && j < len(creditors); {
+		amount := min(debtors[i].amount, creditors[j].amount)
+		transfers = append(transfers, Transfer{From: debtors[i].user, To: creditors[j].user, AmountCents: amount})
+
+		debtors[i].amount -= amount
+		creditors[j].amount -= amount
+		if debtors[i].amount == 0 {
+			i++
+		}
+		if creditors[j].amount == 0 {
+			j++
+		}
+	}
+
+	return transfers
+}
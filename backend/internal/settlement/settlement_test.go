@@ -0,0 +1,75 @@
+package settlement
+
+import (
+	"testing"
+
+	"wondee/finance-app-backend/internal/cost"
+)
+
+func TestDivide(t *testing.T) {
+	members := []uint{1, 2, 3}
+
+	shares, err := Divide(1000, nil, members)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// 10.00 split three ways, the odd cent goes to the first member
+	expected := []int64{334, 333, 333}
+	if len(shares) != 3 {
+		t.Fatalf("Expected 3 shares, got %+v", shares)
+	}
+	for i, share := range shares {
+		if share.UserID != members[i] || share.AmountCents != expected[i] {
+			t.Errorf("Expected %d for member %d, got %+v", expected[i], members[i], share)
+		}
+	}
+
+	shares, _ = Divide(5000, cost.Split{{UserID: 2, Amount: 20}, {UserID: 3, Percent: 100}}, members)
+	if len(shares) != 2 || shares[0].UserID != 2 || shares[0].AmountCents != 2000 || shares[1].AmountCents != 3000 {
+		t.Errorf("Expected 20.00 for member 2 and the rest for member 3, got %+v", shares)
+	}
+
+	if _, err := Divide(1000, nil, nil); err != ErrNoMembers {
+		t.Errorf("Expected ErrNoMembers, got %v", err)
+	}
+}
+
+func TestBalances(t *testing.T) {
+	entries := []Entry{
+		// Alice pays 90.00 for all three
+		{Kind: KindExpense, AmountCents: 9000, PaidBy: 1, Shares: []Share{{UserID: 1, AmountCents: 3000}, {UserID: 2, AmountCents: 3000}, {UserID: 3, AmountCents: 3000}}},
+		// Bob pays 30.00 for Carol
+		{Kind: KindExpense, AmountCents: 3000, PaidBy: 2, Shares: []Share{{UserID: 3, AmountCents: 3000}}},
+		// Carol transfers 10.00 to Alice
+		{Kind: KindSettlement, AmountCents: 1000, PaidBy: 3, Shares: []Share{{UserID: 1, AmountCents: 1000}}},
+	}
+
+	balances := Balances(entries)
+
+	if balances[1] != 5000 || balances[2] != 0 || balances[3] != -5000 {
+		t.Errorf("Unexpected balances %v", balances)
+	}
+}
+
+func TestSettleUp(t *testing.T) {
+	transfers := SettleUp(map[uint]int64{1: 7000, 2: -2000, 3: -4500, 4: -500, 5: 0})
+
+	expected := []Transfer{
+		{From: 3, To: 1, AmountCents: 4500},
+		{From: 2, To: 1, AmountCents: 2000},
+		{From: 4, To: 1, AmountCents: 500},
+	}
+	if len(transfers) != len(expected) {
+		t.Fatalf("Expected %d transfers, got %+v", len(expected), transfers)
+	}
+	for i := range expected {
+		if transfers[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], transfers[i])
+		}
+	}
+
+	if transfers := SettleUp(map[uint]int64{1: 0, 2: 0}); len(transfers) != 0 {
+		t.Errorf("Expected no transfers for even balances, got %+v", transfers)
+	}
+}
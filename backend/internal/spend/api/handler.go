@@ -1,16 +1,23 @@
 package api
 
 import (
+	"errors"
+	"math"
 	"net/http"
-	"slices"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"wondee/finance-app-backend/internal/audit"
 	"wondee/finance-app-backend/internal/cost"
 	cost_repo "wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/currency"
 	"wondee/finance-app-backend/internal/platform/types"
 	"wondee/finance-app-backend/internal/spend"
+	"wondee/finance-app-backend/internal/spend/reconcile"
 	"wondee/finance-app-backend/internal/spend/repository"
 	"wondee/finance-app-backend/internal/spend/service"
+	transaction_api "wondee/finance-app-backend/internal/transaction/api"
 )
 
 // Handler handles HTTP requests for the save-to-spend feature
@@ -18,14 +25,17 @@ type Handler struct {
 	repo     repository.Repository
 	costRepo cost_repo.Repository
 	service  *service.SpendService
+	audit    *audit.Log
 }
 
-// NewHandler creates a new Handler instance
-func NewHandler(repo repository.Repository, costRepo cost_repo.Repository) *Handler {
+// NewHandler creates a new Handler instance; envelopes and auditLog may be
+// nil. Changes are recorded in auditLog.
+func NewHandler(repo repository.Repository, costRepo cost_repo.Repository, envelopes service.EnvelopeSource, auditLog *audit.Log) *Handler {
 	return &Handler{
 		repo:     repo,
 		costRepo: costRepo,
-		service:  service.NewSpendService(repo, costRepo),
+		service:  service.NewSpendService(repo, costRepo, envelopes),
+		audit:    auditLog,
 	}
 }
 
@@ -39,26 +49,73 @@ type SaveToSpendResponse struct {
 	ExcludedFixedCosts []ExcludedFixedCostDTO `json:"excludedFixedCosts"`
 	OneTimeCosts       []OneTimeCostDTO       `json:"oneTimeCosts"`
 	PendingTotal       int                    `json:"pendingTotal"`
+	BudgetRemaining    int                    `json:"budgetRemaining"`
+	MissingRates       []string               `json:"missingRates,omitempty"`
 }
 
+// Amounts of the cost DTOs are in the cost's own currency; the totals of
+// SaveToSpendResponse are converted into the workspace's base currency.
+
 type IncludedFixedCostDTO struct {
-	ID     int    `json:"id"`
-	Name   string `json:"name"`
-	Amount int    `json:"amount"`
-	IsPaid bool   `json:"isPaid"`
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Amount        int    `json:"amount"`
+	Currency      string `json:"currency"`
+	IsPaid        bool   `json:"isPaid"`
+	TransactionID *uint  `json:"transactionId,omitempty"`
 }
 
 type ExcludedFixedCostDTO struct {
-	ID     int    `json:"id"`
-	Name   string `json:"name"`
-	Amount int    `json:"amount"`
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Amount   int    `json:"amount"`
+	Currency string `json:"currency"`
 }
 
 type OneTimeCostDTO struct {
-	ID     uint   `json:"id"`
-	Name   string `json:"name"`
-	Amount int    `json:"amount"`
-	IsPaid bool   `json:"isPaid"`
+	ID            uint   `json:"id"`
+	Name          string `json:"name"`
+	Amount        int    `json:"amount"`
+	Currency      string `json:"currency"`
+	IsPaid        bool   `json:"isPaid"`
+	TransactionID *uint  `json:"transactionId,omitempty"`
+}
+
+// ReconcileResponse lists the costs matched with bank transactions and those
+// left for review. Applied tells whether the matches were marked paid.
+type ReconcileResponse struct {
+	Applied bool                 `json:"applied"`
+	Matches []ReconcileMatchDTO  `json:"matches"`
+	Reviews []ReconcileReviewDTO `json:"reviews"`
+}
+
+type ReconcileItemDTO struct {
+	Kind     string  `json:"kind"`
+	ID       int     `json:"id"`
+	Name     string  `json:"name"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+type ReconcileCandidateDTO struct {
+	Transaction transaction_api.JsonTransaction `json:"transaction"`
+	Score       float64                         `json:"score"`
+}
+
+type ReconcileMatchDTO struct {
+	Item ReconcileItemDTO `json:"item"`
+	ReconcileCandidateDTO
+}
+
+type ReconcileReviewDTO struct {
+	Item       ReconcileItemDTO        `json:"item"`
+	Candidates []ReconcileCandidateDTO `json:"candidates"`
+}
+
+type ConfirmMatchRequest struct {
+	Kind          string `json:"kind" binding:"required"`
+	ID            int    `json:"id" binding:"required"`
+	TransactionID uint   `json:"transactionId" binding:"required"`
 }
 
 type UpdateBalanceRequest struct {
@@ -66,8 +123,9 @@ type UpdateBalanceRequest struct {
 }
 
 type CreateOneTimeCostRequest struct {
-	Name   string `json:"name" binding:"required,min=1,max=100"`
-	Amount int    `json:"amount" binding:"required"`
+	Name     string `json:"name" binding:"required,min=1,max=100"`
+	Amount   int    `json:"amount" binding:"required"`
+	Currency string `json:"currency"`
 }
 
 // GetSaveToSpend returns the complete save-to-spend state
@@ -104,10 +162,10 @@ func (h *Handler) buildSaveToSpendResponse(workspaceID uint, month types.YearMon
 		return nil, err
 	}
 
-	// Build a map of included cost IDs and their paid status
-	includedMap := make(map[int]bool) // fixedCostID -> isPaid
+	// Build a map of included cost IDs and their payment status
+	includedMap := make(map[int]spend.MonthlyPaymentStatus) // fixedCostID -> status
 	for _, status := range statuses {
-		includedMap[status.FixedCostID] = status.IsPaid
+		includedMap[status.FixedCostID] = status
 	}
 
 	// Load all fixed costs
@@ -123,18 +181,21 @@ func (h *Handler) buildSaveToSpendResponse(workspaceID uint, month types.YearMon
 			continue
 		}
 
-		if isPaid, included := includedMap[fc.ID]; included {
+		if status, included := includedMap[fc.ID]; included {
 			includedFixedCosts = append(includedFixedCosts, IncludedFixedCostDTO{
-				ID:     fc.ID,
-				Name:   fc.Name,
-				Amount: fc.Amount,
-				IsPaid: isPaid,
+				ID:            fc.ID,
+				Name:          fc.Name,
+				Amount:        fc.AmountAt(&month),
+				Currency:      fc.Currency,
+				IsPaid:        status.IsPaid,
+				TransactionID: status.TransactionID,
 			})
 		} else {
 			excludedFixedCosts = append(excludedFixedCosts, ExcludedFixedCostDTO{
-				ID:     fc.ID,
-				Name:   fc.Name,
-				Amount: fc.Amount,
+				ID:       fc.ID,
+				Name:     fc.Name,
+				Amount:   fc.AmountAt(&month),
+				Currency: fc.Currency,
 			})
 		}
 	}
@@ -146,13 +207,8 @@ func (h *Handler) buildSaveToSpendResponse(workspaceID uint, month types.YearMon
 	}
 
 	oneTimeCostDTOs := make([]OneTimeCostDTO, 0, len(oneTimeCosts))
-	for _, otc := range oneTimeCosts {
-		oneTimeCostDTOs = append(oneTimeCostDTOs, OneTimeCostDTO{
-			ID:     otc.ID,
-			Name:   otc.Name,
-			Amount: otc.Amount,
-			IsPaid: otc.IsPaid,
-		})
+	for i := range oneTimeCosts {
+		oneTimeCostDTOs = append(oneTimeCostDTOs, toOneTimeCostDTO(&oneTimeCosts[i]))
 	}
 
 	// Calculate safe-to-spend and pending total
@@ -161,7 +217,12 @@ func (h *Handler) buildSaveToSpendResponse(workspaceID uint, month types.YearMon
 		return nil, err
 	}
 
-	pendingTotal, err := h.service.GetPendingTotal(workspaceID, month)
+	pendingTotal, missingRates, err := h.service.GetPendingTotalWithMissingRates(workspaceID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	budgetRemaining, err := h.service.GetBudgetRemaining(workspaceID, month)
 	if err != nil {
 		return nil, err
 	}
@@ -174,17 +235,14 @@ func (h *Handler) buildSaveToSpendResponse(workspaceID uint, month types.YearMon
 		ExcludedFixedCosts: excludedFixedCosts,
 		OneTimeCosts:       oneTimeCostDTOs,
 		PendingTotal:       pendingTotal,
+		BudgetRemaining:    budgetRemaining,
+		MissingRates:       missingRates,
 	}, nil
 }
 
-// isValidForMonth checks if a fixed cost is valid for a given month
+// isValidForMonth checks if a fixed cost is due in a given month
 func (h *Handler) isValidForMonth(fc *cost.FixedCost, month *types.YearMonth) bool {
-	// Check validity period
-	if !types.IsRelevant(month, fc.From, fc.To) {
-		return false
-	}
-	// Check billing cycle
-	return slices.Contains(fc.DueMonth, month.Month)
+	return fc.Occurrences(month) > 0
 }
 
 // UpdateBalance updates the checking account balance
@@ -197,11 +255,28 @@ func (h *Handler) UpdateBalance(c *gin.Context) {
 		return
 	}
 
+	var before interface{}
+	if h.audit.Enabled() {
+		if workspace, err := h.repo.GetWorkspace(workspaceID); err == nil {
+			before = gin.H{"amount": workspace.SaveToSpendBalance}
+		}
+	}
+
 	if err := h.repo.UpdateSaveToSpendBalance(workspaceID, req.Amount); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update balance"})
 		return
 	}
 
+	h.audit.Record(audit.Change{
+		WorkspaceID: workspaceID,
+		Actor:       h.getUserID(c),
+		EntityType:  audit.EntitySaveToSpendBalance,
+		EntityID:    workspaceID,
+		Action:      audit.ActionUpdate,
+		Before:      before,
+		After:       gin.H{"amount": req.Amount},
+	})
+
 	// Return updated state
 	currentMonth := types.CurrentYearMonth()
 	response, err := h.buildSaveToSpendResponse(workspaceID, *currentMonth)
@@ -243,10 +318,11 @@ func (h *Handler) updatePaymentStatus(c *gin.Context, isPaid bool) {
 	// Update the status
 	status.IsPaid = isPaid
 	if isPaid {
-		now := types.CurrentYearMonth() // Use current time
-		_ = now                         // Placeholder - in real implementation, use time.Now()
+		now := time.Now()
+		status.PaidAt = &now
 	} else {
 		status.PaidAt = nil
+		status.TransactionID = nil
 	}
 
 	if err := h.repo.UpdatePaymentStatus(status); err != nil {
@@ -342,12 +418,19 @@ func (h *Handler) CreateOneTimeCost(c *gin.Context) {
 		return
 	}
 
+	code, err := currency.Normalize(req.Currency)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	currentMonth := types.CurrentYearMonth()
 
 	cost := &spend.OneTimePendingCost{
 		WorkspaceID: workspaceID,
 		Name:        req.Name,
 		Amount:      req.Amount,
+		Currency:    code,
 		Month:       *currentMonth,
 		IsPaid:      false,
 	}
@@ -357,6 +440,15 @@ func (h *Handler) CreateOneTimeCost(c *gin.Context) {
 		return
 	}
 
+	h.audit.Record(audit.Change{
+		WorkspaceID: workspaceID,
+		Actor:       h.getUserID(c),
+		EntityType:  audit.EntityOneTimeCost,
+		EntityID:    cost.ID,
+		Action:      audit.ActionCreate,
+		After:       toOneTimeCostDTO(cost),
+	})
+
 	// Return updated state
 	response, err := h.buildSaveToSpendResponse(workspaceID, *currentMonth)
 	if err != nil {
@@ -376,11 +468,27 @@ func (h *Handler) DeleteOneTimeCost(c *gin.Context) {
 		return
 	}
 
+	var before *spend.OneTimePendingCost
+	if h.audit.Enabled() {
+		before, _ = h.repo.GetOneTimeCost(costID, workspaceID)
+	}
+
 	if err := h.repo.DeleteOneTimeCost(costID, workspaceID); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "One-time cost not found"})
 		return
 	}
 
+	if before != nil {
+		h.audit.Record(audit.Change{
+			WorkspaceID: workspaceID,
+			Actor:       h.getUserID(c),
+			EntityType:  audit.EntityOneTimeCost,
+			EntityID:    costID,
+			Action:      audit.ActionDelete,
+			Before:      toOneTimeCostDTO(before),
+		})
+	}
+
 	// Return updated state
 	currentMonth := types.CurrentYearMonth()
 	response, err := h.buildSaveToSpendResponse(workspaceID, *currentMonth)
@@ -444,6 +552,7 @@ func (h *Handler) MarkOneTimeCostPending(c *gin.Context) {
 
 	// Update the status
 	cost.IsPaid = false
+	cost.TransactionID = nil
 	if err := h.repo.UpdateOneTimeCost(cost); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update cost"})
 		return
@@ -460,8 +569,134 @@ func (h *Handler) MarkOneTimeCostPending(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// PreviewReconcile matches the unpaid costs of the current month with the
+// imported bank transactions without changing anything.
+func (h *Handler) PreviewReconcile(c *gin.Context) {
+	h.reconcile(c, false)
+}
+
+// ApplyReconcile marks the costs with a clear matching transaction as paid.
+// Ambiguous matches are returned for review and stay pending.
+func (h *Handler) ApplyReconcile(c *gin.Context) {
+	h.reconcile(c, true)
+}
+
+func (h *Handler) reconcile(c *gin.Context, apply bool) {
+	workspaceID := h.getWorkspaceID(c)
+	currentMonth := types.CurrentYearMonth()
+
+	result, err := h.service.Reconcile(workspaceID, *currentMonth, apply)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reconcile transactions"})
+		return
+	}
+
+	response := ReconcileResponse{
+		Applied: apply,
+		Matches: make([]ReconcileMatchDTO, 0, len(result.Matches)),
+		Reviews: make([]ReconcileReviewDTO, 0, len(result.Reviews)),
+	}
+	for _, match := range result.Matches {
+		response.Matches = append(response.Matches, ReconcileMatchDTO{
+			Item:                  toReconcileItemDTO(match.Item),
+			ReconcileCandidateDTO: toReconcileCandidateDTO(match.Candidate),
+		})
+	}
+	for _, review := range result.Reviews {
+		candidates := make([]ReconcileCandidateDTO, 0, len(review.Candidates))
+		for _, candidate := range review.Candidates {
+			candidates = append(candidates, toReconcileCandidateDTO(candidate))
+		}
+		response.Reviews = append(response.Reviews, ReconcileReviewDTO{
+			Item:       toReconcileItemDTO(review.Item),
+			Candidates: candidates,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ConfirmMatch marks a cost of the current month paid by the transaction
+// chosen during review.
+func (h *Handler) ConfirmMatch(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	var req ConfirmMatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: kind, id and transactionId are required"})
+		return
+	}
+
+	currentMonth := types.CurrentYearMonth()
+
+	err := h.service.ConfirmMatch(workspaceID, *currentMonth, req.Kind, req.ID, req.TransactionID)
+	switch {
+	case err == nil:
+	case errors.Is(err, service.ErrUnknownKind):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, service.ErrTransactionMatched):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, service.ErrItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+		return
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to confirm match"})
+		return
+	}
+
+	// Return updated state
+	response, err := h.buildSaveToSpendResponse(workspaceID, *currentMonth)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load data"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // Helper functions
 
+func toReconcileItemDTO(item reconcile.Item) ReconcileItemDTO {
+	return ReconcileItemDTO{
+		Kind:     item.Kind,
+		ID:       item.ID,
+		Name:     item.Name,
+		Amount:   float64(item.AmountCents) / 100,
+		Currency: item.Currency,
+	}
+}
+
+func toReconcileCandidateDTO(candidate reconcile.Candidate) ReconcileCandidateDTO {
+	return ReconcileCandidateDTO{
+		Transaction: transaction_api.ToJsonTransaction(candidate.Transaction),
+		Score:       math.Round(candidate.Score*100) / 100,
+	}
+}
+
+func toOneTimeCostDTO(otc *spend.OneTimePendingCost) OneTimeCostDTO {
+	return OneTimeCostDTO{
+		ID:            otc.ID,
+		Name:          otc.Name,
+		Amount:        otc.Amount,
+		Currency:      otc.Currency,
+		IsPaid:        otc.IsPaid,
+		TransactionID: otc.TransactionID,
+	}
+}
+
+func (h *Handler) getUserID(c *gin.Context) uint {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return 0
+	}
+	return userID.(uint)
+}
+
 func (h *Handler) getWorkspaceID(c *gin.Context) uint {
 	workspaceID, exists := c.Get("workspace_id")
 	if !exists {
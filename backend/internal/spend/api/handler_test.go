@@ -6,13 +6,17 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/currency"
 	"wondee/finance-app-backend/internal/platform/types"
 	"wondee/finance-app-backend/internal/spend"
+	"wondee/finance-app-backend/internal/transaction"
 	"wondee/finance-app-backend/internal/workspace"
 )
 
@@ -101,6 +105,35 @@ func (m *MockSpendRepository) GetOneTimeCost(id uint, workspaceID uint) (*spend.
 	return args.Get(0).(*spend.OneTimePendingCost), args.Error(1)
 }
 
+func (m *MockSpendRepository) GetExchangeRates(workspaceID uint) ([]currency.ExchangeRate, error) {
+	args := m.Called(workspaceID)
+	return args.Get(0).([]currency.ExchangeRate), args.Error(1)
+}
+
+func (m *MockSpendRepository) GetTransactions(workspaceID uint, from, to time.Time) ([]transaction.Transaction, error) {
+	args := m.Called(workspaceID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]transaction.Transaction), args.Error(1)
+}
+
+func (m *MockSpendRepository) GetTransaction(id uint, workspaceID uint) (*transaction.Transaction, error) {
+	args := m.Called(id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockSpendRepository) GetMatchedTransactionIDs(workspaceID uint) ([]uint, error) {
+	args := m.Called(workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
 // MockCostRepository implements cost repository.Repository
 type MockCostRepository struct {
 	mock.Mock
@@ -116,12 +149,30 @@ func (m *MockCostRepository) LoadFixedCostsByUser(userID uint) *[]cost.FixedCost
 	return args.Get(0).(*[]cost.FixedCost)
 }
 
-func (m *MockCostRepository) SaveFixedObject(fixedCost *cost.FixedCost) {
-	m.Called(fixedCost)
+func (m *MockCostRepository) SaveFixedObject(fixedCost *cost.FixedCost) error {
+	return m.Called(fixedCost).Error(0)
+}
+
+func (m *MockCostRepository) DeleteFixedCost(id int, workspaceID uint) error {
+	return m.Called(id, workspaceID).Error(0)
+}
+
+func (m *MockCostRepository) GetFixedCost(id int, workspaceID uint) (*cost.FixedCost, error) {
+	args := m.Called(id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cost.FixedCost), args.Error(1)
+}
+
+func (m *MockCostRepository) SaveAmountRevision(revision *cost.AmountRevision) error {
+	args := m.Called(revision)
+	return args.Error(0)
 }
 
-func (m *MockCostRepository) DeleteFixedCost(id int, workspaceID uint) {
-	m.Called(id, workspaceID)
+func (m *MockCostRepository) DeleteAmountRevision(id uint, fixedCostID int) error {
+	args := m.Called(id, fixedCostID)
+	return args.Error(0)
 }
 
 func (m *MockCostRepository) LoadSpecialCosts(workspaceID uint) *[]cost.SpecialCost {
@@ -134,12 +185,32 @@ func (m *MockCostRepository) LoadSpecialCostsByUser(userID uint) *[]cost.Special
 	return args.Get(0).(*[]cost.SpecialCost)
 }
 
-func (m *MockCostRepository) SaveSpecialCost(costs *cost.SpecialCost) {
-	m.Called(costs)
+func (m *MockCostRepository) SaveSpecialCost(costs *cost.SpecialCost) error {
+	return m.Called(costs).Error(0)
+}
+
+func (m *MockCostRepository) DeleteSpecialCost(id int, workspaceID uint) error {
+	return m.Called(id, workspaceID).Error(0)
+}
+
+func (m *MockCostRepository) LoadCategories(workspaceID uint) ([]cost.Category, error) {
+	args := m.Called(workspaceID)
+	return args.Get(0).([]cost.Category), args.Error(1)
+}
+
+func (m *MockCostRepository) SaveCategory(category *cost.Category) error {
+	args := m.Called(category)
+	return args.Error(0)
+}
+
+func (m *MockCostRepository) DeleteCategory(id uint, workspaceID uint) error {
+	args := m.Called(id, workspaceID)
+	return args.Error(0)
 }
 
-func (m *MockCostRepository) DeleteSpecialCost(id int, workspaceID uint) {
-	m.Called(id, workspaceID)
+func (m *MockCostRepository) ImportCosts(fixedCosts []cost.FixedCost, specialCosts []cost.SpecialCost) error {
+	args := m.Called(fixedCosts, specialCosts)
+	return args.Error(0)
 }
 
 func setupTestRouter(handler *Handler) *gin.Engine {
@@ -157,7 +228,7 @@ func setupTestRouter(handler *Handler) *gin.Engine {
 func TestGetSaveToSpend_BasicCalculation(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 
@@ -198,7 +269,7 @@ func TestGetSaveToSpend_BasicCalculation(t *testing.T) {
 func TestGetSaveToSpend_NegativeResult(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 
@@ -237,7 +308,7 @@ func TestGetSaveToSpend_NegativeResult(t *testing.T) {
 func TestGetSaveToSpend_NoPendingCosts(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 
@@ -277,7 +348,7 @@ func TestGetSaveToSpend_NoPendingCosts(t *testing.T) {
 func TestUpdateBalance_Success(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 
@@ -312,7 +383,7 @@ func TestUpdateBalance_Success(t *testing.T) {
 func TestUpdateBalance_NegativeAmount(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 
@@ -350,7 +421,7 @@ func TestUpdateBalance_NegativeAmount(t *testing.T) {
 func TestIncludeFixedCost_Success(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 
@@ -385,7 +456,7 @@ func TestIncludeFixedCost_Success(t *testing.T) {
 func TestExcludeFixedCost_Success(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 
@@ -416,7 +487,7 @@ func TestExcludeFixedCost_Success(t *testing.T) {
 func TestExcludeFixedCost_NotFound(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 
@@ -438,7 +509,7 @@ func TestExcludeFixedCost_NotFound(t *testing.T) {
 func TestMarkFixedCostPaid_Success(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 
@@ -479,7 +550,7 @@ func TestMarkFixedCostPaid_Success(t *testing.T) {
 func TestMarkFixedCostPending_Success(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 
@@ -519,7 +590,7 @@ func TestMarkFixedCostPending_Success(t *testing.T) {
 func TestMarkFixedCostPaid_NotIncluded(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 
@@ -541,7 +612,7 @@ func TestMarkFixedCostPaid_NotIncluded(t *testing.T) {
 func TestGetSaveToSpend_IncludesAllFixedCosts(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 
@@ -584,7 +655,7 @@ func TestGetSaveToSpend_IncludesAllFixedCosts(t *testing.T) {
 func TestGetSaveToSpend_EmptyState(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 
@@ -624,7 +695,7 @@ func TestGetSaveToSpend_EmptyState(t *testing.T) {
 func TestCreateOneTimeCost_Success(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 
@@ -657,7 +728,7 @@ func TestCreateOneTimeCost_Success(t *testing.T) {
 func TestCreateOneTimeCost_ValidationError(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	router := setupTestRouter(handler)
 	router.POST("/save-to-spend/one-time-costs", handler.CreateOneTimeCost)
@@ -675,7 +746,7 @@ func TestCreateOneTimeCost_ValidationError(t *testing.T) {
 func TestDeleteOneTimeCost_Success(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 
@@ -704,7 +775,7 @@ func TestDeleteOneTimeCost_Success(t *testing.T) {
 func TestMarkOneTimeCostPaid_Success(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 
@@ -746,7 +817,7 @@ func TestMarkOneTimeCostPaid_Success(t *testing.T) {
 func TestSafeToSpendCalculation_WithSignedAmounts(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	handler := NewHandler(mockSpendRepo, mockCostRepo)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
 
 	var workspaceID uint = 1
 	checkingBalance := 1000000 // 10,000 EUR
@@ -799,3 +870,107 @@ func TestSafeToSpendCalculation_WithSignedAmounts(t *testing.T) {
 	assert.Less(t, response.SafeToSpend, response.CheckingBalance,
 		"SafeToSpend must be LESS than CheckingBalance when there are net expenses (negative pendingTotal)")
 }
+
+// ==================== Reconcile Tests ====================
+
+func TestPreviewReconcile_ReturnsMatchesAndReviews(t *testing.T) {
+	mockSpendRepo := new(MockSpendRepository)
+	mockCostRepo := new(MockCostRepository)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
+
+	var workspaceID uint = 1
+	month := *types.CurrentYearMonth()
+	firstOfMonth := time.Date(month.Year, time.Month(month.Month), 1, 0, 0, 0, 0, time.UTC)
+
+	mockSpendRepo.On("GetWorkspace", workspaceID).Return(&workspace.Workspace{ID: workspaceID, BaseCurrency: "EUR"}, nil)
+	mockSpendRepo.On("GetPaymentStatuses", workspaceID, month).Return([]spend.MonthlyPaymentStatus{
+		{FixedCostID: 1, IsPaid: false},
+		{FixedCostID: 2, IsPaid: false},
+	}, nil)
+	mockSpendRepo.On("GetOneTimeCosts", workspaceID, month).Return([]spend.OneTimePendingCost{}, nil)
+	mockCostRepo.On("LoadFixedCosts", workspaceID).Return(&[]cost.FixedCost{
+		{ID: 1, Name: "Rent", Amount: -1200},
+		{ID: 2, Name: "Gym", Amount: -30},
+	})
+	mockSpendRepo.On("GetTransactions", workspaceID, mock.Anything, mock.Anything).Return([]transaction.Transaction{
+		{ID: 20, BookingDate: firstOfMonth, AmountCents: -120000, Counterparty: "Rent Ltd"},
+		{ID: 21, BookingDate: firstOfMonth.AddDate(0, 0, 1), AmountCents: -3000, Counterparty: "Gym North"},
+		{ID: 22, BookingDate: firstOfMonth.AddDate(0, 0, 2), AmountCents: -3000, Counterparty: "Gym South"},
+	}, nil)
+	mockSpendRepo.On("GetMatchedTransactionIDs", workspaceID).Return([]uint{}, nil)
+
+	router := setupTestRouter(handler)
+	router.GET("/save-to-spend/reconcile", handler.PreviewReconcile)
+
+	req, _ := http.NewRequest("GET", "/save-to-spend/reconcile", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response ReconcileResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.False(t, response.Applied)
+	if assert.Len(t, response.Matches, 1) {
+		assert.Equal(t, "Rent", response.Matches[0].Item.Name)
+		assert.Equal(t, -1200.0, response.Matches[0].Item.Amount)
+		assert.Equal(t, uint(20), response.Matches[0].Transaction.ID)
+	}
+	if assert.Len(t, response.Reviews, 1) {
+		assert.Equal(t, "Gym", response.Reviews[0].Item.Name)
+		assert.Len(t, response.Reviews[0].Candidates, 2)
+	}
+	mockSpendRepo.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything)
+}
+
+func TestConfirmMatch_Success(t *testing.T) {
+	mockSpendRepo := new(MockSpendRepository)
+	mockCostRepo := new(MockCostRepository)
+	handler := NewHandler(mockSpendRepo, mockCostRepo, nil, nil)
+
+	var workspaceID uint = 1
+	bookingDate := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	status := &spend.MonthlyPaymentStatus{ID: 1, WorkspaceID: workspaceID, FixedCostID: 2}
+	mockSpendRepo.On("GetTransaction", uint(22), workspaceID).Return(&transaction.Transaction{ID: 22, BookingDate: bookingDate}, nil)
+	mockSpendRepo.On("GetMatchedTransactionIDs", workspaceID).Return([]uint{}, nil)
+	mockSpendRepo.On("GetPaymentStatus", workspaceID, 2, mock.Anything).Return(status, nil)
+	mockSpendRepo.On("UpdatePaymentStatus", status).Return(nil)
+	mockSpendRepo.On("GetWorkspace", workspaceID).Return(&workspace.Workspace{ID: workspaceID}, nil)
+	mockSpendRepo.On("GetPaymentStatuses", workspaceID, mock.Anything).Return([]spend.MonthlyPaymentStatus{*status}, nil)
+	mockSpendRepo.On("GetOneTimeCosts", workspaceID, mock.Anything).Return([]spend.OneTimePendingCost{}, nil)
+	mockCostRepo.On("LoadFixedCosts", workspaceID).Return(&[]cost.FixedCost{})
+
+	router := setupTestRouter(handler)
+	router.POST("/save-to-spend/reconcile/confirm", handler.ConfirmMatch)
+
+	body := []byte(`{"kind": "fixedCost", "id": 2, "transactionId": 22}`)
+	req, _ := http.NewRequest("POST", "/save-to-spend/reconcile/confirm", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, status.IsPaid)
+	assert.Equal(t, bookingDate, *status.PaidAt)
+	assert.Equal(t, uint(22), *status.TransactionID)
+}
+
+func TestConfirmMatch_TransactionNotFound(t *testing.T) {
+	mockSpendRepo := new(MockSpendRepository)
+	handler := NewHandler(mockSpendRepo, new(MockCostRepository), nil, nil)
+
+	mockSpendRepo.On("GetTransaction", uint(99), uint(1)).Return(nil, gorm.ErrRecordNotFound)
+
+	router := setupTestRouter(handler)
+	router.POST("/save-to-spend/reconcile/confirm", handler.ConfirmMatch)
+
+	body := []byte(`{"kind": "oneTimeCost", "id": 2, "transactionId": 99}`)
+	req, _ := http.NewRequest("POST", "/save-to-spend/reconcile/confirm", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -14,6 +14,10 @@ import (
 // - No record → cost is excluded from save-to-spend
 // - IsPaid=false → cost is pending (deducted from safe-to-spend)
 // - IsPaid=true → cost is paid (not deducted)
+//
+// Statuses of a fixed cost in the trash are kept, so restoring the cost also
+// restores its selection, but ignored as the cost itself is not loaded. They
+// are deleted together with the cost when it is purged.
 type MonthlyPaymentStatus struct {
 	ID          uint            `gorm:"primaryKey"`
 	WorkspaceID uint            `gorm:"not null;uniqueIndex:idx_mps_unique,priority:1"`
@@ -21,6 +25,9 @@ type MonthlyPaymentStatus struct {
 	Month       types.YearMonth `gorm:"type:string;not null;uniqueIndex:idx_mps_unique,priority:3"`
 	IsPaid      bool            `gorm:"default:false"`
 	PaidAt      *time.Time
+	// TransactionID links the bank transaction that paid the cost, if it
+	// was reconciled with the ledger.
+	TransactionID *uint `gorm:"index"`
 }
 
 // TableName specifies the table name for GORM
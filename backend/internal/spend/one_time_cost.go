@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"wondee/finance-app-backend/internal/platform/types"
+
+	"gorm.io/gorm"
 )
 
 // OneTimePendingCost represents a one-time pending cost (credit cards, pending purchases)
@@ -13,14 +15,20 @@ import (
 // 1. Created when user adds one-time cost
 // 2. IsPaid = true when marked as paid (removes from calculation)
 // 3. Automatically excluded when Month != current month (lazy cleanup)
+// 4. Moved to the trash when deleted (DeletedAt), until restored or purged
 type OneTimePendingCost struct {
 	ID          uint            `gorm:"primaryKey"`
 	WorkspaceID uint            `gorm:"not null;index:idx_otp_ws_month,priority:1"`
 	Name        string          `gorm:"not null"`
 	Amount      int             `gorm:"not null"` // Amount in cents
+	Currency    string          `gorm:"size:3"`   // Empty for the workspace's base currency
 	Month       types.YearMonth `gorm:"type:string;not null;index:idx_otp_ws_month,priority:2"`
 	IsPaid      bool            `gorm:"default:false"`
-	CreatedAt   time.Time
+	// TransactionID links the bank transaction that paid the cost, if it
+	// was reconciled with the ledger.
+	TransactionID *uint `gorm:"index"`
+	CreatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
 }
 
 // TableName specifies the table name for GORM
@@ -0,0 +1,223 @@
+// Package reconcile matches bank transactions to the pending items of
+// save-to-spend. Every pair of item and transaction gets a score from the
+// amount difference, the similarity of the item name to counterparty and
+// purpose, and the distance of the booking date to the item's month. Only
+// clear matches are meant to be applied automatically; the rest is left for
+// review.
+package reconcile
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/transaction"
+)
+
+// Kinds of items.
+const (
+	KindFixedCost   = "fixedCost"
+	KindOneTimeCost = "oneTimeCost"
+)
+
+// Weights of the partial scores; they add up to 1.
+const (
+	amountWeight = 0.5
+	nameWeight   = 0.35
+	dateWeight   = 0.15
+)
+
+// Options tune the matching.
+type Options struct {
+	// AmountTolerance is the allowed relative difference of the amounts.
+	AmountTolerance float64
+	// MinAmountToleranceCents is the allowed absolute difference, covering
+	// costs planned in whole units while banks book cents.
+	MinAmountToleranceCents int64
+	// WindowDays extends the month by days before and after, for payments
+	// booked early or late.
+	WindowDays int
+	// MinScore drops weaker candidates entirely.
+	MinScore float64
+	// AutoScore is the score a match needs to be applied automatically.
+	AutoScore float64
+	// AmbiguityMargin: a match is ambiguous if another candidate scores
+	// within this margin.
+	AmbiguityMargin float64
+}
+
+var DefaultOptions = Options{
+	AmountTolerance:         0.05,
+	MinAmountToleranceCents: 100,
+	WindowDays:              7,
+	MinScore:                0.5,
+	AutoScore:               0.8,
+	AmbiguityMargin:         0.1,
+}
+
+// Item is a pending save-to-spend entry. The amount is signed like
+// transactions: negative for expenses.
+type Item struct {
+	Kind        string
+	ID          int
+	Name        string
+	AmountCents int64
+	Currency    string // Empty for the workspace's base currency
+}
+
+type Candidate struct {
+	Transaction *transaction.Transaction
+	Score       float64
+}
+
+type Match struct {
+	Item Item
+	Candidate
+}
+
+// Review is an item whose best candidates are too weak or too close to each
+// other to decide automatically.
+type Review struct {
+	Item       Item
+	Candidates []Candidate
+}
+
+type Result struct {
+	Matches []Match
+	Reviews []Review
+}
+
+// Window returns the booking dates considered for a month.
+func Window(month types.YearMonth, options Options) (time.Time, time.Time) {
+	first := time.Date(month.Year, time.Month(month.Month), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1)
+	return first.AddDate(0, 0, -options.WindowDays), last.AddDate(0, 0, options.WindowDays)
+}
+
+// Reconcile assigns transactions to items, each transaction to at most one
+// item. Pairs are assigned greedily from the highest score; an assignment
+// becomes a match if it reaches AutoScore and no other open transaction
+// fits the item, nor another unmatched item the transaction, within
+// AmbiguityMargin. Otherwise the item goes to review with its candidates.
+// baseCurrency resolves empty currency codes.
+func Reconcile(items []Item, transactions []transaction.Transaction, month types.YearMonth, baseCurrency string, options Options) Result {
+	var pairs []pair
+	for i, item := range items {
+		for j := range transactions {
+			score, ok := Score(item, &transactions[j], month, baseCurrency, options)
+			if ok && score >= options.MinScore {
+				pairs = append(pairs, pair{i, j, score})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(a, b int) bool { return pairs[a].score > pairs[b].score })
+
+	byItem := make(map[int][]pair)
+	byTransaction := make(map[int][]pair)
+	for _, p := range pairs {
+		byItem[p.item] = append(byItem[p.item], p)
+		byTransaction[p.transaction] = append(byTransaction[p.transaction], p)
+	}
+
+	doneItems := make(map[int]bool)
+	matchedItems := make(map[int]bool)
+	usedTransactions := make(map[int]bool)
+	result := Result{Matches: make([]Match, 0), Reviews: make([]Review, 0)}
+
+	for _, p := range pairs {
+		if doneItems[p.item] || usedTransactions[p.transaction] {
+			continue
+		}
+		doneItems[p.item] = true
+
+		contested := func(other pair) bool {
+			return p.score-other.score < options.AmbiguityMargin
+		}
+		ambiguous := slices.ContainsFunc(byItem[p.item], func(other pair) bool {
+			return other.transaction != p.transaction && !usedTransactions[other.transaction] && contested(other)
+		}) || slices.ContainsFunc(byTransaction[p.transaction], func(other pair) bool {
+			return other.item != p.item && !matchedItems[other.item] && contested(other)
+		})
+
+		if p.score >= options.AutoScore && !ambiguous {
+			matchedItems[p.item] = true
+			usedTransactions[p.transaction] = true
+			result.Matches = append(result.Matches, Match{
+				Item:      items[p.item],
+				Candidate: Candidate{Transaction: &transactions[p.transaction], Score: p.score},
+			})
+			continue
+		}
+
+		review := Review{Item: items[p.item], Candidates: make([]Candidate, 0, maxCandidates)}
+		for _, other := range byItem[p.item] {
+			if !usedTransactions[other.transaction] && len(review.Candidates) < maxCandidates {
+				review.Candidates = append(review.Candidates, Candidate{Transaction: &transactions[other.transaction], Score: other.score})
+			}
+		}
+		result.Reviews = append(result.Reviews, review)
+	}
+
+	return result
+}
+
+type pair struct {
+	item        int
+	transaction int
+	score       float64
+}
+
+// maxCandidates limits the candidates offered for review.
+const maxCandidates = 3
+
+// Score rates how well a transaction pays an item, from 0 to 1. The second
+// result is false if they cannot match at all: other currency, other sign,
+// amount outside the tolerance or booking date outside the window.
+func Score(item Item, t *transaction.Transaction, month types.YearMonth, baseCurrency string, options Options) (float64, bool) {
+	if currencyOf(item.Currency, baseCurrency) != currencyOf(t.Currency, baseCurrency) {
+		return 0, false
+	}
+	if (item.AmountCents < 0) != (t.AmountCents < 0) {
+		return 0, false
+	}
+
+	tolerance := max(int64(math.Abs(float64(item.AmountCents))*options.AmountTolerance), options.MinAmountToleranceCents)
+	difference := abs(item.AmountCents - t.AmountCents)
+	if difference > tolerance {
+		return 0, false
+	}
+	amountScore := 1 - float64(difference)/float64(tolerance+1)
+
+	from, to := Window(month, options)
+	if t.BookingDate.Before(from) || t.BookingDate.After(to) {
+		return 0, false
+	}
+	dateScore := 1.0
+	first, last := Window(month, Options{})
+	if t.BookingDate.Before(first) {
+		dateScore = 1 - first.Sub(t.BookingDate).Hours()/24/float64(options.WindowDays+1)
+	} else if t.BookingDate.After(last) {
+		dateScore = 1 - t.BookingDate.Sub(last).Hours()/24/float64(options.WindowDays+1)
+	}
+
+	nameScore := transaction.NameSimilarity(item.Name, t.Counterparty+" "+t.Purpose)
+
+	return amountWeight*amountScore + nameWeight*nameScore + dateWeight*dateScore, true
+}
+
+func currencyOf(code, baseCurrency string) string {
+	if code == "" {
+		return strings.ToUpper(baseCurrency)
+	}
+	return strings.ToUpper(code)
+}
+
+func abs(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
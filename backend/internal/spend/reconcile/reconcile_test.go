@@ -0,0 +1,154 @@
+package reconcile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/transaction"
+)
+
+var march = types.YearMonth{Year: 2025, Month: 3}
+
+func booking(id uint, day int, cents int64, counterparty, purpose string) transaction.Transaction {
+	return transaction.Transaction{
+		ID:           id,
+		BookingDate:  time.Date(2025, 3, day, 0, 0, 0, 0, time.UTC),
+		AmountCents:  cents,
+		Counterparty: counterparty,
+		Purpose:      purpose,
+	}
+}
+
+func TestReconcile_MatchesByAmountAndName(t *testing.T) {
+	items := []Item{
+		{Kind: KindFixedCost, ID: 1, Name: "Rent", AmountCents: -120000},
+		{Kind: KindFixedCost, ID: 2, Name: "Netflix", AmountCents: -1299},
+		{Kind: KindOneTimeCost, ID: 7, Name: "Bike repair", AmountCents: -8000},
+	}
+	transactions := []transaction.Transaction{
+		booking(10, 3, -1299, "NETFLIX.COM", "Subscription"),
+		booking(11, 1, -120000, "Hausverwaltung Meier", "Rent March"),
+		booking(12, 14, -4550, "Supermarket", "Card payment"),
+	}
+
+	result := Reconcile(items, transactions, march, "EUR", DefaultOptions)
+
+	if assert.Len(t, result.Matches, 2) {
+		matched := map[int]uint{}
+		for _, match := range result.Matches {
+			matched[match.Item.ID] = match.Transaction.ID
+		}
+		assert.Equal(t, map[int]uint{1: 11, 2: 10}, matched)
+	}
+	assert.Empty(t, result.Reviews, "the bike repair has no candidate within the tolerance")
+}
+
+func TestReconcile_ToleratesCentsOnWholeAmounts(t *testing.T) {
+	items := []Item{{Kind: KindFixedCost, ID: 1, Name: "Electricity", AmountCents: -8500}}
+	transactions := []transaction.Transaction{booking(1, 15, -8537, "Stadtwerke", "Abschlag Electricity")}
+
+	result := Reconcile(items, transactions, march, "EUR", DefaultOptions)
+
+	assert.Len(t, result.Matches, 1)
+}
+
+func TestReconcile_AmbiguousCandidatesGoToReview(t *testing.T) {
+	items := []Item{{Kind: KindFixedCost, ID: 1, Name: "Gym", AmountCents: -3000}}
+	transactions := []transaction.Transaction{
+		booking(1, 2, -3000, "Gym Nord", "Membership"),
+		booking(2, 16, -3000, "Gym Süd", "Membership"),
+	}
+
+	result := Reconcile(items, transactions, march, "EUR", DefaultOptions)
+
+	assert.Empty(t, result.Matches)
+	if assert.Len(t, result.Reviews, 1) {
+		assert.Len(t, result.Reviews[0].Candidates, 2)
+	}
+}
+
+func TestReconcile_WeakCandidateGoesToReview(t *testing.T) {
+	// Amount fits, but nothing in the booking mentions the insurance.
+	items := []Item{{Kind: KindFixedCost, ID: 1, Name: "Car insurance", AmountCents: -4200}}
+	transactions := []transaction.Transaction{booking(1, 10, -4150, "Bakery", "Card payment")}
+
+	result := Reconcile(items, transactions, march, "EUR", DefaultOptions)
+
+	assert.Empty(t, result.Matches)
+	assert.Len(t, result.Reviews, 1)
+}
+
+func TestReconcile_UsesEveryTransactionOnce(t *testing.T) {
+	items := []Item{
+		{Kind: KindFixedCost, ID: 1, Name: "Phone", AmountCents: -2000},
+		{Kind: KindOneTimeCost, ID: 2, Name: "Phone case", AmountCents: -2000},
+	}
+	transactions := []transaction.Transaction{booking(1, 5, -2000, "Telco", "Phone bill")}
+
+	result := Reconcile(items, transactions, march, "EUR", DefaultOptions)
+
+	if assert.Len(t, result.Matches, 1) {
+		assert.Equal(t, 1, result.Matches[0].Item.ID)
+	}
+	assert.Empty(t, result.Reviews, "the phone case has no transaction left")
+}
+
+func TestReconcile_ItemsTiedForOneTransactionGoToReview(t *testing.T) {
+	items := []Item{
+		{Kind: KindFixedCost, ID: 1, Name: "Phone", AmountCents: -2000},
+		{Kind: KindOneTimeCost, ID: 2, Name: "Phone", AmountCents: -2000},
+	}
+	transactions := []transaction.Transaction{booking(1, 5, -2000, "Telco", "Phone bill")}
+
+	result := Reconcile(items, transactions, march, "EUR", DefaultOptions)
+
+	assert.Empty(t, result.Matches)
+	assert.Len(t, result.Reviews, 2)
+}
+
+func TestScore_RejectsImpossiblePairs(t *testing.T) {
+	item := Item{Kind: KindFixedCost, ID: 1, Name: "Rent", AmountCents: -120000}
+
+	tests := []struct {
+		name        string
+		transaction transaction.Transaction
+	}{
+		{"income for an expense", booking(1, 1, 120000, "Rent", "")},
+		{"amount outside tolerance", booking(1, 1, -100000, "Rent", "")},
+		{"other currency", transaction.Transaction{BookingDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), AmountCents: -120000, Currency: "CHF"}},
+		{"outside the window", transaction.Transaction{BookingDate: time.Date(2025, 4, 9, 0, 0, 0, 0, time.UTC), AmountCents: -120000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := Score(item, &tt.transaction, march, "EUR", DefaultOptions)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestScore_PrefersBookingsInsideTheMonth(t *testing.T) {
+	item := Item{Kind: KindFixedCost, ID: 1, Name: "Rent", AmountCents: -120000}
+	inside := booking(1, 31, -120000, "Rent", "")
+	late := transaction.Transaction{BookingDate: time.Date(2025, 4, 5, 0, 0, 0, 0, time.UTC), AmountCents: -120000, Counterparty: "Rent"}
+
+	insideScore, ok := Score(item, &inside, march, "EUR", DefaultOptions)
+	assert.True(t, ok)
+	lateScore, ok := Score(item, &late, march, "EUR", DefaultOptions)
+	assert.True(t, ok)
+
+	assert.InDelta(t, 1.0, insideScore, 0.01)
+	assert.Less(t, lateScore, insideScore)
+}
+
+func TestScore_BaseCurrencyMatchesEmptyCode(t *testing.T) {
+	item := Item{Kind: KindFixedCost, ID: 1, Name: "Rent", AmountCents: -120000}
+	t1 := booking(1, 1, -120000, "Rent", "")
+	t1.Currency = "EUR"
+
+	_, ok := Score(item, &t1, march, "eur", DefaultOptions)
+
+	assert.True(t, ok)
+}
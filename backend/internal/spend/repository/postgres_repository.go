@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"time"
+
+	"wondee/finance-app-backend/internal/currency"
 	"wondee/finance-app-backend/internal/platform/types"
 	"wondee/finance-app-backend/internal/spend"
+	"wondee/finance-app-backend/internal/transaction"
 	"wondee/finance-app-backend/internal/workspace"
 )
 
@@ -91,6 +95,7 @@ func (r *PostgresRepository) UpdateOneTimeCost(cost *spend.OneTimePendingCost) e
 	return r.DB.Save(cost).Error
 }
 
+// DeleteOneTimeCost moves the cost to the trash.
 func (r *PostgresRepository) DeleteOneTimeCost(id uint, workspaceID uint) error {
 	return r.DB.Where("id = ? AND workspace_id = ?", id, workspaceID).
 		Delete(&spend.OneTimePendingCost{}).Error
@@ -104,3 +109,55 @@ func (r *PostgresRepository) GetOneTimeCost(id uint, workspaceID uint) (*spend.O
 	}
 	return &cost, nil
 }
+
+// Exchange rate operations
+
+func (r *PostgresRepository) GetExchangeRates(workspaceID uint) ([]currency.ExchangeRate, error) {
+	var rates []currency.ExchangeRate
+	result := r.DB.Where("workspace_id = ?", workspaceID).Find(&rates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rates, nil
+}
+
+// Transaction operations
+
+// GetTransactions returns the transactions booked between from and to,
+// both inclusive, oldest first.
+func (r *PostgresRepository) GetTransactions(workspaceID uint, from, to time.Time) ([]transaction.Transaction, error) {
+	var transactions []transaction.Transaction
+	result := r.DB.Where("workspace_id = ? AND booking_date BETWEEN ? AND ?", workspaceID, from, to).
+		Order("booking_date, id").
+		Find(&transactions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return transactions, nil
+}
+
+func (r *PostgresRepository) GetTransaction(id uint, workspaceID uint) (*transaction.Transaction, error) {
+	var t transaction.Transaction
+	result := r.DB.Where("id = ? AND workspace_id = ?", id, workspaceID).First(&t)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &t, nil
+}
+
+// GetMatchedTransactionIDs returns the transactions already linked to a fixed
+// or one-time cost, in any month.
+func (r *PostgresRepository) GetMatchedTransactionIDs(workspaceID uint) ([]uint, error) {
+	var fixedCostIDs, oneTimeCostIDs []uint
+	if err := r.DB.Model(&spend.MonthlyPaymentStatus{}).
+		Where("workspace_id = ? AND transaction_id IS NOT NULL", workspaceID).
+		Pluck("transaction_id", &fixedCostIDs).Error; err != nil {
+		return nil, err
+	}
+	if err := r.DB.Model(&spend.OneTimePendingCost{}).
+		Where("workspace_id = ? AND transaction_id IS NOT NULL", workspaceID).
+		Pluck("transaction_id", &oneTimeCostIDs).Error; err != nil {
+		return nil, err
+	}
+	return append(fixedCostIDs, oneTimeCostIDs...), nil
+}
@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"time"
+
+	"wondee/finance-app-backend/internal/currency"
 	"wondee/finance-app-backend/internal/platform/types"
 	"wondee/finance-app-backend/internal/spend"
+	"wondee/finance-app-backend/internal/transaction"
 	"wondee/finance-app-backend/internal/workspace"
 
 	"gorm.io/gorm"
@@ -28,6 +32,14 @@ type Repository interface {
 	UpdateOneTimeCost(cost *spend.OneTimePendingCost) error
 	DeleteOneTimeCost(id uint, workspaceID uint) error
 	GetOneTimeCost(id uint, workspaceID uint) (*spend.OneTimePendingCost, error)
+
+	// Exchange rate operations
+	GetExchangeRates(workspaceID uint) ([]currency.ExchangeRate, error)
+
+	// Transaction operations
+	GetTransactions(workspaceID uint, from, to time.Time) ([]transaction.Transaction, error)
+	GetTransaction(id uint, workspaceID uint) (*transaction.Transaction, error)
+	GetMatchedTransactionIDs(workspaceID uint) ([]uint, error)
 }
 
 // PostgresRepository implements Repository using GORM
@@ -1,24 +1,46 @@
 package service
 
 import (
+	"errors"
+	"slices"
+
 	"wondee/finance-app-backend/internal/cost"
 	cost_repo "wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/currency"
 	"wondee/finance-app-backend/internal/platform/types"
 	"wondee/finance-app-backend/internal/spend"
+	"wondee/finance-app-backend/internal/spend/reconcile"
 	"wondee/finance-app-backend/internal/spend/repository"
+	"wondee/finance-app-backend/internal/transaction"
+	"wondee/finance-app-backend/internal/workspace"
+)
+
+var (
+	ErrUnknownKind        = errors.New("kind must be fixedCost or oneTimeCost")
+	ErrItemNotFound       = errors.New("cost not found or not included in save-to-spend")
+	ErrTransactionMatched = errors.New("transaction already matched to another cost")
 )
 
+// EnvelopeSource reports how much is left in the category budgets of a
+// month, in the base currency.
+type EnvelopeSource interface {
+	RemainingTotal(workspaceID uint, month types.YearMonth) (int, error)
+}
+
 // SpendService provides business logic for the save-to-spend feature
 type SpendService struct {
-	repo     repository.Repository
-	costRepo cost_repo.Repository
+	repo      repository.Repository
+	costRepo  cost_repo.Repository
+	envelopes EnvelopeSource
 }
 
-// NewSpendService creates a new SpendService instance
-func NewSpendService(repo repository.Repository, costRepo cost_repo.Repository) *SpendService {
+// NewSpendService creates a new SpendService instance; envelopes may be nil
+// if budgets are not available.
+func NewSpendService(repo repository.Repository, costRepo cost_repo.Repository, envelopes EnvelopeSource) *SpendService {
 	return &SpendService{
-		repo:     repo,
-		costRepo: costRepo,
+		repo:      repo,
+		costRepo:  costRepo,
+		envelopes: envelopes,
 	}
 }
 
@@ -77,24 +99,9 @@ func (s *SpendService) EnsureInitialized(workspaceID uint, month types.YearMonth
 	return nil
 }
 
-// isValidForMonth checks if a fixed cost is valid for a given month
+// isValidForMonth checks if a fixed cost is due in a given month
 func (s *SpendService) isValidForMonth(fc *cost.FixedCost, month *types.YearMonth) bool {
-	// Check validity period
-	if !types.IsRelevant(month, fc.From, fc.To) {
-		return false
-	}
-	// Check billing cycle - DueMonth contains the months when this cost is due
-	return s.containsMonth(fc.DueMonth, month.Month)
-}
-
-// containsMonth checks if the dueMonths slice contains the given month
-func (s *SpendService) containsMonth(dueMonths []int, month int) bool {
-	for _, m := range dueMonths {
-		if m == month {
-			return true
-		}
-	}
-	return false
+	return fc.Occurrences(month) > 0
 }
 
 // CalculateSafeToSpend calculates the safe-to-spend amount for a workspace
@@ -105,30 +112,56 @@ func (s *SpendService) CalculateSafeToSpend(workspaceID uint, month types.YearMo
 		return 0, err
 	}
 
-	// Get payment statuses for current month
-	statuses, err := s.repo.GetPaymentStatuses(workspaceID, month)
+	pendingTotal, err := s.GetPendingTotal(workspaceID, month)
 	if err != nil {
 		return 0, err
 	}
 
-	// Build a map of fixed cost IDs to their paid status
-	paidMap := make(map[int]bool)
-	for _, status := range statuses {
-		paidMap[status.FixedCostID] = status.IsPaid
+	budgetRemaining, err := s.GetBudgetRemaining(workspaceID, month)
+	if err != nil {
+		return 0, err
+	}
+
+	// Add pending amounts (negative expenses reduce balance, positive income increases it)
+	// and hold back what is left in the budget envelopes
+	return workspace.SaveToSpendBalance + pendingTotal - budgetRemaining, nil
+}
+
+// GetBudgetRemaining returns what is left in the budget envelopes of the
+// month, zero without budgets.
+func (s *SpendService) GetBudgetRemaining(workspaceID uint, month types.YearMonth) (int, error) {
+	if s.envelopes == nil {
+		return 0, nil
+	}
+	return s.envelopes.RemainingTotal(workspaceID, month)
+}
+
+// GetPendingTotal calculates the total pending amount (signed: negative = expenses, positive = income)
+// in the base currency of the workspace.
+func (s *SpendService) GetPendingTotal(workspaceID uint, month types.YearMonth) (int, error) {
+	total, _, err := s.GetPendingTotalWithMissingRates(workspaceID, month)
+	return total, err
+}
+
+// GetPendingTotalWithMissingRates is GetPendingTotal that also returns the
+// currencies of pending costs the workspace has no exchange rate for.
+func (s *SpendService) GetPendingTotalWithMissingRates(workspaceID uint, month types.YearMonth) (int, []string, error) {
+	// Get payment statuses for current month
+	statuses, err := s.repo.GetPaymentStatuses(workspaceID, month)
+	if err != nil {
+		return 0, nil, err
 	}
 
 	// Load fixed costs to get amounts
 	fixedCosts := s.costRepo.LoadFixedCosts(workspaceID)
 
-	// Sum pending fixed costs (included AND not paid)
-	// Amounts are signed: negative = expense, positive = income
-	pendingFixedCostsTotal := 0
+	// Collect pending fixed costs (included AND not paid)
+	var pendingFixedCosts []cost.FixedCost
 	for _, status := range statuses {
 		if !status.IsPaid {
-			// Find the cost amount
 			for _, fc := range *fixedCosts {
 				if fc.ID == status.FixedCostID {
-					pendingFixedCostsTotal += fc.Amount
+					pendingFixedCosts = append(pendingFixedCosts, fc)
 					break
 				}
 			}
@@ -138,58 +171,200 @@ func (s *SpendService) CalculateSafeToSpend(workspaceID uint, month types.YearMo
 	// Get one-time costs for current month
 	oneTimeCosts, err := s.repo.GetOneTimeCosts(workspaceID, month)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
+	}
+
+	var currencies []string
+	for _, fc := range pendingFixedCosts {
+		currencies = append(currencies, fc.Currency)
+	}
+	for _, otc := range oneTimeCosts {
+		currencies = append(currencies, otc.Currency)
+	}
+
+	converter, err := s.loadConverter(workspaceID, currencies)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Sum pending fixed costs (signed amounts)
+	pendingFixedCostsTotal := 0
+	for _, fc := range pendingFixedCosts {
+		pendingFixedCostsTotal += converter.Convert(pendingAmount(&fc, &month), fc.Currency, &month)
 	}
 
 	// Sum unpaid one-time costs (signed amounts)
 	unpaidOneTimeTotal := 0
 	for _, otc := range oneTimeCosts {
 		if !otc.IsPaid {
-			unpaidOneTimeTotal += otc.Amount
+			unpaidOneTimeTotal += converter.Convert(otc.Amount, otc.Currency, &month)
 		}
 	}
 
-	// Add pending amounts (negative expenses reduce balance, positive income increases it)
-	return workspace.SaveToSpendBalance + pendingFixedCostsTotal + unpaidOneTimeTotal, nil
+	return pendingFixedCostsTotal + unpaidOneTimeTotal, converter.Missing(), nil
 }
 
-// GetPendingTotal calculates the total pending amount (signed: negative = expenses, positive = income)
-func (s *SpendService) GetPendingTotal(workspaceID uint, month types.YearMonth) (int, error) {
-	// Get payment statuses for current month
+// loadConverter prepares the conversion into the workspace's base currency.
+// Workspace and rates are only read if one of the currencies is set, i.e. a
+// cost is not kept in the base currency.
+func (s *SpendService) loadConverter(workspaceID uint, currencies []string) (*currency.Converter, error) {
+	if !slices.ContainsFunc(currencies, func(code string) bool { return code != "" }) {
+		return currency.NewConverter("", nil), nil
+	}
+
+	return currency.LoadConverter(rateSource{s.repo}, workspaceID)
+}
+
+// rateSource provides the base currency and rates from the spend repository.
+type rateSource struct {
+	repository.Repository
+}
+
+func (r rateSource) GetWorkspaceByID(id uint) (*workspace.Workspace, error) {
+	return r.GetWorkspace(id)
+}
+
+// pendingAmount returns the amount of an included fixed cost for the month.
+// Costs due several times a month (e.g. weekly) count with every occurrence;
+// costs included manually outside their due month count once.
+func pendingAmount(fc *cost.FixedCost, month *types.YearMonth) int {
+	return fc.AmountAt(month) * max(fc.Occurrences(month), 1)
+}
+
+// Reconcile matches the unpaid costs of a month with the imported bank
+// transactions booked around it. Transactions already linked to a cost are
+// left out. With apply, the clear matches are marked paid at the booking
+// date; ambiguous ones are only returned for review.
+func (s *SpendService) Reconcile(workspaceID uint, month types.YearMonth, apply bool) (*reconcile.Result, error) {
+	workspace, err := s.repo.GetWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
 	statuses, err := s.repo.GetPaymentStatuses(workspaceID, month)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	oneTimeCosts, err := s.repo.GetOneTimeCosts(workspaceID, month)
+	if err != nil {
+		return nil, err
 	}
 
-	// Load fixed costs to get amounts
 	fixedCosts := s.costRepo.LoadFixedCosts(workspaceID)
+	fixedCostsByID := make(map[int]*cost.FixedCost, len(*fixedCosts))
+	for i := range *fixedCosts {
+		fixedCostsByID[(*fixedCosts)[i].ID] = &(*fixedCosts)[i]
+	}
 
-	// Sum pending fixed costs (signed amounts)
-	pendingFixedCostsTotal := 0
+	var items []reconcile.Item
 	for _, status := range statuses {
-		if !status.IsPaid {
-			for _, fc := range *fixedCosts {
-				if fc.ID == status.FixedCostID {
-					pendingFixedCostsTotal += fc.Amount
-					break
-				}
-			}
+		fc, ok := fixedCostsByID[status.FixedCostID]
+		if status.IsPaid || !ok {
+			continue
 		}
+		items = append(items, reconcile.Item{
+			Kind:        reconcile.KindFixedCost,
+			ID:          fc.ID,
+			Name:        fc.Name,
+			AmountCents: int64(pendingAmount(fc, &month)) * 100,
+			Currency:    fc.Currency,
+		})
+	}
+	for _, otc := range oneTimeCosts {
+		if otc.IsPaid {
+			continue
+		}
+		items = append(items, reconcile.Item{
+			Kind:        reconcile.KindOneTimeCost,
+			ID:          int(otc.ID),
+			Name:        otc.Name,
+			AmountCents: int64(otc.Amount) * 100,
+			Currency:    otc.Currency,
+		})
 	}
 
-	// Get one-time costs for current month
-	oneTimeCosts, err := s.repo.GetOneTimeCosts(workspaceID, month)
+	transactions, err := s.openTransactions(workspaceID, month)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	// Sum unpaid one-time costs (signed amounts)
-	unpaidOneTimeTotal := 0
-	for _, otc := range oneTimeCosts {
-		if !otc.IsPaid {
-			unpaidOneTimeTotal += otc.Amount
+	result := reconcile.Reconcile(items, transactions, month, workspace.BaseCurrency, reconcile.DefaultOptions)
+
+	if apply {
+		for _, match := range result.Matches {
+			if err := s.markPaid(workspaceID, month, match.Item.Kind, match.Item.ID, match.Transaction); err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	return pendingFixedCostsTotal + unpaidOneTimeTotal, nil
+	return &result, nil
+}
+
+// ConfirmMatch marks a cost paid by a transaction chosen during review.
+func (s *SpendService) ConfirmMatch(workspaceID uint, month types.YearMonth, kind string, itemID int, transactionID uint) error {
+	if kind != reconcile.KindFixedCost && kind != reconcile.KindOneTimeCost {
+		return ErrUnknownKind
+	}
+
+	t, err := s.repo.GetTransaction(transactionID, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	matched, err := s.repo.GetMatchedTransactionIDs(workspaceID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(matched, t.ID) {
+		return ErrTransactionMatched
+	}
+
+	return s.markPaid(workspaceID, month, kind, itemID, t)
+}
+
+// openTransactions returns the transactions in the matching window of the
+// month that are not linked to a cost yet.
+func (s *SpendService) openTransactions(workspaceID uint, month types.YearMonth) ([]transaction.Transaction, error) {
+	from, to := reconcile.Window(month, reconcile.DefaultOptions)
+	transactions, err := s.repo.GetTransactions(workspaceID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	matched, err := s.repo.GetMatchedTransactionIDs(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(transactions, func(t transaction.Transaction) bool {
+		return slices.Contains(matched, t.ID)
+	}), nil
+}
+
+func (s *SpendService) markPaid(workspaceID uint, month types.YearMonth, kind string, itemID int, t *transaction.Transaction) error {
+	paidAt := t.BookingDate
+	transactionID := t.ID
+
+	switch kind {
+	case reconcile.KindFixedCost:
+		status, err := s.repo.GetPaymentStatus(workspaceID, itemID, month)
+		if err != nil {
+			return ErrItemNotFound
+		}
+		status.IsPaid = true
+		status.PaidAt = &paidAt
+		status.TransactionID = &transactionID
+		return s.repo.UpdatePaymentStatus(status)
+	case reconcile.KindOneTimeCost:
+		otc, err := s.repo.GetOneTimeCost(uint(itemID), workspaceID)
+		if err != nil || otc.Month != month {
+			return ErrItemNotFound
+		}
+		otc.IsPaid = true
+		otc.TransactionID = &transactionID
+		return s.repo.UpdateOneTimeCost(otc)
+	default:
+		return ErrUnknownKind
+	}
 }
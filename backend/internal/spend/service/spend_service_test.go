@@ -2,13 +2,16 @@ package service_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/currency"
 	"wondee/finance-app-backend/internal/platform/types"
 	"wondee/finance-app-backend/internal/spend"
 	"wondee/finance-app-backend/internal/spend/service"
+	"wondee/finance-app-backend/internal/transaction"
 	"wondee/finance-app-backend/internal/workspace"
 )
 
@@ -97,6 +100,35 @@ func (m *MockSpendRepository) GetOneTimeCost(id uint, workspaceID uint) (*spend.
 	return args.Get(0).(*spend.OneTimePendingCost), args.Error(1)
 }
 
+func (m *MockSpendRepository) GetExchangeRates(workspaceID uint) ([]currency.ExchangeRate, error) {
+	args := m.Called(workspaceID)
+	return args.Get(0).([]currency.ExchangeRate), args.Error(1)
+}
+
+func (m *MockSpendRepository) GetTransactions(workspaceID uint, from, to time.Time) ([]transaction.Transaction, error) {
+	args := m.Called(workspaceID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]transaction.Transaction), args.Error(1)
+}
+
+func (m *MockSpendRepository) GetTransaction(id uint, workspaceID uint) (*transaction.Transaction, error) {
+	args := m.Called(id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockSpendRepository) GetMatchedTransactionIDs(workspaceID uint) ([]uint, error) {
+	args := m.Called(workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
 // MockCostRepository implements cost repository.Repository
 type MockCostRepository struct {
 	mock.Mock
@@ -112,12 +144,30 @@ func (m *MockCostRepository) LoadFixedCostsByUser(userID uint) *[]cost.FixedCost
 	return args.Get(0).(*[]cost.FixedCost)
 }
 
-func (m *MockCostRepository) SaveFixedObject(fixedCost *cost.FixedCost) {
-	m.Called(fixedCost)
+func (m *MockCostRepository) SaveFixedObject(fixedCost *cost.FixedCost) error {
+	return m.Called(fixedCost).Error(0)
+}
+
+func (m *MockCostRepository) DeleteFixedCost(id int, workspaceID uint) error {
+	return m.Called(id, workspaceID).Error(0)
+}
+
+func (m *MockCostRepository) GetFixedCost(id int, workspaceID uint) (*cost.FixedCost, error) {
+	args := m.Called(id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cost.FixedCost), args.Error(1)
+}
+
+func (m *MockCostRepository) SaveAmountRevision(revision *cost.AmountRevision) error {
+	args := m.Called(revision)
+	return args.Error(0)
 }
 
-func (m *MockCostRepository) DeleteFixedCost(id int, workspaceID uint) {
-	m.Called(id, workspaceID)
+func (m *MockCostRepository) DeleteAmountRevision(id uint, fixedCostID int) error {
+	args := m.Called(id, fixedCostID)
+	return args.Error(0)
 }
 
 func (m *MockCostRepository) LoadSpecialCosts(workspaceID uint) *[]cost.SpecialCost {
@@ -130,18 +180,38 @@ func (m *MockCostRepository) LoadSpecialCostsByUser(userID uint) *[]cost.Special
 	return args.Get(0).(*[]cost.SpecialCost)
 }
 
-func (m *MockCostRepository) SaveSpecialCost(costs *cost.SpecialCost) {
-	m.Called(costs)
+func (m *MockCostRepository) SaveSpecialCost(costs *cost.SpecialCost) error {
+	return m.Called(costs).Error(0)
 }
 
-func (m *MockCostRepository) DeleteSpecialCost(id int, workspaceID uint) {
-	m.Called(id, workspaceID)
+func (m *MockCostRepository) DeleteSpecialCost(id int, workspaceID uint) error {
+	return m.Called(id, workspaceID).Error(0)
+}
+
+func (m *MockCostRepository) LoadCategories(workspaceID uint) ([]cost.Category, error) {
+	args := m.Called(workspaceID)
+	return args.Get(0).([]cost.Category), args.Error(1)
+}
+
+func (m *MockCostRepository) SaveCategory(category *cost.Category) error {
+	args := m.Called(category)
+	return args.Error(0)
+}
+
+func (m *MockCostRepository) DeleteCategory(id uint, workspaceID uint) error {
+	args := m.Called(id, workspaceID)
+	return args.Error(0)
+}
+
+func (m *MockCostRepository) ImportCosts(fixedCosts []cost.FixedCost, specialCosts []cost.SpecialCost) error {
+	args := m.Called(fixedCosts, specialCosts)
+	return args.Error(0)
 }
 
 func TestCalculateSafeToSpend_WithPendingCosts(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	svc := service.NewSpendService(mockSpendRepo, mockCostRepo)
+	svc := service.NewSpendService(mockSpendRepo, mockCostRepo, nil)
 
 	var workspaceID uint = 1
 	month := types.YearMonth{Year: 2025, Month: 1}
@@ -180,7 +250,7 @@ func TestCalculateSafeToSpend_WithPendingCosts(t *testing.T) {
 func TestCalculateSafeToSpend_AllPaid(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	svc := service.NewSpendService(mockSpendRepo, mockCostRepo)
+	svc := service.NewSpendService(mockSpendRepo, mockCostRepo, nil)
 
 	var workspaceID uint = 1
 	month := types.YearMonth{Year: 2025, Month: 1}
@@ -211,7 +281,7 @@ func TestCalculateSafeToSpend_AllPaid(t *testing.T) {
 func TestCalculateSafeToSpend_NegativeResult(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	svc := service.NewSpendService(mockSpendRepo, mockCostRepo)
+	svc := service.NewSpendService(mockSpendRepo, mockCostRepo, nil)
 
 	var workspaceID uint = 1
 	month := types.YearMonth{Year: 2025, Month: 1}
@@ -243,7 +313,7 @@ func TestCalculateSafeToSpend_NegativeResult(t *testing.T) {
 func TestGetPendingTotal_SumsPendingCosts(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	svc := service.NewSpendService(mockSpendRepo, mockCostRepo)
+	svc := service.NewSpendService(mockSpendRepo, mockCostRepo, nil)
 
 	var workspaceID uint = 1
 	month := types.YearMonth{Year: 2025, Month: 1}
@@ -277,7 +347,7 @@ func TestGetPendingTotal_SumsPendingCosts(t *testing.T) {
 func TestEnsureInitialized_AlreadyInitialized(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	svc := service.NewSpendService(mockSpendRepo, mockCostRepo)
+	svc := service.NewSpendService(mockSpendRepo, mockCostRepo, nil)
 
 	var workspaceID uint = 1
 	month := types.YearMonth{Year: 2025, Month: 1}
@@ -294,7 +364,7 @@ func TestEnsureInitialized_AlreadyInitialized(t *testing.T) {
 func TestEnsureInitialized_CopiesFromPreviousMonth(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	svc := service.NewSpendService(mockSpendRepo, mockCostRepo)
+	svc := service.NewSpendService(mockSpendRepo, mockCostRepo, nil)
 
 	var workspaceID uint = 1
 	month := types.YearMonth{Year: 2025, Month: 2}
@@ -323,7 +393,7 @@ func TestEnsureInitialized_CopiesFromPreviousMonth(t *testing.T) {
 func TestCalculateSafeToSpend_WithExpenses(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	svc := service.NewSpendService(mockSpendRepo, mockCostRepo)
+	svc := service.NewSpendService(mockSpendRepo, mockCostRepo, nil)
 
 	var workspaceID uint = 1
 	month := types.YearMonth{Year: 2025, Month: 1}
@@ -359,7 +429,7 @@ func TestCalculateSafeToSpend_WithExpenses(t *testing.T) {
 func TestCalculateSafeToSpend_WithMixedIncomeAndExpenses(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	svc := service.NewSpendService(mockSpendRepo, mockCostRepo)
+	svc := service.NewSpendService(mockSpendRepo, mockCostRepo, nil)
 
 	var workspaceID uint = 1
 	month := types.YearMonth{Year: 2025, Month: 1}
@@ -397,7 +467,7 @@ func TestCalculateSafeToSpend_WithMixedIncomeAndExpenses(t *testing.T) {
 func TestGetPendingTotal_WithExpenses(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	svc := service.NewSpendService(mockSpendRepo, mockCostRepo)
+	svc := service.NewSpendService(mockSpendRepo, mockCostRepo, nil)
 
 	var workspaceID uint = 1
 	month := types.YearMonth{Year: 2025, Month: 1}
@@ -424,7 +494,7 @@ func TestGetPendingTotal_WithExpenses(t *testing.T) {
 func TestGetPendingTotal_WithMixedIncomeAndExpenses(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	svc := service.NewSpendService(mockSpendRepo, mockCostRepo)
+	svc := service.NewSpendService(mockSpendRepo, mockCostRepo, nil)
 
 	var workspaceID uint = 1
 	month := types.YearMonth{Year: 2025, Month: 1}
@@ -452,7 +522,7 @@ func TestGetPendingTotal_WithMixedIncomeAndExpenses(t *testing.T) {
 func TestEnsureInitialized_CreatesForAllValidCosts_FirstTime(t *testing.T) {
 	mockSpendRepo := new(MockSpendRepository)
 	mockCostRepo := new(MockCostRepository)
-	svc := service.NewSpendService(mockSpendRepo, mockCostRepo)
+	svc := service.NewSpendService(mockSpendRepo, mockCostRepo, nil)
 
 	var workspaceID uint = 1
 	month := types.YearMonth{Year: 2025, Month: 2}
@@ -481,3 +551,180 @@ func TestEnsureInitialized_CreatesForAllValidCosts_FirstTime(t *testing.T) {
 	assert.NoError(t, err)
 	mockSpendRepo.AssertNumberOfCalls(t, "CreatePaymentStatus", 1)
 }
+
+func TestGetPendingTotal_ConvertsForeignCurrencies(t *testing.T) {
+	mockSpendRepo := new(MockSpendRepository)
+	mockCostRepo := new(MockCostRepository)
+	svc := service.NewSpendService(mockSpendRepo, mockCostRepo, nil)
+
+	var workspaceID uint = 1
+	month := types.YearMonth{Year: 2025, Month: 1}
+
+	mockSpendRepo.On("GetPaymentStatuses", workspaceID, month).Return([]spend.MonthlyPaymentStatus{
+		{FixedCostID: 1, IsPaid: false},
+		{FixedCostID: 2, IsPaid: false},
+	}, nil)
+
+	fixedCosts := []cost.FixedCost{
+		{ID: 1, Name: "Rent", Amount: -100000},
+		{ID: 2, Name: "Insurance", Amount: -20000, Currency: "CHF"},
+	}
+	mockCostRepo.On("LoadFixedCosts", workspaceID).Return(&fixedCosts)
+
+	mockSpendRepo.On("GetOneTimeCosts", workspaceID, month).Return([]spend.OneTimePendingCost{
+		{ID: 1, Amount: 10000, Currency: "USD", IsPaid: false},
+	}, nil)
+	mockSpendRepo.On("GetWorkspace", workspaceID).Return(&workspace.Workspace{ID: workspaceID, BaseCurrency: "EUR"}, nil)
+	mockSpendRepo.On("GetExchangeRates", workspaceID).Return([]currency.ExchangeRate{
+		{WorkspaceID: workspaceID, Currency: "CHF", Rate: 1.05, ValidFrom: month},
+		{WorkspaceID: workspaceID, Currency: "USD", Rate: 0.9, ValidFrom: month},
+	}, nil)
+
+	pendingTotal, err := svc.GetPendingTotal(workspaceID, month)
+
+	assert.NoError(t, err)
+	// -100000 + (-20000 * 1.05) + (10000 * 0.9)
+	assert.Equal(t, -112000, pendingTotal)
+}
+
+func TestReconcile_AppliesClearMatches(t *testing.T) {
+	mockSpendRepo := new(MockSpendRepository)
+	mockCostRepo := new(MockCostRepository)
+	svc := service.NewSpendService(mockSpendRepo, mockCostRepo, nil)
+
+	var workspaceID uint = 1
+	month := types.YearMonth{Year: 2025, Month: 3}
+
+	mockSpendRepo.On("GetWorkspace", workspaceID).Return(&workspace.Workspace{ID: workspaceID, BaseCurrency: "EUR"}, nil)
+	mockSpendRepo.On("GetPaymentStatuses", workspaceID, month).Return([]spend.MonthlyPaymentStatus{
+		{FixedCostID: 1, IsPaid: false},
+		{FixedCostID: 2, IsPaid: true},
+	}, nil)
+	mockSpendRepo.On("GetOneTimeCosts", workspaceID, month).Return([]spend.OneTimePendingCost{
+		{ID: 5, Name: "Concert tickets", Amount: -90, Month: month},
+	}, nil)
+	mockCostRepo.On("LoadFixedCosts", workspaceID).Return(&[]cost.FixedCost{
+		{ID: 1, Name: "Rent", Amount: -1200},
+		{ID: 2, Name: "Internet", Amount: -40},
+	})
+
+	rentPaidAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	mockSpendRepo.On("GetTransactions", workspaceID, mock.Anything, mock.Anything).Return([]transaction.Transaction{
+		{ID: 20, BookingDate: rentPaidAt, AmountCents: -120000, Counterparty: "Rent Ltd"},
+		{ID: 21, BookingDate: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC), AmountCents: -4000, Counterparty: "Internet Provider"},
+		{ID: 22, BookingDate: time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC), AmountCents: -9000, Purpose: "Concert tickets"},
+	}, nil)
+	// The internet bill was matched by an earlier run.
+	mockSpendRepo.On("GetMatchedTransactionIDs", workspaceID).Return([]uint{21}, nil)
+
+	status := &spend.MonthlyPaymentStatus{WorkspaceID: workspaceID, FixedCostID: 1, Month: month}
+	mockSpendRepo.On("GetPaymentStatus", workspaceID, 1, month).Return(status, nil)
+	mockSpendRepo.On("UpdatePaymentStatus", status).Return(nil)
+	oneTimeCost := &spend.OneTimePendingCost{ID: 5, WorkspaceID: workspaceID, Name: "Concert tickets", Amount: -90, Month: month}
+	mockSpendRepo.On("GetOneTimeCost", uint(5), workspaceID).Return(oneTimeCost, nil)
+	mockSpendRepo.On("UpdateOneTimeCost", oneTimeCost).Return(nil)
+
+	result, err := svc.Reconcile(workspaceID, month, true)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Matches, 2)
+	assert.Empty(t, result.Reviews)
+
+	assert.True(t, status.IsPaid)
+	assert.Equal(t, rentPaidAt, *status.PaidAt)
+	assert.Equal(t, uint(20), *status.TransactionID)
+	assert.True(t, oneTimeCost.IsPaid)
+	assert.Equal(t, uint(22), *oneTimeCost.TransactionID)
+}
+
+func TestReconcile_PreviewChangesNothing(t *testing.T) {
+	mockSpendRepo := new(MockSpendRepository)
+	mockCostRepo := new(MockCostRepository)
+	svc := service.NewSpendService(mockSpendRepo, mockCostRepo, nil)
+
+	var workspaceID uint = 1
+	month := types.YearMonth{Year: 2025, Month: 3}
+
+	mockSpendRepo.On("GetWorkspace", workspaceID).Return(&workspace.Workspace{ID: workspaceID, BaseCurrency: "EUR"}, nil)
+	mockSpendRepo.On("GetPaymentStatuses", workspaceID, month).Return([]spend.MonthlyPaymentStatus{
+		{FixedCostID: 1, IsPaid: false},
+	}, nil)
+	mockSpendRepo.On("GetOneTimeCosts", workspaceID, month).Return([]spend.OneTimePendingCost{}, nil)
+	mockCostRepo.On("LoadFixedCosts", workspaceID).Return(&[]cost.FixedCost{
+		{ID: 1, Name: "Rent", Amount: -1200},
+	})
+	mockSpendRepo.On("GetTransactions", workspaceID, mock.Anything, mock.Anything).Return([]transaction.Transaction{
+		{ID: 20, BookingDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), AmountCents: -120000, Counterparty: "Rent Ltd"},
+	}, nil)
+	mockSpendRepo.On("GetMatchedTransactionIDs", workspaceID).Return([]uint{}, nil)
+
+	result, err := svc.Reconcile(workspaceID, month, false)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Matches, 1)
+	mockSpendRepo.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything)
+}
+
+func TestConfirmMatch_RejectsMatchedTransaction(t *testing.T) {
+	mockSpendRepo := new(MockSpendRepository)
+	mockCostRepo := new(MockCostRepository)
+	svc := service.NewSpendService(mockSpendRepo, mockCostRepo, nil)
+
+	var workspaceID uint = 1
+	month := types.YearMonth{Year: 2025, Month: 3}
+
+	mockSpendRepo.On("GetTransaction", uint(20), workspaceID).Return(&transaction.Transaction{ID: 20}, nil)
+	mockSpendRepo.On("GetMatchedTransactionIDs", workspaceID).Return([]uint{20}, nil)
+
+	err := svc.ConfirmMatch(workspaceID, month, "fixedCost", 1, 20)
+
+	assert.ErrorIs(t, err, service.ErrTransactionMatched)
+	mockSpendRepo.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything)
+}
+
+func TestConfirmMatch_UnknownKind(t *testing.T) {
+	svc := service.NewSpendService(new(MockSpendRepository), new(MockCostRepository), nil)
+
+	err := svc.ConfirmMatch(1, types.YearMonth{Year: 2025, Month: 3}, "salary", 1, 20)
+
+	assert.ErrorIs(t, err, service.ErrUnknownKind)
+}
+
+// MockEnvelopeSource implements service.EnvelopeSource
+type MockEnvelopeSource struct {
+	mock.Mock
+}
+
+func (m *MockEnvelopeSource) RemainingTotal(workspaceID uint, month types.YearMonth) (int, error) {
+	args := m.Called(workspaceID, month)
+	return args.Int(0), args.Error(1)
+}
+
+func TestCalculateSafeToSpend_HoldsBackBudgetEnvelopes(t *testing.T) {
+	mockSpendRepo := new(MockSpendRepository)
+	mockCostRepo := new(MockCostRepository)
+	mockEnvelopes := new(MockEnvelopeSource)
+	svc := service.NewSpendService(mockSpendRepo, mockCostRepo, mockEnvelopes)
+
+	var workspaceID uint = 1
+	month := types.YearMonth{Year: 2025, Month: 1}
+
+	mockSpendRepo.On("GetWorkspace", workspaceID).Return(&workspace.Workspace{
+		ID:                 workspaceID,
+		SaveToSpendBalance: 2000,
+	}, nil)
+	mockSpendRepo.On("GetPaymentStatuses", workspaceID, month).Return([]spend.MonthlyPaymentStatus{
+		{FixedCostID: 1, IsPaid: false},
+	}, nil)
+	fixedCosts := []cost.FixedCost{{ID: 1, Name: "Rent", Amount: -800}}
+	mockCostRepo.On("LoadFixedCosts", workspaceID).Return(&fixedCosts)
+	mockSpendRepo.On("GetOneTimeCosts", workspaceID, month).Return([]spend.OneTimePendingCost{}, nil)
+	mockEnvelopes.On("RemainingTotal", workspaceID, month).Return(350, nil)
+
+	safeToSpend, err := svc.CalculateSafeToSpend(workspaceID, month)
+
+	assert.NoError(t, err)
+	// 2000 - 800 - 350 left for groceries and fuel
+	assert.Equal(t, 850, safeToSpend)
+	mockEnvelopes.AssertExpectations(t)
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"wondee/finance-app-backend/internal/account"
+	"wondee/finance-app-backend/internal/cost"
+
+	"gorm.io/gorm"
+)
+
+type AccountRepository interface {
+	GetAccounts(workspaceID uint) ([]account.Account, error)
+	SaveAccount(a *account.Account) error
+	DeleteAccount(id uint, workspaceID uint) error
+}
+
+func (r *GormRepository) GetAccounts(workspaceID uint) ([]account.Account, error) {
+	var accounts []account.Account
+	err := r.DB.Where("workspace_id = ?", workspaceID).Find(&accounts).Error
+	if err != nil {
+		return nil, err
+	}
+	account.Sort(accounts)
+	return accounts, nil
+}
+
+func (r *GormRepository) SaveAccount(a *account.Account) error {
+	if a.ID == 0 {
+		return r.DB.Create(a).Error
+	}
+	result := r.DB.Model(a).Where("workspace_id = ?", a.WorkspaceID).
+		Select("Name", "Type", "Balance", "Currency").Updates(a)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteAccount removes the account; costs booked to it, including those in
+// the trash, fall back to the default account. Transfers to the account
+// become plain payments of their source account.
+func (r *GormRepository) DeleteAccount(id uint, workspaceID uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND workspace_id = ?", id, workspaceID).Delete(&account.Account{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Unscoped().Model(&cost.FixedCost{}).
+			Where("account_id = ? AND workspace_id = ?", id, workspaceID).
+			Update("account_id", nil).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Model(&cost.SpecialCost{}).
+			Where("account_id = ? AND workspace_id = ?", id, workspaceID).
+			Update("account_id", nil).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Model(&cost.FixedCost{}).
+			Where("target_account_id = ? AND workspace_id = ?", id, workspaceID).
+			Update("target_account_id", nil).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&cost.SpecialCost{}).
+			Where("target_account_id = ? AND workspace_id = ?", id, workspaceID).
+			Update("target_account_id", nil).Error
+	})
+}
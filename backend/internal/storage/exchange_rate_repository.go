@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"wondee/finance-app-backend/internal/currency"
+
+	"gorm.io/gorm"
+)
+
+type ExchangeRateRepository interface {
+	GetExchangeRates(workspaceID uint) ([]currency.ExchangeRate, error)
+	SaveExchangeRate(rate *currency.ExchangeRate) error
+	DeleteExchangeRate(id uint, workspaceID uint) error
+}
+
+func (r *GormRepository) GetExchangeRates(workspaceID uint) ([]currency.ExchangeRate, error) {
+	var rates []currency.ExchangeRate
+	err := r.DB.Where("workspace_id = ?", workspaceID).Find(&rates).Error
+	if err != nil {
+		return nil, err
+	}
+	currency.SortRates(rates)
+	return rates, nil
+}
+
+// SaveExchangeRate replaces an existing rate of the same currency and month.
+func (r *GormRepository) SaveExchangeRate(rate *currency.ExchangeRate) error {
+	validFrom, _ := rate.ValidFrom.Value()
+
+	var existing currency.ExchangeRate
+	err := r.DB.Where("workspace_id = ? AND currency = ? AND valid_from = ?", rate.WorkspaceID, rate.Currency, validFrom).First(&existing).Error
+	if err == nil {
+		rate.ID = existing.ID
+		rate.CreatedAt = existing.CreatedAt
+		return r.DB.Save(rate).Error
+	} else if err == gorm.ErrRecordNotFound {
+		return r.DB.Create(rate).Error
+	}
+
+	return err
+}
+
+func (r *GormRepository) DeleteExchangeRate(id uint, workspaceID uint) error {
+	result := r.DB.Where("id = ? AND workspace_id = ?", id, workspaceID).Delete(&currency.ExchangeRate{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
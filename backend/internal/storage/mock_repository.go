@@ -3,7 +3,9 @@ package storage
 import (
 	"errors"
 	"sort"
+	"wondee/finance-app-backend/internal/account"
 	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/currency"
 	"wondee/finance-app-backend/internal/user"
 	"wondee/finance-app-backend/internal/wealth"
 	"wondee/finance-app-backend/internal/workspace"
@@ -15,12 +17,18 @@ import (
 type MockRepository struct {
 	FixedCosts      []cost.FixedCost
 	SpecialCosts    []cost.SpecialCost
+	Categories      []cost.Category
 	Users           []user.User
 	WealthProfiles  []wealth.WealthProfile
 	Workspaces      []workspace.Workspace
 	Invites         []workspace.Invite
+	ExchangeRates   []currency.ExchangeRate
+	Accounts        []account.Account
 	nextWorkspaceID uint
 	nextInviteID    uint
+	nextRevisionID  uint
+	nextRateID      uint
+	nextAccountID   uint
 }
 
 func (m *MockRepository) CreateWorkspace(ws *workspace.Workspace) error {
@@ -61,6 +69,114 @@ func (m *MockRepository) UpdateWorkspaceCurrentAmount(workspaceID uint, amount i
 	return errors.New("workspace not found")
 }
 
+func (m *MockRepository) UpdateWorkspaceBaseCurrency(workspaceID uint, code string) error {
+	for i, w := range m.Workspaces {
+		if w.ID == workspaceID {
+			m.Workspaces[i].BaseCurrency = code
+			return nil
+		}
+	}
+	return errors.New("workspace not found")
+}
+
+func (m *MockRepository) UpdateWorkspaceLowBalanceThreshold(workspaceID uint, threshold int) error {
+	for i, w := range m.Workspaces {
+		if w.ID == workspaceID {
+			m.Workspaces[i].LowBalanceThreshold = threshold
+			return nil
+		}
+	}
+	return errors.New("workspace not found")
+}
+
+func (m *MockRepository) GetExchangeRates(workspaceID uint) ([]currency.ExchangeRate, error) {
+	var result []currency.ExchangeRate
+	for _, r := range m.ExchangeRates {
+		if r.WorkspaceID == workspaceID {
+			result = append(result, r)
+		}
+	}
+	currency.SortRates(result)
+	return result, nil
+}
+
+func (m *MockRepository) SaveExchangeRate(rate *currency.ExchangeRate) error {
+	for i, r := range m.ExchangeRates {
+		if r.WorkspaceID == rate.WorkspaceID && r.Currency == rate.Currency && r.ValidFrom == rate.ValidFrom {
+			rate.ID = r.ID
+			m.ExchangeRates[i] = *rate
+			return nil
+		}
+	}
+	m.nextRateID++
+	rate.ID = m.nextRateID
+	m.ExchangeRates = append(m.ExchangeRates, *rate)
+	return nil
+}
+
+func (m *MockRepository) DeleteExchangeRate(id uint, workspaceID uint) error {
+	for i, r := range m.ExchangeRates {
+		if r.ID == id && r.WorkspaceID == workspaceID {
+			m.ExchangeRates = append(m.ExchangeRates[:i], m.ExchangeRates[i+1:]...)
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (m *MockRepository) GetAccounts(workspaceID uint) ([]account.Account, error) {
+	var result []account.Account
+	for _, a := range m.Accounts {
+		if a.WorkspaceID == workspaceID {
+			result = append(result, a)
+		}
+	}
+	account.Sort(result)
+	return result, nil
+}
+
+func (m *MockRepository) SaveAccount(a *account.Account) error {
+	if a.ID == 0 {
+		m.nextAccountID++
+		a.ID = m.nextAccountID
+		m.Accounts = append(m.Accounts, *a)
+		return nil
+	}
+	for i, existing := range m.Accounts {
+		if existing.ID == a.ID && existing.WorkspaceID == a.WorkspaceID {
+			m.Accounts[i] = *a
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (m *MockRepository) DeleteAccount(id uint, workspaceID uint) error {
+	for i, a := range m.Accounts {
+		if a.ID == id && a.WorkspaceID == workspaceID {
+			m.Accounts = append(m.Accounts[:i], m.Accounts[i+1:]...)
+			for j, c := range m.FixedCosts {
+				if c.AccountID != nil && *c.AccountID == id && c.WorkspaceID == workspaceID {
+					m.FixedCosts[j].AccountID = nil
+				}
+				if c.TargetAccountID != nil && *c.TargetAccountID == id && c.WorkspaceID == workspaceID {
+					m.FixedCosts[j].TargetAccountID = nil
+				}
+			}
+			for j, c := range m.SpecialCosts {
+				if c.AccountID != nil && *c.AccountID == id && c.WorkspaceID == workspaceID {
+					m.SpecialCosts[j].AccountID = nil
+				}
+				if c.TargetAccountID != nil && *c.TargetAccountID == id && c.WorkspaceID == workspaceID {
+					m.SpecialCosts[j].TargetAccountID = nil
+				}
+			}
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
 func (m *MockRepository) CreateInvite(invite *workspace.Invite) error {
 	if invite.ID == 0 {
 		m.nextInviteID++
@@ -138,10 +254,11 @@ func (m *MockRepository) LoadFixedCostsByUser(userID uint) *[]cost.FixedCost {
 	return &filtered
 }
 
-func (m *MockRepository) SaveFixedObject(cost *cost.FixedCost) {
+func (m *MockRepository) SaveFixedObject(cost *cost.FixedCost) error {
 	found := false
 	for i, c := range m.FixedCosts {
 		if c.ID == cost.ID && cost.ID != 0 {
+			cost.Revisions = c.Revisions // stored separately in the database
 			m.FixedCosts[i] = *cost
 			found = true
 			break
@@ -153,9 +270,10 @@ func (m *MockRepository) SaveFixedObject(cost *cost.FixedCost) {
 		}
 		m.FixedCosts = append(m.FixedCosts, *cost)
 	}
+	return nil
 }
 
-func (m *MockRepository) DeleteFixedCost(id int, workspaceID uint) {
+func (m *MockRepository) DeleteFixedCost(id int, workspaceID uint) error {
 	var newCosts []cost.FixedCost
 	for _, c := range m.FixedCosts {
 		if c.ID != id || c.WorkspaceID != workspaceID {
@@ -163,6 +281,51 @@ func (m *MockRepository) DeleteFixedCost(id int, workspaceID uint) {
 		}
 	}
 	m.FixedCosts = newCosts
+	return nil
+}
+
+func (m *MockRepository) GetFixedCost(id int, workspaceID uint) (*cost.FixedCost, error) {
+	for _, c := range m.FixedCosts {
+		if c.ID == id && c.WorkspaceID == workspaceID {
+			return &c, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockRepository) SaveAmountRevision(revision *cost.AmountRevision) error {
+	for i, c := range m.FixedCosts {
+		if c.ID != revision.FixedCostID {
+			continue
+		}
+		for j, r := range c.Revisions {
+			if r.ValidFrom == revision.ValidFrom {
+				revision.ID = r.ID
+				m.FixedCosts[i].Revisions[j] = *revision
+				return nil
+			}
+		}
+		m.nextRevisionID++
+		revision.ID = m.nextRevisionID
+		m.FixedCosts[i].Revisions = append(m.FixedCosts[i].Revisions, *revision)
+		return nil
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (m *MockRepository) DeleteAmountRevision(id uint, fixedCostID int) error {
+	for i, c := range m.FixedCosts {
+		if c.ID != fixedCostID {
+			continue
+		}
+		for j, r := range c.Revisions {
+			if r.ID == id {
+				m.FixedCosts[i].Revisions = append(c.Revisions[:j:j], c.Revisions[j+1:]...)
+				return nil
+			}
+		}
+	}
+	return gorm.ErrRecordNotFound
 }
 
 func (m *MockRepository) LoadSpecialCosts(workspaceID uint) *[]cost.SpecialCost {
@@ -203,7 +366,7 @@ func (m *MockRepository) LoadSpecialCostsByUser(userID uint) *[]cost.SpecialCost
 	return &filtered
 }
 
-func (m *MockRepository) SaveSpecialCost(cost *cost.SpecialCost) {
+func (m *MockRepository) SaveSpecialCost(cost *cost.SpecialCost) error {
 	found := false
 	for i, c := range m.SpecialCosts {
 		if c.ID == cost.ID && cost.ID != 0 {
@@ -218,9 +381,20 @@ func (m *MockRepository) SaveSpecialCost(cost *cost.SpecialCost) {
 		}
 		m.SpecialCosts = append(m.SpecialCosts, *cost)
 	}
+	return nil
+}
+
+func (m *MockRepository) ImportCosts(fixedCosts []cost.FixedCost, specialCosts []cost.SpecialCost) error {
+	for i := range fixedCosts {
+		m.SaveFixedObject(&fixedCosts[i])
+	}
+	for i := range specialCosts {
+		m.SaveSpecialCost(&specialCosts[i])
+	}
+	return nil
 }
 
-func (m *MockRepository) DeleteSpecialCost(id int, workspaceID uint) {
+func (m *MockRepository) DeleteSpecialCost(id int, workspaceID uint) error {
 	var newCosts []cost.SpecialCost
 	for _, c := range m.SpecialCosts {
 		if c.ID != id || c.WorkspaceID != workspaceID {
@@ -228,6 +402,71 @@ func (m *MockRepository) DeleteSpecialCost(id int, workspaceID uint) {
 		}
 	}
 	m.SpecialCosts = newCosts
+	return nil
+}
+
+func (m *MockRepository) LoadCategories(workspaceID uint) ([]cost.Category, error) {
+	var filtered []cost.Category
+	for _, c := range m.Categories {
+		if c.WorkspaceID == workspaceID {
+			filtered = append(filtered, c)
+		}
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].Name < filtered[j].Name
+	})
+
+	return filtered, nil
+}
+
+func (m *MockRepository) SaveCategory(category *cost.Category) error {
+	for i, c := range m.Categories {
+		if c.ID == category.ID && category.ID != 0 {
+			m.Categories[i] = *category
+			return nil
+		}
+	}
+	if category.ID == 0 {
+		category.ID = uint(len(m.Categories) + 1)
+	}
+	m.Categories = append(m.Categories, *category)
+	return nil
+}
+
+func (m *MockRepository) DeleteCategory(id uint, workspaceID uint) error {
+	var deleted *cost.Category
+	var newCategories []cost.Category
+	for _, c := range m.Categories {
+		if c.ID == id && c.WorkspaceID == workspaceID {
+			deleted = &c
+			continue
+		}
+		newCategories = append(newCategories, c)
+	}
+	if deleted == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	for i, c := range newCategories {
+		if c.ParentID != nil && *c.ParentID == id {
+			newCategories[i].ParentID = deleted.ParentID
+		}
+	}
+	m.Categories = newCategories
+
+	for i, c := range m.FixedCosts {
+		if c.CategoryID != nil && *c.CategoryID == id && c.WorkspaceID == workspaceID {
+			m.FixedCosts[i].CategoryID = nil
+		}
+	}
+	for i, c := range m.SpecialCosts {
+		if c.CategoryID != nil && *c.CategoryID == id && c.WorkspaceID == workspaceID {
+			m.SpecialCosts[i].CategoryID = nil
+		}
+	}
+
+	return nil
 }
 
 func (m *MockRepository) PurgeUserData(userID uint) error {
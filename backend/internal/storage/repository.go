@@ -10,6 +10,8 @@ type Repository interface {
 	WealthProfileRepository
 	WorkspaceRepository
 	InviteRepository
+	ExchangeRateRepository
+	AccountRepository
 }
 
 // GormRepository implements Repository using GORM
@@ -22,10 +22,10 @@ type UserRepository interface {
 
 func (r *GormRepository) PurgeUserData(userID uint) error {
 	return r.DB.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Where("user_id = ?", userID).Delete(&cost.FixedCost{}).Error; err != nil {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&cost.FixedCost{}).Error; err != nil {
 			return err
 		}
-		if err := tx.Where("user_id = ?", userID).Delete(&cost.SpecialCost{}).Error; err != nil {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&cost.SpecialCost{}).Error; err != nil {
 			return err
 		}
 		if err := tx.Where("user_id = ?", userID).Delete(&wealth.WealthProfile{}).Error; err != nil {
@@ -90,10 +90,10 @@ func (r *GormRepository) Delete(id uint) error {
 
 	return r.DB.Transaction(func(tx *gorm.DB) error {
 		// Delete related records that might not have CASCADE set up in DB
-		if err := tx.Where("user_id = ?", id).Delete(&cost.FixedCost{}).Error; err != nil {
+		if err := tx.Unscoped().Where("user_id = ?", id).Delete(&cost.FixedCost{}).Error; err != nil {
 			return err
 		}
-		if err := tx.Where("user_id = ?", id).Delete(&cost.SpecialCost{}).Error; err != nil {
+		if err := tx.Unscoped().Where("user_id = ?", id).Delete(&cost.SpecialCost{}).Error; err != nil {
 			return err
 		}
 		if err := tx.Where("user_id = ?", id).Delete(&wealth.WealthProfile{}).Error; err != nil {
@@ -9,6 +9,8 @@ type WorkspaceRepository interface {
 	GetWorkspaceByID(id uint) (*workspace.Workspace, error)
 	UpdateWorkspace(ws *workspace.Workspace) error
 	UpdateWorkspaceCurrentAmount(workspaceID uint, amount int) error
+	UpdateWorkspaceBaseCurrency(workspaceID uint, code string) error
+	UpdateWorkspaceLowBalanceThreshold(workspaceID uint, threshold int) error
 }
 
 func (r *GormRepository) CreateWorkspace(ws *workspace.Workspace) error {
@@ -31,3 +33,11 @@ func (r *GormRepository) UpdateWorkspace(ws *workspace.Workspace) error {
 func (r *GormRepository) UpdateWorkspaceCurrentAmount(workspaceID uint, amount int) error {
 	return r.DB.Model(&workspace.Workspace{}).Where("id = ?", workspaceID).Update("current_amount", amount).Error
 }
+
+func (r *GormRepository) UpdateWorkspaceBaseCurrency(workspaceID uint, code string) error {
+	return r.DB.Model(&workspace.Workspace{}).Where("id = ?", workspaceID).Update("base_currency", code).Error
+}
+
+func (r *GormRepository) UpdateWorkspaceLowBalanceThreshold(workspaceID uint, threshold int) error {
+	return r.DB.Model(&workspace.Workspace{}).Where("id = ?", workspaceID).Update("low_balance_threshold", threshold).Error
+}
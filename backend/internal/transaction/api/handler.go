@@ -0,0 +1,232 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"wondee/finance-app-backend/internal/transaction"
+	"wondee/finance-app-backend/internal/transaction/bankimport"
+	"wondee/finance-app-backend/internal/transaction/repository"
+	"wondee/finance-app-backend/internal/transaction/service"
+)
+
+const dateLayout = "2006-01-02"
+
+// maxStatementSize limits uploaded statements; a year of a busy account in
+// camt.053 stays well below it.
+const maxStatementSize = 10 << 20
+
+// Handler handles HTTP requests for the transaction ledger
+type Handler struct {
+	repo    repository.Repository
+	service *service.TransactionService
+}
+
+// NewHandler creates a new Handler instance
+func NewHandler(repo repository.Repository) *Handler {
+	return &Handler{
+		repo:    repo,
+		service: service.NewTransactionService(repo),
+	}
+}
+
+// Response types
+
+// JsonTransaction carries the amount in currency units with cents as
+// decimals; dates are formatted as YYYY-MM-DD.
+type JsonTransaction struct {
+	ID               uint    `json:"id"`
+	BookingDate      string  `json:"bookingDate"`
+	ValueDate        *string `json:"valueDate"`
+	Amount           float64 `json:"amount"`
+	Currency         string  `json:"currency"`
+	Counterparty     string  `json:"counterparty"`
+	CounterpartyIBAN string  `json:"counterpartyIban"`
+	Purpose          string  `json:"purpose"`
+	Reference        string  `json:"reference"`
+	AccountIBAN      string  `json:"accountIban"`
+	Source           string  `json:"source"`
+}
+
+type ImportResponse struct {
+	Format       string           `json:"format"`
+	DryRun       bool             `json:"dryRun"`
+	Total        int              `json:"total"`
+	Duplicates   int              `json:"duplicates"`
+	Imported     int              `json:"imported"`
+	Transactions []ImportedRowDTO `json:"transactions"`
+}
+
+type ImportedRowDTO struct {
+	JsonTransaction
+	Duplicate bool `json:"duplicate"`
+}
+
+func ToJsonTransaction(t *transaction.Transaction) JsonTransaction {
+	result := JsonTransaction{
+		ID:               t.ID,
+		BookingDate:      t.BookingDate.Format(dateLayout),
+		Amount:           float64(t.AmountCents) / 100,
+		Currency:         t.Currency,
+		Counterparty:     t.Counterparty,
+		CounterpartyIBAN: t.CounterpartyIBAN,
+		Purpose:          t.Purpose,
+		Reference:        t.Reference,
+		AccountIBAN:      t.AccountIBAN,
+		Source:           t.Source,
+	}
+	if t.ValueDate != nil {
+		valueDate := t.ValueDate.Format(dateLayout)
+		result.ValueDate = &valueDate
+	}
+	return result
+}
+
+// ImportStatement reads a bank statement uploaded as multipart field "file".
+// The format is detected unless given as form field "format"; with
+// "dryRun=true" nothing is stored and the response only previews which
+// transactions are new.
+func (h *Handler) ImportStatement(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Statement file is required"})
+		return
+	}
+	if fileHeader.Size > maxStatementSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Statement file is too large"})
+		return
+	}
+
+	format, err := bankimport.ParseFormat(c.PostForm("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(c.PostForm("dryRun"))
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read statement file"})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read statement file"})
+		return
+	}
+
+	parsed, format, err := bankimport.Parse(data, format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.Import(workspaceID, parsed, dryRun)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import transactions"})
+		return
+	}
+
+	response := ImportResponse{
+		Format:       string(format),
+		DryRun:       dryRun,
+		Total:        len(result.Entries),
+		Duplicates:   result.Duplicates,
+		Imported:     result.Imported,
+		Transactions: make([]ImportedRowDTO, 0, len(result.Entries)),
+	}
+	for _, entry := range result.Entries {
+		response.Transactions = append(response.Transactions, ImportedRowDTO{
+			JsonTransaction: ToJsonTransaction(&entry.Transaction),
+			Duplicate:       entry.Duplicate,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetTransactions lists the ledger, optionally limited by the booking dates
+// "from" and "to" (YYYY-MM-DD, inclusive).
+func (h *Handler) GetTransactions(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	from, err := parseDateQuery(c, "from")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	to, err := parseDateQuery(c, "to")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transactions, err := h.repo.ListTransactions(workspaceID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load transactions"})
+		return
+	}
+
+	result := make([]JsonTransaction, 0, len(transactions))
+	for i := range transactions {
+		result = append(result, ToJsonTransaction(&transactions[i]))
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteTransaction removes a transaction from the ledger; costs it paid
+// are pending again. Importing the statement again restores it.
+func (h *Handler) DeleteTransaction(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
+		return
+	}
+
+	if err := h.repo.DeleteTransaction(uint(id), workspaceID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete transaction"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Helper functions
+
+func parseDateQuery(c *gin.Context, name string) (*time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, errors.New(name + " must be a date like 2025-01-31")
+	}
+	return &date, nil
+}
+
+func (h *Handler) getWorkspaceID(c *gin.Context) uint {
+	workspaceID, exists := c.Get("workspace_id")
+	if !exists {
+		return 0
+	}
+	return workspaceID.(uint)
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+	"wondee/finance-app-backend/internal/transaction"
+)
+
+// MockTransactionRepository implements transaction repository.Repository
+type MockTransactionRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransactionRepository) ListTransactions(workspaceID uint, from, to *time.Time) ([]transaction.Transaction, error) {
+	args := m.Called(workspaceID, from, to)
+	return args.Get(0).([]transaction.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) GetTransaction(id uint, workspaceID uint) (*transaction.Transaction, error) {
+	args := m.Called(id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) DeleteTransaction(id uint, workspaceID uint) error {
+	args := m.Called(id, workspaceID)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) FindFingerprints(workspaceID uint, fingerprints []string) ([]string, error) {
+	args := m.Called(workspaceID, fingerprints)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SaveTransactions(transactions []transaction.Transaction) error {
+	args := m.Called(transactions)
+	for i := range transactions {
+		transactions[i].ID = uint(i + 1)
+	}
+	return args.Error(0)
+}
+
+const statementCSV = "Buchungstag;Verwendungszweck;Beguenstigter/Zahlungspflichtiger;Betrag\n" +
+	"01.03.2025;Miete März;Hausverwaltung;-1.200,00\n" +
+	"28.03.2025;Gehalt;ACME GmbH;3.500,50\n"
+
+func setupTestRouter(handler *Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+	router.GET("/transactions", handler.GetTransactions)
+	router.POST("/transactions/import", handler.ImportStatement)
+	router.DELETE("/transactions/:id", handler.DeleteTransaction)
+	return router
+}
+
+func uploadStatement(router *gin.Engine, content string, fields map[string]string) *httptest.ResponseRecorder {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "umsaetze.csv")
+	part.Write([]byte(content))
+	for key, value := range fields {
+		writer.WriteField(key, value)
+	}
+	writer.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, "/transactions/import", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestImportStatement(t *testing.T) {
+	mockRepo := new(MockTransactionRepository)
+	router := setupTestRouter(NewHandler(mockRepo))
+
+	mockRepo.On("FindFingerprints", uint(1), mock.Anything).Return([]string{}, nil)
+	mockRepo.On("SaveTransactions", mock.Anything).Return(nil)
+
+	w := uploadStatement(router, statementCSV, nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response ImportResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "csv", response.Format)
+	assert.Equal(t, 2, response.Imported)
+	assert.Equal(t, "2025-03-01", response.Transactions[0].BookingDate)
+	assert.Equal(t, -1200.0, response.Transactions[0].Amount)
+	assert.Equal(t, 3500.5, response.Transactions[1].Amount)
+	assert.Equal(t, "ACME GmbH", response.Transactions[1].Counterparty)
+}
+
+func TestImportStatement_DryRun(t *testing.T) {
+	mockRepo := new(MockTransactionRepository)
+	router := setupTestRouter(NewHandler(mockRepo))
+
+	mockRepo.On("FindFingerprints", uint(1), mock.Anything).Return([]string{}, nil)
+
+	w := uploadStatement(router, statementCSV, map[string]string{"format": "csv", "dryRun": "true"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"dryRun":true`)
+	mockRepo.AssertNotCalled(t, "SaveTransactions", mock.Anything)
+}
+
+func TestImportStatement_InvalidStatement(t *testing.T) {
+	router := setupTestRouter(NewHandler(new(MockTransactionRepository)))
+
+	w := uploadStatement(router, "foo;bar\n1;2\n", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = uploadStatement(router, statementCSV, map[string]string{"format": "ofx"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetTransactions(t *testing.T) {
+	mockRepo := new(MockTransactionRepository)
+	router := setupTestRouter(NewHandler(mockRepo))
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("ListTransactions", uint(1), &from, (*time.Time)(nil)).Return([]transaction.Transaction{
+		{ID: 7, BookingDate: from, AmountCents: -999, Source: transaction.SourceMT940},
+	}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/transactions?from=2025-03-01", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response []JsonTransaction
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Len(t, response, 1)
+	assert.Equal(t, -9.99, response[0].Amount)
+	assert.Nil(t, response[0].ValueDate)
+
+	req, _ = http.NewRequest(http.MethodGet, "/transactions?to=03.2025", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDeleteTransaction(t *testing.T) {
+	mockRepo := new(MockTransactionRepository)
+	router := setupTestRouter(NewHandler(mockRepo))
+
+	mockRepo.On("DeleteTransaction", uint(7), uint(1)).Return(nil)
+	mockRepo.On("DeleteTransaction", uint(8), uint(1)).Return(gorm.ErrRecordNotFound)
+	mockRepo.On("DeleteTransaction", uint(9), uint(1)).Return(errors.New("connection lost"))
+
+	req, _ := http.NewRequest(http.MethodDelete, "/transactions/7", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	req, _ = http.NewRequest(http.MethodDelete, "/transactions/8", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req, _ = http.NewRequest(http.MethodDelete, "/transactions/9", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,235 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/audit"
+	"wondee/finance-app-backend/internal/cost"
+	cost_api "wondee/finance-app-backend/internal/cost/api"
+	cost_repo "wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/transaction/recurring"
+	"wondee/finance-app-backend/internal/transaction/repository"
+)
+
+// recurringHistoryYears limits the history searched for recurring payments;
+// yearly payments need two years to show up.
+const recurringHistoryYears = 2
+
+// RecurringHandler suggests fixed costs from recurring payments in the
+// transaction ledger.
+type RecurringHandler struct {
+	repo     repository.Repository
+	costRepo cost_repo.Repository
+	audit    *audit.Log
+	balance  cost_api.BalanceMonitor
+}
+
+// NewRecurringHandler creates a new RecurringHandler instance. Fixed costs
+// created from suggestions are recorded in auditLog and watched by balance,
+// both may be nil.
+func NewRecurringHandler(repo repository.Repository, costRepo cost_repo.Repository, auditLog *audit.Log, balance cost_api.BalanceMonitor) *RecurringHandler {
+	return &RecurringHandler{
+		repo:     repo,
+		costRepo: costRepo,
+		audit:    auditLog,
+		balance:  balance,
+	}
+}
+
+// Response types
+
+type RecurringResponse struct {
+	Suggestions []SuggestionDTO `json:"suggestions"`
+	Alerts      []AlertDTO      `json:"alerts"`
+}
+
+// SuggestionDTO is a recurring payment without fixed cost. It is identified
+// by its latest payment; FixedCost is the cost that accepting it creates.
+type SuggestionDTO struct {
+	ID               uint                   `json:"id"`
+	Counterparty     string                 `json:"counterparty"`
+	CounterpartyIBAN string                 `json:"counterpartyIban"`
+	Purpose          string                 `json:"purpose"`
+	Amount           float64                `json:"amount"`
+	Currency         string                 `json:"currency"`
+	Payments         int                    `json:"payments"`
+	FirstPayment     string                 `json:"firstPayment"`
+	LastPayment      string                 `json:"lastPayment"`
+	NextPayment      string                 `json:"nextPayment"`
+	FixedCost        cost_api.JsonFixedCost `json:"fixedCost"`
+}
+
+// AlertDTO reports a fixed cost whose payments stopped or changed. Amounts
+// are in currency units with cents as decimals.
+type AlertDTO struct {
+	Kind           string  `json:"kind"`
+	FixedCostID    int     `json:"fixedCostId"`
+	Name           string  `json:"name"`
+	ExpectedAmount float64 `json:"expectedAmount"`
+	Amount         float64 `json:"amount"`
+	Counterparty   string  `json:"counterparty"`
+	LastPayment    string  `json:"lastPayment"`
+	NextPayment    string  `json:"nextPayment"`
+}
+
+// AcceptSuggestionRequest accepts the suggestion with the given ID, i.e.
+// latest payment. Name and category optionally replace the proposed ones.
+type AcceptSuggestionRequest struct {
+	ID         uint   `json:"id" binding:"required"`
+	Name       string `json:"name" binding:"max=100"`
+	CategoryID *uint  `json:"categoryId"`
+	IsSaving   bool   `json:"isSaving"`
+}
+
+// GetRecurring detects recurring payments and compares them with the fixed
+// costs of the workspace.
+func (h *RecurringHandler) GetRecurring(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	review, err := h.review(workspaceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load transactions"})
+		return
+	}
+
+	response := RecurringResponse{
+		Suggestions: make([]SuggestionDTO, 0, len(review.Suggestions)),
+		Alerts:      make([]AlertDTO, 0, len(review.Alerts)),
+	}
+	for i := range review.Suggestions {
+		response.Suggestions = append(response.Suggestions, toSuggestionDTO(&review.Suggestions[i]))
+	}
+	for _, alert := range review.Alerts {
+		response.Alerts = append(response.Alerts, AlertDTO{
+			Kind:           alert.Kind,
+			FixedCostID:    alert.FixedCost.ID,
+			Name:           alert.FixedCost.Name,
+			ExpectedAmount: float64(alert.ExpectedCents) / 100,
+			Amount:         float64(alert.Pattern.AmountCents) / 100,
+			Counterparty:   alert.Pattern.Counterparty,
+			LastPayment:    alert.Pattern.Last().BookingDate.Format(dateLayout),
+			NextPayment:    alert.Pattern.NextDate.Format(dateLayout),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AcceptSuggestion creates the fixed cost proposed by a suggestion.
+func (h *RecurringHandler) AcceptSuggestion(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	var req AcceptSuggestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: id is required"})
+		return
+	}
+
+	review, err := h.review(workspaceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load transactions"})
+		return
+	}
+
+	var suggestion *recurring.Pattern
+	for i := range review.Suggestions {
+		if review.Suggestions[i].Last().ID == req.ID {
+			suggestion = &review.Suggestions[i]
+			break
+		}
+	}
+	if suggestion == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Suggestion not found"})
+		return
+	}
+
+	fixedCost := suggestion.FixedCost()
+	fixedCost.WorkspaceID = workspaceID
+	fixedCost.UserID = h.getUserID(c)
+	fixedCost.IsSaving = req.IsSaving
+	fixedCost.CategoryID = req.CategoryID
+	if req.Name != "" {
+		fixedCost.Name = req.Name
+	}
+
+	if req.CategoryID != nil {
+		categories, err := h.costRepo.LoadCategories(workspaceID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load categories"})
+			return
+		}
+		if !cost.NewCategoryTree(categories).Contains(*req.CategoryID) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "category not found"})
+			return
+		}
+	}
+
+	notify := cost_api.WatchBalance(h.balance, workspaceID)
+	if err := h.costRepo.SaveFixedObject(&fixedCost); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save fixed cost"})
+		return
+	}
+	notify(fixedCost.UserID, fixedCost.Name)
+
+	result := cost_api.ToJsonStruct(&fixedCost)
+	h.audit.Record(audit.Change{
+		WorkspaceID: workspaceID,
+		Actor:       fixedCost.UserID,
+		EntityType:  audit.EntityFixedCost,
+		EntityID:    uint(fixedCost.ID),
+		Action:      audit.ActionCreate,
+		After:       result,
+	})
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func (h *RecurringHandler) review(workspaceID uint) (*recurring.Review, error) {
+	from := time.Now().AddDate(-recurringHistoryYears, 0, 0)
+	transactions, err := h.repo.ListTransactions(workspaceID, &from, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := recurring.Detect(transactions, recurring.DefaultOptions)
+	review := recurring.Compare(*h.costRepo.LoadFixedCosts(workspaceID), patterns, recurring.DefaultOptions)
+	return &review, nil
+}
+
+// Helper functions
+
+func toSuggestionDTO(pattern *recurring.Pattern) SuggestionDTO {
+	fixedCost := pattern.FixedCost()
+
+	return SuggestionDTO{
+		ID:               pattern.Last().ID,
+		Counterparty:     pattern.Counterparty,
+		CounterpartyIBAN: pattern.CounterpartyIBAN,
+		Purpose:          pattern.Purpose,
+		Amount:           float64(pattern.AmountCents) / 100,
+		Currency:         pattern.Currency,
+		Payments:         len(pattern.Transactions),
+		FirstPayment:     pattern.First().BookingDate.Format(dateLayout),
+		LastPayment:      pattern.Last().BookingDate.Format(dateLayout),
+		NextPayment:      pattern.NextDate.Format(dateLayout),
+		FixedCost:        cost_api.ToJsonStruct(&fixedCost),
+	}
+}
+
+func (h *RecurringHandler) getUserID(c *gin.Context) uint {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return 0
+	}
+	return userID.(uint)
+}
+
+func (h *RecurringHandler) getWorkspaceID(c *gin.Context) uint {
+	workspaceID, exists := c.Get("workspace_id")
+	if !exists {
+		return 0
+	}
+	return workspaceID.(uint)
+}
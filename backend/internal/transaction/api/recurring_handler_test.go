@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"wondee/finance-app-backend/internal/audit"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/storage"
+	"wondee/finance-app-backend/internal/transaction"
+)
+
+type auditWriter struct {
+	entries []audit.Entry
+}
+
+func (w *auditWriter) CreateEntry(entry *audit.Entry) error {
+	w.entries = append(w.entries, *entry)
+	return nil
+}
+
+func setupRecurringRouter(handler *RecurringHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+	router.GET("/transactions/recurring", handler.GetRecurring)
+	router.POST("/transactions/recurring/accept", handler.AcceptSuggestion)
+	return router
+}
+
+// monthlyPayments returns payments on the first of the last months, the
+// latest with the highest ID.
+func monthlyPayments(firstID uint, months int, cents int64, counterparty string) []transaction.Transaction {
+	now := time.Now()
+	firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var result []transaction.Transaction
+	for i := months - 1; i >= 0; i-- {
+		result = append(result, transaction.Transaction{
+			ID:           firstID + uint(months-1-i),
+			BookingDate:  firstOfMonth.AddDate(0, -i, 0),
+			AmountCents:  cents,
+			Counterparty: counterparty,
+		})
+	}
+	return result
+}
+
+func TestGetRecurring(t *testing.T) {
+	mockRepo := new(MockTransactionRepository)
+	costRepo := &storage.MockRepository{FixedCosts: []cost.FixedCost{
+		{ID: 1, WorkspaceID: 1, Name: "Rent", Amount: -1100},
+	}}
+	handler := NewRecurringHandler(mockRepo, costRepo, nil, nil)
+
+	transactions := append(monthlyPayments(1, 4, -1299, "Netflix"), monthlyPayments(10, 4, -120000, "Rent Ltd")...)
+	mockRepo.On("ListTransactions", uint(1), mock.Anything, (*time.Time)(nil)).Return(transactions, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/transactions/recurring", nil)
+	w := httptest.NewRecorder()
+	setupRecurringRouter(handler).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response RecurringResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	if assert.Len(t, response.Suggestions, 1) {
+		suggestion := response.Suggestions[0]
+		assert.Equal(t, uint(4), suggestion.ID)
+		assert.Equal(t, -12.99, suggestion.Amount)
+		assert.Equal(t, 4, suggestion.Payments)
+		assert.Equal(t, "Netflix", suggestion.FixedCost.Name)
+		assert.Equal(t, -13, suggestion.FixedCost.Amount)
+		assert.Equal(t, "MONTHLY", string(suggestion.FixedCost.Recurrence.Frequency))
+	}
+	if assert.Len(t, response.Alerts, 1) {
+		assert.Equal(t, "amountChanged", response.Alerts[0].Kind)
+		assert.Equal(t, 1, response.Alerts[0].FixedCostID)
+		assert.Equal(t, -1100.0, response.Alerts[0].ExpectedAmount)
+		assert.Equal(t, -1200.0, response.Alerts[0].Amount)
+	}
+}
+
+func TestAcceptSuggestion(t *testing.T) {
+	mockRepo := new(MockTransactionRepository)
+	costRepo := &storage.MockRepository{}
+	writer := &auditWriter{}
+	handler := NewRecurringHandler(mockRepo, costRepo, audit.NewLog(writer), nil)
+
+	mockRepo.On("ListTransactions", uint(1), mock.Anything, (*time.Time)(nil)).Return(monthlyPayments(1, 3, -999, "SPOTIFY AB"), nil)
+
+	body := []byte(`{"id": 3, "name": "Spotify"}`)
+	req, _ := http.NewRequest(http.MethodPost, "/transactions/recurring/accept", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	setupRecurringRouter(handler).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	if assert.Len(t, costRepo.FixedCosts, 1) {
+		saved := costRepo.FixedCosts[0]
+		assert.Equal(t, "Spotify", saved.Name)
+		assert.Equal(t, -10, saved.Amount)
+		assert.Equal(t, uint(1), saved.WorkspaceID)
+		assert.Equal(t, cost.FrequencyMonthly, saved.Recurrence.Frequency)
+		assert.NotNil(t, saved.From)
+	}
+	if assert.Len(t, writer.entries, 1) {
+		assert.Equal(t, audit.EntityFixedCost, writer.entries[0].EntityType)
+		assert.Equal(t, audit.ActionCreate, writer.entries[0].Action)
+		assert.Equal(t, uint(1), writer.entries[0].UserID)
+	}
+}
+
+func TestAcceptSuggestion_NotFound(t *testing.T) {
+	mockRepo := new(MockTransactionRepository)
+	handler := NewRecurringHandler(mockRepo, &storage.MockRepository{}, nil, nil)
+
+	mockRepo.On("ListTransactions", uint(1), mock.Anything, (*time.Time)(nil)).Return(monthlyPayments(1, 3, -999, "SPOTIFY AB"), nil)
+
+	body := []byte(`{"id": 2}`)
+	req, _ := http.NewRequest(http.MethodPost, "/transactions/recurring/accept", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	setupRecurringRouter(handler).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,190 @@
+// Package bankimport reads bank statements in the ISO 20022 CAMT.053 format,
+// the SWIFT MT940 format and the CSV exports of common German banks. The
+// parsers only return booked entries; pending ones are skipped. Workspace
+// and fingerprint are left to the caller.
+package bankimport
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"wondee/finance-app-backend/internal/transaction"
+)
+
+type Format string
+
+const (
+	FormatCAMT053 Format = transaction.SourceCAMT053
+	FormatMT940   Format = transaction.SourceMT940
+	FormatCSV     Format = transaction.SourceCSV
+)
+
+// ParseFormat accepts the supported formats case-insensitively; an empty
+// value means the format is detected from the content.
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(strings.ReplaceAll(value, ".", ""))) {
+	case "":
+		return "", nil
+	case FormatCAMT053, "camt":
+		return FormatCAMT053, nil
+	case FormatMT940, "sta":
+		return FormatMT940, nil
+	case FormatCSV:
+		return FormatCSV, nil
+	}
+	return "", fmt.Errorf("unsupported format %q, use camt053, mt940 or csv", value)
+}
+
+// Detect guesses the format of a statement from its content.
+func Detect(data []byte) Format {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\ufeff")))
+
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		return FormatCAMT053
+	case bytes.Contains(trimmed, []byte(":61:")) && bytes.Contains(trimmed, []byte(":20:")):
+		return FormatMT940
+	default:
+		return FormatCSV
+	}
+}
+
+// Parse reads a statement in the given format, detecting it if empty.
+func Parse(data []byte, format Format) ([]transaction.Transaction, Format, error) {
+	if format == "" {
+		format = Detect(data)
+	}
+
+	var transactions []transaction.Transaction
+	var err error
+
+	switch format {
+	case FormatCAMT053:
+		transactions, err = ParseCAMT053(data)
+	case FormatMT940:
+		transactions, err = ParseMT940(decode(data))
+	case FormatCSV:
+		transactions, err = ParseCSV(decode(data))
+	default:
+		err = fmt.Errorf("unsupported format %q", format)
+	}
+
+	if err != nil {
+		return nil, format, err
+	}
+	return transactions, format, nil
+}
+
+// decode returns data as text. German banks still export MT940 and CSV in
+// ISO 8859-1, which is recognized by not being valid UTF-8.
+func decode(data []byte) string {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	if utf8.Valid(data) {
+		return string(data)
+	}
+
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
+
+// parseCents reads a decimal amount exactly. The decimal separator is the
+// last '.' or ','; if only one kind occurs and it repeats or is followed by
+// three digits, it is a thousands separator instead ("1.234" in German
+// exports).
+func parseCents(value string) (int64, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9', r == '-', r == '+', r == ',', r == '.':
+			return r
+		case r == '−': // typographic minus
+			return '-'
+		default:
+			return -1
+		}
+	}, value)
+
+	negative := strings.HasPrefix(cleaned, "-") || strings.HasSuffix(cleaned, "-")
+	cleaned = strings.Trim(cleaned, "+-")
+
+	separator := strings.LastIndexAny(cleaned, ".,")
+	if separator >= 0 && !strings.ContainsAny(cleaned, otherSeparator(cleaned[separator])) {
+		if strings.Count(cleaned, string(cleaned[separator])) > 1 || len(cleaned)-separator-1 == 3 {
+			separator = -1
+		}
+	}
+
+	whole, fraction := cleaned, ""
+	if separator >= 0 {
+		whole, fraction = cleaned[:separator], cleaned[separator+1:]
+	}
+	whole = strings.NewReplacer(".", "", ",", "").Replace(whole)
+
+	if whole == "" && fraction == "" || len(fraction) > 2 {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	fraction = (fraction + "00")[:2]
+
+	cents, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+
+	if negative {
+		cents = -cents
+	}
+	return cents, nil
+}
+
+func otherSeparator(separator byte) string {
+	if separator == '.' {
+		return ","
+	}
+	return "."
+}
+
+var dateLayouts = []string{"02.01.2006", "2.1.2006", "02.01.06", "2006-01-02", "2006-01-02T15:04:05", time.RFC3339}
+
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// normalizeIBAN removes spaces and upper-cases an account number.
+func normalizeIBAN(value string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(value), ""))
+}
+
+// sepaFields are the SEPA keywords banks put into unstructured purposes.
+var sepaFields = []string{"EREF+", "KREF+", "MREF+", "CRED+", "DEBT+", "COAM+", "OAMT+", "SVWZ+", "ABWA+", "ABWE+", "IBAN+", "BIC+"}
+
+// sepaPurpose returns the text after SVWZ+ if the purpose uses SEPA
+// keywords, and the purpose unchanged otherwise.
+func sepaPurpose(purpose string) string {
+	start := strings.Index(purpose, "SVWZ+")
+	if start < 0 {
+		return strings.TrimSpace(purpose)
+	}
+
+	rest := purpose[start+len("SVWZ+"):]
+	end := len(rest)
+	for _, field := range sepaFields {
+		if index := strings.Index(rest, field); index >= 0 && index < end {
+			end = index
+		}
+	}
+	return strings.TrimSpace(rest[:end])
+}
@@ -0,0 +1,282 @@
+package bankimport
+
+import (
+	"testing"
+	"time"
+
+	"wondee/finance-app-backend/internal/transaction"
+)
+
+const camtSample = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Acct><Id><IBAN>DE02 1203 0000 0000 2020 51</IBAN></Id></Acct>
+      <Ntry>
+        <Amt Ccy="EUR">1200.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2025-03-01</Dt></BookgDt>
+        <ValDt><Dt>2025-03-03</Dt></ValDt>
+        <AcctSvcrRef>2025030100001</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <Refs><EndToEndId>MIETE-03</EndToEndId></Refs>
+          <RltdPties>
+            <Cdtr><Nm>Hausverwaltung Müller</Nm></Cdtr>
+            <CdtrAcct><Id><IBAN>DE89370400440532013000</IBAN></Id></CdtrAcct>
+          </RltdPties>
+          <RmtInf><Ustrd>Miete März</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">3500.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2025-03-28</Dt></BookgDt>
+        <NtryDtls><TxDtls>
+          <RltdPties><Dbtr><Nm>ACME GmbH</Nm></Dbtr></RltdPties>
+          <RmtInf><Ustrd>Gehalt 03/2025</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">80.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2025-03-15</Dt></BookgDt>
+        <NtryDtls>
+          <TxDtls>
+            <AmtDtls><TxAmt><Amt Ccy="EUR">50.00</Amt></TxAmt></AmtDtls>
+            <RltdPties><Cdtr><Nm>Stadtwerke</Nm></Cdtr></RltdPties>
+          </TxDtls>
+          <TxDtls>
+            <AmtDtls><TxAmt><Amt Ccy="EUR">30.00</Amt></TxAmt></AmtDtls>
+            <RltdPties><Cdtr><Nm>Telekom</Nm></Cdtr></RltdPties>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">9.99</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>PDNG</Sts>
+        <BookgDt><Dt>2025-03-30</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+func TestParseCAMT053(t *testing.T) {
+	transactions, format, err := Parse([]byte(camtSample), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if format != FormatCAMT053 {
+		t.Errorf("Expected camt053 to be detected, got %s", format)
+	}
+	if len(transactions) != 4 {
+		t.Fatalf("Expected 4 booked transactions, got %d", len(transactions))
+	}
+
+	rent := transactions[0]
+	if rent.AmountCents != -120000 || rent.Currency != "EUR" || rent.Counterparty != "Hausverwaltung Müller" ||
+		rent.CounterpartyIBAN != "DE89370400440532013000" || rent.Purpose != "Miete März" || rent.Reference != "MIETE-03" ||
+		rent.AccountIBAN != "DE02120300000000202051" || rent.Source != transaction.SourceCAMT053 {
+		t.Errorf("Unexpected rent: %+v", rent)
+	}
+	if !rent.BookingDate.Equal(date(2025, 3, 1)) || !rent.ValueDate.Equal(date(2025, 3, 3)) {
+		t.Errorf("Unexpected dates: %v, %v", rent.BookingDate, rent.ValueDate)
+	}
+
+	if salary := transactions[1]; salary.AmountCents != 350000 || salary.Counterparty != "ACME GmbH" {
+		t.Errorf("Unexpected salary: %+v", salary)
+	}
+
+	if transactions[2].AmountCents != -5000 || transactions[2].Counterparty != "Stadtwerke" ||
+		transactions[3].AmountCents != -3000 || transactions[3].Counterparty != "Telekom" {
+		t.Errorf("Expected the batch entry to be split, got %+v and %+v", transactions[2], transactions[3])
+	}
+}
+
+func TestParseCAMT053Version08(t *testing.T) {
+	data := `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"><BkToCstmrStmt><Stmt>
+		<Ntry><Amt Ccy="EUR">15.50</Amt><CdtDbtInd>DBIT</CdtDbtInd><Sts><Cd>BOOK</Cd></Sts>
+		<BookgDt><DtTm>2025-04-02T10:15:00</DtTm></BookgDt>
+		<NtryDtls><TxDtls><RltdPties><Cdtr><Pty><Nm>Bäckerei</Nm></Pty></Cdtr></RltdPties></TxDtls></NtryDtls>
+		</Ntry></Stmt></BkToCstmrStmt></Document>`
+
+	transactions, err := ParseCAMT053([]byte(data))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(transactions) != 1 || transactions[0].Counterparty != "Bäckerei" || !transactions[0].BookingDate.Equal(date(2025, 4, 2)) {
+		t.Errorf("Unexpected transactions: %+v", transactions)
+	}
+}
+
+const mt940Sample = ":20:STARTUMS\r\n" +
+	":25:10020030/1234567\r\n" +
+	":28C:00001/001\r\n" +
+	":60F:C250228EUR2500,00\r\n" +
+	":61:2503030301DR1200,00NDDTMIETE-03//0301A\r\n" +
+	":86:105?00SEPA-LASTSCHRIFT?20EREF+MIETE-03?21SVWZ+Miete Mär\r\n" +
+	"z?22 2025?30GENODEF1S04?31DE89370400440532013000?32Hausverwaltun\r\n" +
+	"g Müller\r\n" +
+	":61:2503280328CR3500,NTRFNONREF\r\n" +
+	":86:Gehalt 03/2025 ACME GmbH\r\n" +
+	":61:2501020102RC10,00NMSCNONREF\r\n" +
+	":62F:C250331EUR4790,00\r\n" +
+	"-\r\n"
+
+func TestParseMT940(t *testing.T) {
+	transactions, format, err := Parse([]byte(mt940Sample), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if format != FormatMT940 {
+		t.Errorf("Expected mt940 to be detected, got %s", format)
+	}
+	if len(transactions) != 3 {
+		t.Fatalf("Expected 3 transactions, got %d", len(transactions))
+	}
+
+	rent := transactions[0]
+	if rent.AmountCents != -120000 || rent.Currency != "EUR" || rent.Counterparty != "Hausverwaltung Müller" ||
+		rent.CounterpartyIBAN != "DE89370400440532013000" || rent.Purpose != "Miete März 2025" ||
+		rent.Reference != "MIETE-03" || rent.AccountIBAN != "10020030/1234567" {
+		t.Errorf("Unexpected rent: %+v", rent)
+	}
+	if !rent.BookingDate.Equal(date(2025, 3, 1)) || !rent.ValueDate.Equal(date(2025, 3, 3)) {
+		t.Errorf("Unexpected dates: %v, %v", rent.BookingDate, rent.ValueDate)
+	}
+
+	if salary := transactions[1]; salary.AmountCents != 350000 || salary.Purpose != "Gehalt 03/2025 ACME GmbH" || salary.Reference != "" {
+		t.Errorf("Unexpected salary: %+v", salary)
+	}
+
+	if reversal := transactions[2]; reversal.AmountCents != -1000 {
+		t.Errorf("Expected a reversed credit to be a debit, got %+v", reversal)
+	}
+}
+
+func TestParseMT940EntryDateAcrossNewYear(t *testing.T) {
+	transactions, err := ParseMT940(":20:X\n:60F:C241231EUR0,00\n:61:2501021231D5,00NMSCNONREF\n")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !transactions[0].BookingDate.Equal(date(2024, 12, 31)) {
+		t.Errorf("Expected booking in the previous year, got %v", transactions[0].BookingDate)
+	}
+}
+
+func TestParseCSVSparkasse(t *testing.T) {
+	data := "\"Auftragskonto\";\"Buchungstag\";\"Valutadatum\";\"Buchungstext\";\"Verwendungszweck\";\"Beguenstigter/Zahlungspflichtiger\";\"Kontonummer/IBAN\";\"BIC (SWIFT-Code)\";\"Betrag\";\"Waehrung\"\n" +
+		"\"DE02120300000000202051\";\"01.03.25\";\"03.03.25\";\"FOLGELASTSCHRIFT\";\"EREF+MIETE-03 SVWZ+Miete März\";\"Hausverwaltung Müller\";\"DE89370400440532013000\";\"GENODEF1S04\";\"-1.200,00\";\"EUR\"\n" +
+		"\"DE02120300000000202051\";\"28.03.25\";\"28.03.25\";\"GUTSCHRIFT\";\"\";\"ACME GmbH\";\"\";\"\";\"3.500,00\";\"EUR\"\n"
+
+	transactions, format, err := Parse([]byte(data), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if format != FormatCSV || len(transactions) != 2 {
+		t.Fatalf("Expected 2 csv transactions, got %s %d", format, len(transactions))
+	}
+
+	rent := transactions[0]
+	if rent.AmountCents != -120000 || rent.Purpose != "Miete März" || rent.Counterparty != "Hausverwaltung Müller" ||
+		rent.AccountIBAN != "DE02120300000000202051" || !rent.BookingDate.Equal(date(2025, 3, 1)) {
+		t.Errorf("Unexpected rent: %+v", rent)
+	}
+
+	if salary := transactions[1]; salary.AmountCents != 350000 || salary.Purpose != "GUTSCHRIFT" {
+		t.Errorf("Expected the booking text as fallback purpose, got %+v", salary)
+	}
+}
+
+func TestParseCSVWithPreambleAndLatin1(t *testing.T) {
+	// ING export: account summary above the header, ISO 8859-1 encoded
+	data := []byte("Umsatzanzeige;Datei erstellt am: 01.04.2025\n" +
+		"IBAN;DE12 5001 0517 0123 4567 89\n" +
+		"\n" +
+		"Buchung;Wertstellungsdatum;Auftraggeber/Empf\xe4nger;Buchungstext;Verwendungszweck;Saldo;W\xe4hrung;Betrag;W\xe4hrung\n" +
+		"02.04.2025;02.04.2025;B\xe4ckerei;Lastschrift;Br\xf6tchen;100,00;EUR;-3,5;EUR\n")
+
+	transactions, _, err := Parse(data, FormatCSV)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(transactions) != 1 || transactions[0].Counterparty != "Bäckerei" || transactions[0].Purpose != "Brötchen" ||
+		transactions[0].AmountCents != -350 {
+		t.Errorf("Unexpected transactions: %+v", transactions)
+	}
+}
+
+func TestParseCSVDebitCreditAndPending(t *testing.T) {
+	data := "Buchungsdatum,Status,Zahlungspflichtige*r,Zahlungsempfänger*in,Verwendungszweck,Soll,Haben\n" +
+		"2025-04-01,Gebucht,ACME GmbH,Max Mustermann,Gehalt,,\"3,500.00\"\n" +
+		"2025-04-02,Gebucht,Max Mustermann,Bäckerei,Brötchen,3.50,\n" +
+		"2025-04-03,Vorgemerkt,Max Mustermann,Kino,Tickets,24.00,\n"
+
+	transactions, err := ParseCSV(data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(transactions) != 2 {
+		t.Fatalf("Expected the pending row to be skipped, got %d transactions", len(transactions))
+	}
+	if transactions[0].AmountCents != 350000 || transactions[0].Counterparty != "ACME GmbH" {
+		t.Errorf("Expected the payer as counterparty of a credit, got %+v", transactions[0])
+	}
+	if transactions[1].AmountCents != -350 || transactions[1].Counterparty != "Bäckerei" {
+		t.Errorf("Expected the payee as counterparty of a debit, got %+v", transactions[1])
+	}
+}
+
+func TestParseCSVWithoutHeader(t *testing.T) {
+	if _, err := ParseCSV("foo;bar\n1;2\n"); err == nil {
+		t.Error("Expected an error for a csv without booking date and amount")
+	}
+}
+
+func TestParseCents(t *testing.T) {
+	tests := map[string]int64{
+		"1.234,56":  123456,
+		"1,234.56":  123456,
+		"-1.200":    -120000,
+		"-3,5":      -350,
+		"12.5":      1250,
+		"3500,":     350000,
+		"1.234.567": 123456700,
+		"−9,99 €":   -999,
+		"100":       10000,
+		"0.01":      1,
+	}
+
+	for value, expected := range tests {
+		cents, err := parseCents(value)
+		if err != nil || cents != expected {
+			t.Errorf("parseCents(%q) = %d, %v, expected %d", value, cents, err, expected)
+		}
+	}
+
+	for _, value := range []string{"", "abc", "1,2345"} {
+		if _, err := parseCents(value); err == nil {
+			t.Errorf("Expected an error for %q", value)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := map[string]Format{"": "", "CAMT.053": FormatCAMT053, "camt": FormatCAMT053, "MT940": FormatMT940, "sta": FormatMT940, "csv": FormatCSV}
+	for value, expected := range tests {
+		if format, err := ParseFormat(value); err != nil || format != expected {
+			t.Errorf("ParseFormat(%q) = %s, %v, expected %s", value, format, err, expected)
+		}
+	}
+
+	if _, err := ParseFormat("ofx"); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,246 @@
+package bankimport
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"wondee/finance-app-backend/internal/transaction"
+)
+
+// The subset of camt.053 needed for the ledger. Element names are matched
+// without namespace, so versions .02 to .08 are read alike; where they
+// differ (status as text or code, parties with or without Pty) both
+// variants are mapped.
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	IBAN    string      `xml:"Acct>Id>IBAN"`
+	Entries []camtEntry `xml:"Ntry"`
+}
+
+type camtEntry struct {
+	Amount         camtAmount      `xml:"Amt"`
+	CreditDebit    string          `xml:"CdtDbtInd"`
+	Status         camtStatus      `xml:"Sts"`
+	BookingDate    camtDate        `xml:"BookgDt"`
+	ValueDate      camtDate        `xml:"ValDt"`
+	ServicerRef    string          `xml:"AcctSvcrRef"`
+	Details        []camtTxDetails `xml:"NtryDtls>TxDtls"`
+	AdditionalInfo string          `xml:"AddtlNtryInf"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type camtStatus struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+func (s camtStatus) booked() bool {
+	status := strings.TrimSpace(s.Text + s.Code)
+	return status == "" || status == "BOOK"
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+func (d camtDate) parse() (*time.Time, error) {
+	value := d.Date
+	if value == "" {
+		value = d.DateTime
+	}
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := parseDate(value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+type camtTxDetails struct {
+	EndToEndID     string      `xml:"Refs>EndToEndId"`
+	Amount         camtAmount  `xml:"Amt"`
+	TxAmount       camtAmount  `xml:"AmtDtls>TxAmt>Amt"`
+	CreditDebit    string      `xml:"CdtDbtInd"`
+	Parties        camtParties `xml:"RltdPties"`
+	Unstructured   []string    `xml:"RmtInf>Ustrd"`
+	AdditionalInfo string      `xml:"AddtlTxInf"`
+}
+
+// amount prefers the transaction amount of AmtDtls (.02) over Amt (.08).
+func (d camtTxDetails) amount() camtAmount {
+	if d.TxAmount.Value != "" {
+		return d.TxAmount
+	}
+	return d.Amount
+}
+
+type camtParties struct {
+	Debtor          camtParty `xml:"Dbtr"`
+	DebtorAccount   string    `xml:"DbtrAcct>Id>IBAN"`
+	Creditor        camtParty `xml:"Cdtr"`
+	CreditorAccount string    `xml:"CdtrAcct>Id>IBAN"`
+}
+
+type camtParty struct {
+	Name      string `xml:"Nm"`
+	PartyName string `xml:"Pty>Nm"`
+}
+
+func (p camtParty) name() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.PartyName
+}
+
+// ParseCAMT053 reads the booked entries of all statements in a camt.053
+// document. Batch entries with individual transaction amounts are split
+// into one transaction per detail.
+func ParseCAMT053(data []byte) ([]transaction.Transaction, error) {
+	var document camtDocument
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		content, err := io.ReadAll(input)
+		return strings.NewReader(decode(content)), err
+	}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("invalid camt.053 document: %w", err)
+	}
+	if len(document.Statements) == 0 {
+		return nil, errors.New("camt.053 document contains no statement")
+	}
+
+	transactions := make([]transaction.Transaction, 0)
+	for _, statement := range document.Statements {
+		for _, entry := range statement.Entries {
+			if !entry.Status.booked() {
+				continue
+			}
+
+			parsed, err := camtEntryTransactions(entry)
+			if err != nil {
+				return nil, err
+			}
+			for i := range parsed {
+				parsed[i].AccountIBAN = normalizeIBAN(statement.IBAN)
+			}
+			transactions = append(transactions, parsed...)
+		}
+	}
+
+	return transactions, nil
+}
+
+func camtEntryTransactions(entry camtEntry) ([]transaction.Transaction, error) {
+	bookingDate, err := entry.BookingDate.parse()
+	if err != nil {
+		return nil, err
+	}
+	valueDate, err := entry.ValueDate.parse()
+	if err != nil {
+		return nil, err
+	}
+	if bookingDate == nil {
+		bookingDate = valueDate
+	}
+	if bookingDate == nil {
+		return nil, errors.New("camt.053 entry without booking date")
+	}
+
+	base := transaction.Transaction{
+		BookingDate: *bookingDate,
+		ValueDate:   valueDate,
+		Source:      transaction.SourceCAMT053,
+		Reference:   strings.TrimSpace(entry.ServicerRef),
+		Purpose:     strings.TrimSpace(entry.AdditionalInfo),
+	}
+
+	split := len(entry.Details) > 1
+	for _, details := range entry.Details {
+		split = split && details.amount().Value != ""
+	}
+
+	if !split {
+		result := base
+		if err := setCamtAmount(&result, entry.Amount, entry.CreditDebit); err != nil {
+			return nil, err
+		}
+		if len(entry.Details) == 1 {
+			applyCamtDetails(&result, entry.Details[0])
+		}
+		return []transaction.Transaction{result}, nil
+	}
+
+	results := make([]transaction.Transaction, 0, len(entry.Details))
+	for _, details := range entry.Details {
+		result := base
+		creditDebit := details.CreditDebit
+		if creditDebit == "" {
+			creditDebit = entry.CreditDebit
+		}
+		if err := setCamtAmount(&result, details.amount(), creditDebit); err != nil {
+			return nil, err
+		}
+		applyCamtDetails(&result, details)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func setCamtAmount(t *transaction.Transaction, amount camtAmount, creditDebit string) error {
+	cents, err := parseCents(amount.Value)
+	if err != nil {
+		return err
+	}
+
+	switch strings.TrimSpace(creditDebit) {
+	case "DBIT":
+		cents = -cents
+	case "CRDT":
+	default:
+		return fmt.Errorf("invalid credit/debit indicator %q", creditDebit)
+	}
+
+	t.AmountCents = cents
+	t.Currency = strings.ToUpper(amount.Currency)
+	return nil
+}
+
+// applyCamtDetails takes the counterparty from the side opposite to the
+// account: the creditor of a debit, the debtor of a credit.
+func applyCamtDetails(t *transaction.Transaction, details camtTxDetails) {
+	if t.AmountCents < 0 {
+		t.Counterparty = details.Parties.Creditor.name()
+		t.CounterpartyIBAN = normalizeIBAN(details.Parties.CreditorAccount)
+	} else {
+		t.Counterparty = details.Parties.Debtor.name()
+		t.CounterpartyIBAN = normalizeIBAN(details.Parties.DebtorAccount)
+	}
+	t.Counterparty = strings.TrimSpace(t.Counterparty)
+
+	if purpose := strings.TrimSpace(strings.Join(details.Unstructured, "")); purpose != "" {
+		t.Purpose = purpose
+	} else if info := strings.TrimSpace(details.AdditionalInfo); info != "" {
+		t.Purpose = info
+	}
+
+	if ref := strings.TrimSpace(details.EndToEndID); ref != "" && ref != "NOTPROVIDED" {
+		t.Reference = ref
+	}
+}
@@ -0,0 +1,224 @@
+package bankimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"wondee/finance-app-backend/internal/transaction"
+)
+
+// Columns of bank CSV exports.
+const (
+	columnBookingDate  = "bookingDate"
+	columnValueDate    = "valueDate"
+	columnAmount       = "amount"
+	columnDebit        = "debit"
+	columnCredit       = "credit"
+	columnCurrency     = "currency"
+	columnCounterparty = "counterparty"
+	columnPayer        = "payer"
+	columnIBAN         = "iban"
+	columnPurpose      = "purpose"
+	columnBookingText  = "bookingText"
+	columnReference    = "reference"
+	columnAccount      = "account"
+	columnStatus       = "status"
+)
+
+// csvColumns lists the lower-cased header names used by Sparkasse, ING,
+// DKB, comdirect, Volksbanken, Deutsche Bank, Commerzbank and N26. DKB
+// exports payer and payee separately; the payer is used for credits.
+var csvColumns = map[string][]string{
+	columnBookingDate:  {"buchungstag", "buchungsdatum", "buchung", "datum", "date", "booking date"},
+	columnValueDate:    {"valutadatum", "valuta", "wertstellung", "wertstellungsdatum", "value date"},
+	columnAmount:       {"betrag", "betrag (€)", "betrag (eur)", "betrag in eur", "umsatz in eur", "umsatz", "amount", "amount (eur)"},
+	columnDebit:        {"soll", "debit"},
+	columnCredit:       {"haben", "credit"},
+	columnCurrency:     {"waehrung", "währung", "currency"},
+	columnCounterparty: {"beguenstigter/zahlungspflichtiger", "begünstigter/zahlungspflichtiger", "auftraggeber/empfänger", "name zahlungsbeteiligter", "zahlungsempfänger*in", "empfänger", "payee", "partner name"},
+	columnPayer:        {"zahlungspflichtige*r"},
+	columnIBAN:         {"kontonummer/iban", "iban zahlungsbeteiligter", "iban", "account number", "partner iban"},
+	columnPurpose:      {"verwendungszweck", "payment reference", "description"},
+	columnBookingText:  {"buchungstext", "umsatztyp", "vorgang", "transaction type"},
+	columnReference:    {"kundenreferenz (end-to-end)", "kundenreferenz", "end-to-end-referenz"},
+	columnAccount:      {"auftragskonto", "iban auftragskonto"},
+	columnStatus:       {"status"},
+}
+
+// csvHeaderSearchLines bounds the account summary some banks put above the
+// header.
+const csvHeaderSearchLines = 30
+
+// ParseCSV reads a bank CSV export. The header is searched in the first
+// lines, since ING, DKB and comdirect put an account summary above it;
+// delimiter, date and number formats are detected.
+func ParseCSV(data string) ([]transaction.Transaction, error) {
+	lines := strings.SplitAfter(data, "\n")
+
+	for i := 0; i < len(lines) && i < csvHeaderSearchLines; i++ {
+		for _, delimiter := range []rune{';', ',', '\t'} {
+			header, err := readCSVLine(lines[i], delimiter)
+			if err != nil {
+				continue
+			}
+
+			columns := mapCSVColumns(header)
+			_, hasDate := columns[columnBookingDate]
+			_, hasAmount := columns[columnAmount]
+			_, hasDebit := columns[columnDebit]
+			if hasDate && (hasAmount || hasDebit) {
+				return readCSVRows(strings.Join(lines[i+1:], ""), delimiter, columns, i+1)
+			}
+		}
+	}
+
+	return nil, errors.New("no header with booking date and amount found")
+}
+
+func readCSVLine(line string, delimiter rune) ([]string, error) {
+	reader := csv.NewReader(strings.NewReader(line))
+	reader.Comma = delimiter
+	reader.LazyQuotes = true
+	return reader.Read()
+}
+
+func mapCSVColumns(header []string) map[string]int {
+	indexes := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, exists := indexes[name]; !exists {
+			indexes[name] = i
+		}
+	}
+
+	columns := make(map[string]int)
+	for column, aliases := range csvColumns {
+		for _, alias := range aliases {
+			if index, found := indexes[alias]; found {
+				columns[column] = index
+				break
+			}
+		}
+	}
+	return columns
+}
+
+func readCSVRows(data string, delimiter rune, columns map[string]int, headerLine int) ([]transaction.Transaction, error) {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	transactions := make([]transaction.Transaction, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		get := func(column string) string {
+			index, found := columns[column]
+			if !found || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+
+		// Footer lines with balances have no booking date.
+		if get(columnBookingDate) == "" {
+			continue
+		}
+		if status := strings.ToLower(get(columnStatus)); status == "vorgemerkt" || status == "pending" {
+			continue
+		}
+
+		parsed, err := parseCSVRow(get)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", headerLine+line, err)
+		}
+		transactions = append(transactions, *parsed)
+	}
+
+	return transactions, nil
+}
+
+func parseCSVRow(get func(string) string) (*transaction.Transaction, error) {
+	bookingDate, err := parseDate(get(columnBookingDate))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &transaction.Transaction{
+		BookingDate:      bookingDate,
+		Currency:         strings.ToUpper(get(columnCurrency)),
+		Counterparty:     get(columnCounterparty),
+		CounterpartyIBAN: normalizeIBAN(get(columnIBAN)),
+		Purpose:          sepaPurpose(get(columnPurpose)),
+		Reference:        get(columnReference),
+		AccountIBAN:      normalizeIBAN(get(columnAccount)),
+		Source:           transaction.SourceCSV,
+	}
+
+	if value := get(columnValueDate); value != "" {
+		valueDate, err := parseDate(value)
+		if err != nil {
+			return nil, err
+		}
+		result.ValueDate = &valueDate
+	}
+
+	if value := get(columnAmount); value != "" {
+		result.AmountCents, err = parseCents(value)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		debit, credit, err := parseDebitCredit(get(columnDebit), get(columnCredit))
+		if err != nil {
+			return nil, err
+		}
+		result.AmountCents = credit - debit
+	}
+
+	if payer := get(columnPayer); payer != "" && result.AmountCents > 0 {
+		result.Counterparty = payer
+	}
+	if result.Purpose == "" {
+		result.Purpose = get(columnBookingText)
+	}
+	if result.Reference == "NOTPROVIDED" {
+		result.Reference = ""
+	}
+
+	return result, nil
+}
+
+// parseDebitCredit reads the separate debit and credit columns as positive
+// amounts; some banks sign the debit, others do not.
+func parseDebitCredit(debitValue, creditValue string) (int64, int64, error) {
+	var debit, credit int64
+	var err error
+
+	if debitValue != "" {
+		if debit, err = parseCents(debitValue); err != nil {
+			return 0, 0, err
+		}
+	}
+	if creditValue != "" {
+		if credit, err = parseCents(creditValue); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if debit < 0 {
+		debit = -debit
+	}
+	return debit, credit, nil
+}
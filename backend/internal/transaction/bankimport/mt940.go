@@ -0,0 +1,173 @@
+package bankimport
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"wondee/finance-app-backend/internal/transaction"
+)
+
+var (
+	mt940Tag = regexp.MustCompile(`^:(\d{2}[A-Z]?):`)
+
+	// value date, entry date, debit/credit mark, funds code, amount,
+	// transaction type and the customer reference up to "//"
+	mt940StatementLine = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d{0,2})([NFS][A-Z0-9]{3})([^/\n]*)`)
+
+	mt940Subfield = regexp.MustCompile(`\?(\d{2})`)
+)
+
+type mt940Field struct {
+	tag   string
+	value string
+}
+
+// ParseMT940 reads the statement lines (:61:) of one or more MT940
+// statements together with their information to account owner (:86:). The
+// German structured :86: format with ?-subfields is split into
+// counterparty, account and purpose; other banks' free text is kept as
+// purpose.
+func ParseMT940(data string) ([]transaction.Transaction, error) {
+	fields := splitMT940(data)
+	if len(fields) == 0 {
+		return nil, errors.New("mt940 statement contains no fields")
+	}
+
+	transactions := make([]transaction.Transaction, 0)
+	account, currency := "", ""
+	var current *transaction.Transaction
+
+	flush := func() {
+		if current != nil {
+			transactions = append(transactions, *current)
+			current = nil
+		}
+	}
+
+	for _, field := range fields {
+		switch field.tag {
+		case "25":
+			account = normalizeIBAN(field.value)
+		case "60F", "60M":
+			if len(field.value) >= 10 {
+				currency = strings.ToUpper(field.value[7:10])
+			}
+		case "61":
+			flush()
+			parsed, err := parseMT940StatementLine(field.value)
+			if err != nil {
+				return nil, err
+			}
+			parsed.AccountIBAN = account
+			parsed.Currency = currency
+			current = parsed
+		case "86":
+			if current != nil {
+				applyMT940Information(current, field.value)
+			}
+		}
+	}
+	flush()
+
+	return transactions, nil
+}
+
+// splitMT940 splits the message into fields; lines not starting with a tag
+// continue the previous field.
+func splitMT940(data string) []mt940Field {
+	var fields []mt940Field
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		if match := mt940Tag.FindStringSubmatch(line); match != nil {
+			fields = append(fields, mt940Field{tag: match[1], value: line[len(match[0]):]})
+			continue
+		}
+		if len(fields) > 0 && line != "-" && !strings.HasPrefix(line, "{") {
+			fields[len(fields)-1].value += "\n" + line
+		}
+	}
+	return fields
+}
+
+func parseMT940StatementLine(value string) (*transaction.Transaction, error) {
+	match := mt940StatementLine.FindStringSubmatch(value)
+	if match == nil {
+		return nil, fmt.Errorf("invalid mt940 statement line %q", value)
+	}
+
+	valueDate, err := time.Parse("060102", match[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid mt940 value date %q", match[1])
+	}
+
+	bookingDate := valueDate
+	if match[2] != "" {
+		entry, err := time.Parse("0102", match[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid mt940 entry date %q", match[2])
+		}
+
+		// The entry date has no year; it may fall into the year before or
+		// after the value date around new year.
+		year := valueDate.Year()
+		switch {
+		case entry.Month() == time.December && valueDate.Month() == time.January:
+			year--
+		case entry.Month() == time.January && valueDate.Month() == time.December:
+			year++
+		}
+		bookingDate = time.Date(year, entry.Month(), entry.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	cents, err := parseCents(match[5])
+	if err != nil {
+		return nil, err
+	}
+	if match[3] == "D" || match[3] == "RC" {
+		cents = -cents
+	}
+
+	reference := strings.TrimSpace(match[7])
+	if reference == "NONREF" {
+		reference = ""
+	}
+
+	return &transaction.Transaction{
+		BookingDate: bookingDate,
+		ValueDate:   &valueDate,
+		AmountCents: cents,
+		Reference:   reference,
+		Source:      transaction.SourceMT940,
+	}, nil
+}
+
+func applyMT940Information(t *transaction.Transaction, value string) {
+	// Lines are wrapped at 65 characters regardless of content.
+	value = strings.ReplaceAll(value, "\n", "")
+
+	if len(value) < 4 || value[3] != '?' {
+		t.Purpose = strings.TrimSpace(value)
+		return
+	}
+
+	subfields := make(map[string]string)
+	indexes := mt940Subfield.FindAllStringSubmatchIndex(value, -1)
+	for i, index := range indexes {
+		end := len(value)
+		if i+1 < len(indexes) {
+			end = indexes[i+1][0]
+		}
+		subfields[value[index[2]:index[3]]] += value[index[1]:end]
+	}
+
+	var purpose strings.Builder
+	for _, key := range []string{"20", "21", "22", "23", "24", "25", "26", "27", "28", "29", "60", "61", "62", "63"} {
+		purpose.WriteString(subfields[key])
+	}
+
+	t.Purpose = sepaPurpose(purpose.String())
+	t.Counterparty = strings.TrimSpace(subfields["32"] + subfields["33"])
+	t.CounterpartyIBAN = normalizeIBAN(subfields["31"])
+}
@@ -0,0 +1,378 @@
+// Package recurring finds payments in the transaction history that repeat
+// with a stable interval and amount, such as subscriptions, rent or salary.
+// The detected patterns are compared with the fixed costs of a workspace:
+// patterns without a fixed cost become suggestions, fixed costs whose
+// payments stopped or changed their amount are flagged.
+package recurring
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/transaction"
+)
+
+// Kinds of alerts.
+const (
+	AlertStopped       = "stopped"
+	AlertAmountChanged = "amountChanged"
+)
+
+// Options tune the detection.
+type Options struct {
+	// MinOccurrences is the number of payments needed to detect a pattern
+	// of up to a quarter; half-yearly and yearly payments need two.
+	MinOccurrences int
+	// AmountTolerance is the allowed relative difference between two
+	// consecutive payments of a pattern, so that price increases do not
+	// break it.
+	AmountTolerance float64
+	// IntervalTolerance is the allowed relative deviation of a gap between
+	// two payments from the period, covering weekends and short months.
+	IntervalTolerance float64
+	// ChangeTolerance is the relative difference of the latest payment to a
+	// fixed cost that is reported as changed amount.
+	ChangeTolerance float64
+	// MinSimilarity is the share of a fixed cost's name that must appear in
+	// the payments to link them.
+	MinSimilarity float64
+}
+
+var DefaultOptions = Options{
+	MinOccurrences:    3,
+	AmountTolerance:   0.2,
+	IntervalTolerance: 0.15,
+	ChangeTolerance:   0.05,
+	MinSimilarity:     0.5,
+}
+
+// period is a supported distance between payments.
+type period struct {
+	frequency cost.Frequency
+	interval  int
+	days      float64
+}
+
+var periods = []period{
+	{cost.FrequencyWeekly, 1, 7},
+	{cost.FrequencyWeekly, 2, 14},
+	{cost.FrequencyMonthly, 1, 30.44},
+	{cost.FrequencyMonthly, 2, 60.88},
+	{cost.FrequencyMonthly, 3, 91.31},
+	{cost.FrequencyMonthly, 6, 182.62},
+	{cost.FrequencyYearly, 1, 365.25},
+}
+
+// Pattern is a series of payments to or from the same counterparty. Every
+// transaction belongs to at most one pattern, so the latest payment
+// identifies it.
+type Pattern struct {
+	Counterparty     string
+	CounterpartyIBAN string
+	Purpose          string // Purpose of the latest payment
+	Currency         string // Empty for the workspace's base currency
+	AmountCents      int64  // Amount of the latest payment
+	Recurrence       cost.Recurrence
+	Transactions     []transaction.Transaction // Oldest first
+	// NextDate is when the next payment is expected.
+	NextDate time.Time
+	// Active is false if the next payment is overdue at the end of the
+	// imported history.
+	Active bool
+}
+
+// First returns the earliest payment.
+func (p *Pattern) First() *transaction.Transaction {
+	return &p.Transactions[0]
+}
+
+// Last returns the latest payment.
+func (p *Pattern) Last() *transaction.Transaction {
+	return &p.Transactions[len(p.Transactions)-1]
+}
+
+// FixedCost proposes a fixed cost for the pattern, valid from the month of
+// the first payment and due like the payments so far.
+func (p *Pattern) FixedCost() cost.FixedCost {
+	first := p.First()
+	from := first.Month()
+
+	recurrence := p.Recurrence
+	recurrence.Anchor = &from
+	if recurrence.Frequency == cost.FrequencyWeekly {
+		recurrence.AnchorDay = first.BookingDate.Day()
+	}
+
+	name := p.Counterparty
+	if name == "" {
+		name = p.Purpose
+	}
+
+	return cost.FixedCost{
+		Name:       name,
+		Amount:     p.Last().Amount(),
+		Currency:   p.Currency,
+		From:       &from,
+		Recurrence: recurrence,
+	}
+}
+
+// Alert reports a fixed cost whose payments differ from the plan.
+type Alert struct {
+	Kind      string
+	FixedCost *cost.FixedCost
+	Pattern   *Pattern
+	// ExpectedCents is the planned amount at the latest payment.
+	ExpectedCents int64
+}
+
+type Review struct {
+	Suggestions []Pattern
+	Alerts      []Alert
+}
+
+// Detect finds the recurring payments among the transactions. Payments are
+// grouped by counterparty account, or name if the account is unknown, and
+// direction. A group forms a pattern if its gaps fit one period; otherwise
+// its payments are split by amount, since one counterparty may collect
+// several subscriptions.
+func Detect(transactions []transaction.Transaction, options Options) []Pattern {
+	var end time.Time
+	groups := make(map[string][]transaction.Transaction)
+	var keys []string
+
+	for _, t := range transactions {
+		if t.BookingDate.After(end) {
+			end = t.BookingDate
+		}
+
+		key := payeeKey(&t)
+		if key == "" {
+			continue
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], t)
+	}
+	sort.Strings(keys)
+
+	patterns := make([]Pattern, 0)
+	for _, key := range keys {
+		group := groups[key]
+		sort.SliceStable(group, func(i, j int) bool { return group[i].BookingDate.Before(group[j].BookingDate) })
+
+		if pattern, ok := detectSeries(group, end, options); ok {
+			patterns = append(patterns, pattern)
+			continue
+		}
+		for _, cluster := range amountClusters(group, options.AmountTolerance) {
+			if pattern, ok := detectSeries(cluster, end, options); ok {
+				patterns = append(patterns, pattern)
+			}
+		}
+	}
+
+	return patterns
+}
+
+// Compare links the patterns with the fixed costs relevant in the month of
+// the latest payment, by direction, name and amount. Active patterns without
+// fixed cost are suggested; linked fixed costs are flagged if their payments
+// stopped or the latest payment differs from the planned amount.
+func Compare(fixedCosts []cost.FixedCost, patterns []Pattern, options Options) Review {
+	review := Review{Suggestions: make([]Pattern, 0), Alerts: make([]Alert, 0)}
+	covered := make(map[int]bool)
+
+	for i := range fixedCosts {
+		fc := &fixedCosts[i]
+		best := -1
+		for j := range patterns {
+			if covered[j] || !matches(fc, &patterns[j], options) {
+				continue
+			}
+			if best < 0 || closer(fc, &patterns[j], &patterns[best]) {
+				best = j
+			}
+		}
+		if best < 0 {
+			continue
+		}
+		covered[best] = true
+
+		pattern := &patterns[best]
+		month := pattern.Last().Month()
+		expected := int64(fc.AmountAt(&month)) * 100
+
+		switch {
+		case !pattern.Active:
+			review.Alerts = append(review.Alerts, Alert{Kind: AlertStopped, FixedCost: fc, Pattern: pattern, ExpectedCents: expected})
+		case changed(expected, pattern.AmountCents, options.ChangeTolerance):
+			review.Alerts = append(review.Alerts, Alert{Kind: AlertAmountChanged, FixedCost: fc, Pattern: pattern, ExpectedCents: expected})
+		}
+	}
+
+	for j := range patterns {
+		if !covered[j] && patterns[j].Active {
+			review.Suggestions = append(review.Suggestions, patterns[j])
+		}
+	}
+
+	return review
+}
+
+// detectSeries checks whether the payments, oldest first, recur with one
+// period and without jumps in the amount.
+func detectSeries(payments []transaction.Transaction, end time.Time, options Options) (Pattern, bool) {
+	if len(payments) < 2 {
+		return Pattern{}, false
+	}
+
+	gaps := make([]float64, 0, len(payments)-1)
+	for i := 1; i < len(payments); i++ {
+		if changed(payments[i-1].AmountCents, payments[i].AmountCents, options.AmountTolerance) {
+			return Pattern{}, false
+		}
+		gaps = append(gaps, payments[i].BookingDate.Sub(payments[i-1].BookingDate).Hours()/24)
+	}
+
+	p, ok := fittingPeriod(gaps, options.IntervalTolerance)
+	if !ok {
+		return Pattern{}, false
+	}
+	if len(payments) < options.MinOccurrences && p.days < 180 {
+		return Pattern{}, false
+	}
+
+	last := payments[len(payments)-1]
+	next := nextDate(last.BookingDate, p)
+	grace := time.Duration(p.days*options.IntervalTolerance*24) * time.Hour
+
+	return Pattern{
+		Counterparty:     last.Counterparty,
+		CounterpartyIBAN: last.CounterpartyIBAN,
+		Purpose:          last.Purpose,
+		Currency:         last.Currency,
+		AmountCents:      last.AmountCents,
+		Recurrence:       cost.Recurrence{Frequency: p.frequency, Interval: p.interval},
+		Transactions:     payments,
+		NextDate:         next,
+		Active:           !end.After(next.Add(grace)),
+	}, true
+}
+
+// fittingPeriod returns the period all gaps match.
+func fittingPeriod(gaps []float64, tolerance float64) (period, bool) {
+	for _, p := range periods {
+		fits := true
+		for _, gap := range gaps {
+			if math.Abs(gap-p.days) > max(p.days*tolerance, 2) {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			return p, true
+		}
+	}
+	return period{}, false
+}
+
+func nextDate(last time.Time, p period) time.Time {
+	switch p.frequency {
+	case cost.FrequencyWeekly:
+		return last.AddDate(0, 0, 7*p.interval)
+	case cost.FrequencyYearly:
+		return last.AddDate(p.interval, 0, 0)
+	default:
+		return last.AddDate(0, p.interval, 0)
+	}
+}
+
+// amountClusters splits payments into groups of similar amounts, each kept
+// in date order.
+func amountClusters(payments []transaction.Transaction, tolerance float64) [][]transaction.Transaction {
+	sorted := make([]transaction.Transaction, len(payments))
+	copy(sorted, payments)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].AmountCents < sorted[j].AmountCents })
+
+	var clusters [][]transaction.Transaction
+	start := 0
+	for i := 1; i <= len(sorted); i++ {
+		if i == len(sorted) || changed(sorted[i-1].AmountCents, sorted[i].AmountCents, tolerance) {
+			cluster := sorted[start:i]
+			sort.SliceStable(cluster, func(a, b int) bool { return cluster[a].BookingDate.Before(cluster[b].BookingDate) })
+			clusters = append(clusters, cluster)
+			start = i
+		}
+	}
+	return clusters
+}
+
+// matches tells whether a pattern may pay a fixed cost relevant at its
+// latest payment.
+func matches(fc *cost.FixedCost, p *Pattern, options Options) bool {
+	month := p.Last().Month()
+	if !types.IsRelevant(&month, fc.From, fc.To) {
+		return false
+	}
+	if (fc.Amount < 0) != (p.AmountCents < 0) {
+		return false
+	}
+	return similarity(fc, p) >= options.MinSimilarity
+}
+
+// closer tells whether pattern a fits the fixed cost better than b: by
+// name first, then by amount.
+func closer(fc *cost.FixedCost, a, b *Pattern) bool {
+	if sa, sb := similarity(fc, a), similarity(fc, b); sa != sb {
+		return sa > sb
+	}
+	planned := int64(fc.Amount) * 100
+	return abs(a.AmountCents-planned) < abs(b.AmountCents-planned)
+}
+
+func similarity(fc *cost.FixedCost, p *Pattern) float64 {
+	return transaction.NameSimilarity(fc.Name, p.Counterparty+" "+p.Purpose)
+}
+
+// changed tells whether two amounts differ by more than the relative
+// tolerance, ignoring differences below one currency unit.
+func changed(a, b int64, tolerance float64) bool {
+	difference := abs(a - b)
+	return difference > 100 && float64(difference) > tolerance*float64(max(abs(a), abs(b)))
+}
+
+// payeeKey groups payments by direction and counterparty: the account if
+// known, else the letters of the name, so that card payments with varying
+// terminal numbers fall together.
+func payeeKey(t *transaction.Transaction) string {
+	direction := "out"
+	if t.AmountCents > 0 {
+		direction = "in"
+	}
+
+	if t.CounterpartyIBAN != "" {
+		return direction + ":" + strings.ToUpper(t.CounterpartyIBAN)
+	}
+
+	name := strings.Join(strings.FieldsFunc(strings.ToLower(t.Counterparty), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}), " ")
+	if name == "" {
+		return ""
+	}
+	return direction + ":" + name
+}
+
+func abs(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
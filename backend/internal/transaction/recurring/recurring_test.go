@@ -0,0 +1,200 @@
+package recurring
+
+import (
+	"testing"
+	"time"
+
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/platform/types"
+	"wondee/finance-app-backend/internal/transaction"
+)
+
+func payment(id uint, date string, cents int64, counterparty string) transaction.Transaction {
+	bookingDate, _ := time.Parse("2006-01-02", date)
+	return transaction.Transaction{ID: id, BookingDate: bookingDate, AmountCents: cents, Counterparty: counterparty}
+}
+
+// history has a monthly subscription with a price increase, monthly rent
+// to a known account, weekly card payments at the same shop with varying
+// amounts, a yearly insurance and a gym membership that ended in February.
+func history() []transaction.Transaction {
+	rent := func(id uint, date string) transaction.Transaction {
+		t := payment(id, date, -120000, "Hausverwaltung Meier")
+		t.CounterpartyIBAN = "DE02120300000000202051"
+		return t
+	}
+
+	return []transaction.Transaction{
+		payment(1, "2025-01-03", -1299, "NETFLIX.COM 4711"),
+		payment(2, "2025-02-03", -1299, "NETFLIX.COM 4712"),
+		payment(3, "2025-03-04", -1399, "NETFLIX.COM 4713"),
+		payment(4, "2025-04-03", -1399, "NETFLIX.COM 4714"),
+		rent(5, "2025-01-01"),
+		rent(6, "2025-02-03"),
+		rent(7, "2025-03-01"),
+		rent(8, "2025-04-01"),
+		payment(9, "2025-03-08", -2310, "Bakery"),
+		payment(10, "2025-03-15", -480, "Bakery"),
+		payment(11, "2025-03-22", -9900, "Bakery"),
+		payment(12, "2024-04-15", -35000, "Car Insurance AG"),
+		payment(13, "2025-04-14", -36000, "Car Insurance AG"),
+		payment(14, "2024-12-01", -3000, "Gym Nord"),
+		payment(15, "2025-01-01", -3000, "Gym Nord"),
+		payment(16, "2025-02-01", -3000, "Gym Nord"),
+	}
+}
+
+func findPattern(patterns []Pattern, counterparty string) *Pattern {
+	for i := range patterns {
+		if patterns[i].Counterparty == counterparty {
+			return &patterns[i]
+		}
+	}
+	return nil
+}
+
+func TestDetect(t *testing.T) {
+	patterns := Detect(history(), DefaultOptions)
+
+	if len(patterns) != 4 {
+		t.Fatalf("Expected 4 patterns, got %d: %+v", len(patterns), patterns)
+	}
+
+	netflix := findPattern(patterns, "NETFLIX.COM 4714")
+	if netflix == nil {
+		t.Fatal("Expected the subscription to be detected across changing names and prices")
+	}
+	if netflix.Recurrence.Frequency != cost.FrequencyMonthly || netflix.Recurrence.Interval != 1 {
+		t.Errorf("Expected monthly subscription, got %+v", netflix.Recurrence)
+	}
+	if netflix.AmountCents != -1399 || len(netflix.Transactions) != 4 || !netflix.Active {
+		t.Errorf("Unexpected subscription: %+v", netflix)
+	}
+	if netflix.NextDate.Format("2006-01-02") != "2025-05-03" {
+		t.Errorf("Expected next payment on 2025-05-03, got %v", netflix.NextDate)
+	}
+
+	insurance := findPattern(patterns, "Car Insurance AG")
+	if insurance == nil || insurance.Recurrence.Frequency != cost.FrequencyYearly {
+		t.Errorf("Expected the yearly insurance from two payments, got %+v", insurance)
+	}
+
+	gym := findPattern(patterns, "Gym Nord")
+	if gym == nil || gym.Active {
+		t.Errorf("Expected an inactive gym membership, got %+v", gym)
+	}
+
+	if findPattern(patterns, "Bakery") != nil {
+		t.Error("Expected weekly payments with varying amounts to be ignored")
+	}
+}
+
+func TestDetect_SplitsCounterpartyByAmount(t *testing.T) {
+	var transactions []transaction.Transaction
+	for i, date := range []string{"2025-01-05", "2025-02-05", "2025-03-05"} {
+		transactions = append(transactions,
+			payment(uint(2*i+1), date, -1299, "PayPal Europe"),
+			payment(uint(2*i+2), date, -999, "PayPal Europe"),
+		)
+	}
+
+	patterns := Detect(transactions, DefaultOptions)
+
+	if len(patterns) != 2 {
+		t.Fatalf("Expected one pattern per amount, got %d", len(patterns))
+	}
+	for _, pattern := range patterns {
+		if len(pattern.Transactions) != 3 {
+			t.Errorf("Expected three payments per pattern, got %d", len(pattern.Transactions))
+		}
+	}
+}
+
+func TestDetect_RequiresRegularGaps(t *testing.T) {
+	transactions := []transaction.Transaction{
+		payment(1, "2025-01-05", -5000, "Electronics Shop"),
+		payment(2, "2025-01-20", -5000, "Electronics Shop"),
+		payment(3, "2025-03-28", -5000, "Electronics Shop"),
+	}
+
+	if patterns := Detect(transactions, DefaultOptions); len(patterns) != 0 {
+		t.Errorf("Expected no pattern, got %+v", patterns)
+	}
+}
+
+func TestPattern_FixedCost(t *testing.T) {
+	patterns := Detect(history(), DefaultOptions)
+	netflix := findPattern(patterns, "NETFLIX.COM 4714")
+
+	fc := netflix.FixedCost()
+
+	if fc.Name != "NETFLIX.COM 4714" || fc.Amount != -14 {
+		t.Errorf("Unexpected fixed cost: %+v", fc)
+	}
+	if fc.From == nil || *fc.From != (types.YearMonth{Year: 2025, Month: 1}) {
+		t.Errorf("Expected the cost to start with the first payment, got %v", fc.From)
+	}
+	if err := fc.Recurrence.Validate(); err != nil {
+		t.Errorf("Expected a valid recurrence, got %v", err)
+	}
+	if fc.Occurrences(&types.YearMonth{Year: 2025, Month: 6}) != 1 {
+		t.Error("Expected the cost to be due every month")
+	}
+}
+
+func TestPattern_FixedCost_Weekly(t *testing.T) {
+	pattern := Pattern{
+		Counterparty: "Cleaner",
+		Recurrence:   cost.Recurrence{Frequency: cost.FrequencyWeekly, Interval: 1},
+		Transactions: []transaction.Transaction{payment(1, "2025-03-06", -4000, "Cleaner")},
+	}
+
+	fc := pattern.FixedCost()
+
+	if fc.Recurrence.AnchorDay != 6 {
+		t.Errorf("Expected the weekly cost to be anchored on the first payment, got %+v", fc.Recurrence)
+	}
+	if err := fc.Recurrence.Validate(); err != nil {
+		t.Errorf("Expected a valid recurrence, got %v", err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	patterns := Detect(history(), DefaultOptions)
+	fixedCosts := []cost.FixedCost{
+		{ID: 1, Name: "Netflix", Amount: -12},
+		{ID: 2, Name: "Rent Hausverwaltung", Amount: -1200},
+		{ID: 3, Name: "Gym", Amount: -30},
+		{ID: 4, Name: "Newspaper", Amount: -20},
+	}
+
+	review := Compare(fixedCosts, patterns, DefaultOptions)
+
+	if len(review.Suggestions) != 1 || review.Suggestions[0].Counterparty != "Car Insurance AG" {
+		t.Errorf("Expected only the insurance to be suggested, got %+v", review.Suggestions)
+	}
+
+	alerts := make(map[int]string)
+	for _, alert := range review.Alerts {
+		alerts[alert.FixedCost.ID] = alert.Kind
+	}
+	want := map[int]string{1: AlertAmountChanged, 3: AlertStopped}
+	if len(alerts) != len(want) || alerts[1] != want[1] || alerts[3] != want[3] {
+		t.Errorf("Expected alerts %v, got %v", want, alerts)
+	}
+}
+
+func TestCompare_IgnoresEndedFixedCosts(t *testing.T) {
+	patterns := Detect(history(), DefaultOptions)
+	ended := types.YearMonth{Year: 2024, Month: 12}
+	fixedCosts := []cost.FixedCost{{ID: 1, Name: "Netflix", Amount: -13, To: &ended}}
+
+	review := Compare(fixedCosts, patterns, DefaultOptions)
+
+	if len(review.Alerts) != 0 {
+		t.Errorf("Expected no alerts, got %+v", review.Alerts)
+	}
+	if findPattern(review.Suggestions, "NETFLIX.COM 4714") == nil {
+		t.Error("Expected the subscription to be suggested again")
+	}
+}
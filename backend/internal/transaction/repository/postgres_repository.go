@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"time"
+
+	"wondee/finance-app-backend/internal/spend"
+	"wondee/finance-app-backend/internal/transaction"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *PostgresRepository) ListTransactions(workspaceID uint, from, to *time.Time) ([]transaction.Transaction, error) {
+	query := r.DB.Where("workspace_id = ?", workspaceID)
+	if from != nil {
+		query = query.Where("booking_date >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("booking_date <= ?", *to)
+	}
+
+	var transactions []transaction.Transaction
+	result := query.Order("booking_date DESC, id DESC").Find(&transactions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return transactions, nil
+}
+
+func (r *PostgresRepository) GetTransaction(id uint, workspaceID uint) (*transaction.Transaction, error) {
+	var t transaction.Transaction
+	result := r.DB.Where("id = ? AND workspace_id = ?", id, workspaceID).First(&t)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &t, nil
+}
+
+// DeleteTransaction removes the transaction; costs it was matched with,
+// including those in the trash, are pending again.
+func (r *PostgresRepository) DeleteTransaction(id uint, workspaceID uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND workspace_id = ?", id, workspaceID).Delete(&transaction.Transaction{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Model(&spend.MonthlyPaymentStatus{}).
+			Where("transaction_id = ? AND workspace_id = ?", id, workspaceID).
+			Updates(map[string]interface{}{"is_paid": false, "paid_at": nil, "transaction_id": nil}).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&spend.OneTimePendingCost{}).
+			Where("transaction_id = ? AND workspace_id = ?", id, workspaceID).
+			Updates(map[string]interface{}{"is_paid": false, "transaction_id": nil}).Error
+	})
+}
+
+func (r *PostgresRepository) FindFingerprints(workspaceID uint, fingerprints []string) ([]string, error) {
+	found := make([]string, 0)
+	if len(fingerprints) == 0 {
+		return found, nil
+	}
+
+	result := r.DB.Model(&transaction.Transaction{}).
+		Where("workspace_id = ? AND fingerprint IN ?", workspaceID, fingerprints).
+		Pluck("fingerprint", &found)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return found, nil
+}
+
+// SaveTransactions skips rows whose fingerprint got stored in the meantime
+// by a concurrent import instead of failing the whole import. Rows are
+// inserted one by one, as a batch insert would hand out the returned IDs in
+// order without regard to the skipped rows.
+func (r *PostgresRepository) SaveTransactions(transactions []transaction.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		for i := range transactions {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&transactions[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"time"
+
+	"wondee/finance-app-backend/internal/transaction"
+
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for transaction ledger data access
+type Repository interface {
+	// ListTransactions returns the transactions booked between from and to,
+	// both inclusive and optional, newest first.
+	ListTransactions(workspaceID uint, from, to *time.Time) ([]transaction.Transaction, error)
+	GetTransaction(id uint, workspaceID uint) (*transaction.Transaction, error)
+	DeleteTransaction(id uint, workspaceID uint) error
+
+	// FindFingerprints returns which of the given fingerprints are already
+	// stored in the workspace.
+	FindFingerprints(workspaceID uint, fingerprints []string) ([]string, error)
+	// SaveTransactions creates all transactions in one database transaction.
+	// Transactions already stored are skipped and keep ID 0.
+	SaveTransactions(transactions []transaction.Transaction) error
+}
+
+// PostgresRepository implements Repository using GORM
+type PostgresRepository struct {
+	DB *gorm.DB
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"wondee/finance-app-backend/internal/transaction"
+	"wondee/finance-app-backend/internal/transaction/repository"
+)
+
+// TransactionService provides business logic for the transaction ledger
+type TransactionService struct {
+	repo repository.Repository
+}
+
+// NewTransactionService creates a new TransactionService instance
+func NewTransactionService(repo repository.Repository) *TransactionService {
+	return &TransactionService{repo: repo}
+}
+
+// ImportEntry is a parsed transaction and whether it is already stored.
+type ImportEntry struct {
+	Transaction transaction.Transaction
+	Duplicate   bool
+}
+
+type ImportResult struct {
+	Entries    []ImportEntry
+	Duplicates int
+	Imported   int
+}
+
+// Import stores the parsed transactions of a statement in the workspace,
+// skipping those imported before. Statements may overlap, so every
+// transaction is checked individually. A dry run only marks the
+// duplicates.
+func (s *TransactionService) Import(workspaceID uint, transactions []transaction.Transaction, dryRun bool) (*ImportResult, error) {
+	for i := range transactions {
+		transactions[i].WorkspaceID = workspaceID
+	}
+	transaction.AssignFingerprints(transactions)
+
+	fingerprints := make([]string, len(transactions))
+	for i, t := range transactions {
+		fingerprints[i] = t.Fingerprint
+	}
+
+	existing, err := s.repo.FindFingerprints(workspaceID, fingerprints)
+	if err != nil {
+		return nil, err
+	}
+	stored := make(map[string]bool, len(existing))
+	for _, fingerprint := range existing {
+		stored[fingerprint] = true
+	}
+
+	result := &ImportResult{Entries: make([]ImportEntry, 0, len(transactions))}
+	newTransactions := make([]transaction.Transaction, 0, len(transactions))
+
+	for _, t := range transactions {
+		duplicate := stored[t.Fingerprint]
+		if duplicate {
+			result.Duplicates++
+		} else {
+			newTransactions = append(newTransactions, t)
+		}
+		result.Entries = append(result.Entries, ImportEntry{Transaction: t, Duplicate: duplicate})
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	if err := s.repo.SaveTransactions(newTransactions); err != nil {
+		return nil, err
+	}
+
+	// Report the stored rows with their new IDs. Rows stored by a
+	// concurrent import in the meantime were skipped and have none.
+	saved := 0
+	for i := range result.Entries {
+		if result.Entries[i].Duplicate {
+			continue
+		}
+		result.Entries[i].Transaction = newTransactions[saved]
+		saved++
+
+		if result.Entries[i].Transaction.ID == 0 {
+			result.Entries[i].Duplicate = true
+			result.Duplicates++
+		} else {
+			result.Imported++
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"wondee/finance-app-backend/internal/transaction"
+	"wondee/finance-app-backend/internal/transaction/service"
+)
+
+// MockTransactionRepository implements transaction repository.Repository
+type MockTransactionRepository struct {
+	mock.Mock
+
+	// stored are the fingerprints a concurrent import saved in the meantime
+	stored []string
+}
+
+func (m *MockTransactionRepository) ListTransactions(workspaceID uint, from, to *time.Time) ([]transaction.Transaction, error) {
+	args := m.Called(workspaceID, from, to)
+	return args.Get(0).([]transaction.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) GetTransaction(id uint, workspaceID uint) (*transaction.Transaction, error) {
+	args := m.Called(id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*transaction.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) DeleteTransaction(id uint, workspaceID uint) error {
+	args := m.Called(id, workspaceID)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) FindFingerprints(workspaceID uint, fingerprints []string) ([]string, error) {
+	args := m.Called(workspaceID, fingerprints)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SaveTransactions(transactions []transaction.Transaction) error {
+	args := m.Called(transactions)
+	for i := range transactions {
+		if !slices.Contains(m.stored, transactions[i].Fingerprint) {
+			transactions[i].ID = uint(100 + i)
+		}
+	}
+	return args.Error(0)
+}
+
+func statement() []transaction.Transaction {
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	return []transaction.Transaction{
+		{BookingDate: day, AmountCents: -120000, Purpose: "Miete März"},
+		{BookingDate: day, AmountCents: -350, Purpose: "Café"},
+		{BookingDate: day, AmountCents: -350, Purpose: "Café"},
+	}
+}
+
+func fingerprints(transactions []transaction.Transaction) []string {
+	copied := append([]transaction.Transaction(nil), transactions...)
+	transaction.AssignFingerprints(copied)
+
+	result := make([]string, len(copied))
+	for i, t := range copied {
+		result[i] = t.Fingerprint
+	}
+	return result
+}
+
+func TestImport_StoresNewTransactions(t *testing.T) {
+	mockRepo := new(MockTransactionRepository)
+	svc := service.NewTransactionService(mockRepo)
+
+	mockRepo.On("FindFingerprints", uint(1), mock.Anything).Return([]string{}, nil)
+	mockRepo.On("SaveTransactions", mock.MatchedBy(func(transactions []transaction.Transaction) bool {
+		return len(transactions) == 3 && transactions[0].WorkspaceID == 1 && transactions[0].Fingerprint != ""
+	})).Return(nil)
+
+	result, err := svc.Import(1, statement(), false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Imported)
+	assert.Equal(t, 0, result.Duplicates)
+	assert.Equal(t, uint(100), result.Entries[0].Transaction.ID)
+	assert.Equal(t, uint(102), result.Entries[2].Transaction.ID)
+}
+
+func TestImport_SkipsDuplicates(t *testing.T) {
+	mockRepo := new(MockTransactionRepository)
+	svc := service.NewTransactionService(mockRepo)
+
+	// The rent and one of the two equal payments were imported before.
+	known := fingerprints(statement())
+	mockRepo.On("FindFingerprints", uint(1), known).Return([]string{known[0], known[1]}, nil)
+	mockRepo.On("SaveTransactions", mock.MatchedBy(func(transactions []transaction.Transaction) bool {
+		return len(transactions) == 1 && transactions[0].Fingerprint == known[2]
+	})).Return(nil)
+
+	result, err := svc.Import(1, statement(), false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, 2, result.Duplicates)
+	assert.True(t, result.Entries[0].Duplicate)
+	assert.True(t, result.Entries[1].Duplicate)
+	assert.False(t, result.Entries[2].Duplicate)
+	assert.Equal(t, uint(100), result.Entries[2].Transaction.ID)
+}
+
+func TestImport_CountsRowsStoredConcurrentlyAsDuplicates(t *testing.T) {
+	mockRepo := new(MockTransactionRepository)
+	svc := service.NewTransactionService(mockRepo)
+
+	// Another import stores the rent between the check and the insert.
+	known := fingerprints(statement())
+	mockRepo.stored = []string{known[0]}
+	mockRepo.On("FindFingerprints", uint(1), known).Return([]string{}, nil)
+	mockRepo.On("SaveTransactions", mock.Anything).Return(nil)
+
+	result, err := svc.Import(1, statement(), false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 1, result.Duplicates)
+	assert.True(t, result.Entries[0].Duplicate)
+	assert.False(t, result.Entries[1].Duplicate)
+	assert.Equal(t, uint(101), result.Entries[1].Transaction.ID)
+}
+
+func TestImport_DryRunDoesNotSave(t *testing.T) {
+	mockRepo := new(MockTransactionRepository)
+	svc := service.NewTransactionService(mockRepo)
+
+	known := fingerprints(statement())
+	mockRepo.On("FindFingerprints", uint(1), known).Return([]string{known[0]}, nil)
+
+	result, err := svc.Import(1, statement(), true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Imported)
+	assert.Equal(t, 1, result.Duplicates)
+	mockRepo.AssertNotCalled(t, "SaveTransactions", mock.Anything)
+}
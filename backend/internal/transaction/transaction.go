@@ -0,0 +1,139 @@
+package transaction
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"wondee/finance-app-backend/internal/platform/types"
+)
+
+// Sources a transaction can be imported from.
+const (
+	SourceCAMT053 = "camt053"
+	SourceMT940   = "mt940"
+	SourceCSV     = "csv"
+)
+
+// Transaction is a booked entry of a bank statement. Unlike costs, which are
+// planned amounts, transactions record what actually happened on an account.
+//
+// The amount is kept exactly in cents as delivered by the bank; Amount()
+// rounds it to the whole units used by costs.
+type Transaction struct {
+	ID               uint       `gorm:"primaryKey"`
+	WorkspaceID      uint       `gorm:"not null;uniqueIndex:idx_transaction_fingerprint,priority:1;index:idx_transaction_ws_date,priority:1"`
+	BookingDate      time.Time  `gorm:"type:date;not null;index:idx_transaction_ws_date,priority:2"`
+	ValueDate        *time.Time `gorm:"type:date"`
+	AmountCents      int64      `gorm:"not null"` // Negative for debits
+	Currency         string     `gorm:"size:3"`   // Empty for the workspace's base currency
+	Counterparty     string
+	CounterpartyIBAN string
+	Purpose          string
+	Reference        string
+	AccountIBAN      string // The statement's own account, if known
+	Source           string `gorm:"size:10"`
+	Fingerprint      string `gorm:"size:64;not null;uniqueIndex:idx_transaction_fingerprint,priority:2"`
+	CreatedAt        time.Time
+}
+
+// TableName specifies the table name for GORM
+func (Transaction) TableName() string {
+	return "transactions"
+}
+
+// Amount returns the amount rounded to whole units.
+func (t *Transaction) Amount() int {
+	return int(math.Round(float64(t.AmountCents) / 100))
+}
+
+// Month returns the month the transaction was booked in.
+func (t *Transaction) Month() types.YearMonth {
+	return types.YearMonth{Year: t.BookingDate.Year(), Month: int(t.BookingDate.Month())}
+}
+
+// DuplicateKey identifies a transaction independent of how it was imported:
+// booking date, amount, counterparty account and the purpose with all
+// whitespace removed, since banks wrap long purposes differently. Names,
+// currency and own account are left out because not every format carries
+// them in full.
+func (t *Transaction) DuplicateKey() string {
+	return strings.Join([]string{
+		t.BookingDate.Format("2006-01-02"),
+		strconv.FormatInt(t.AmountCents, 10),
+		compact(t.CounterpartyIBAN),
+		compact(t.Purpose),
+	}, "\x1f")
+}
+
+// Fingerprint hashes the duplicate key. occurrence numbers transactions with
+// the same key within one statement (two equal card payments on one day),
+// so that both are kept on the first import and both are recognized on the
+// next.
+func Fingerprint(duplicateKey string, occurrence int) string {
+	if occurrence > 0 {
+		duplicateKey += "\x1f" + strconv.Itoa(occurrence)
+	}
+	sum := sha256.Sum256([]byte(duplicateKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// AssignFingerprints sets the fingerprint of each transaction, numbering
+// transactions with equal duplicate keys in the given order.
+func AssignFingerprints(transactions []Transaction) {
+	occurrences := make(map[string]int)
+	for i := range transactions {
+		key := transactions[i].DuplicateKey()
+		transactions[i].Fingerprint = Fingerprint(key, occurrences[key])
+		occurrences[key]++
+	}
+}
+
+// NameSimilarity returns the share of the words of name (three characters
+// or more) found in text, ignoring case. Names without such words fall back
+// to comparing the whole name.
+func NameSimilarity(name, text string) float64 {
+	text = strings.ToLower(text)
+	words := significantWords(name)
+	if len(words) == 0 {
+		if name = strings.TrimSpace(strings.ToLower(name)); name != "" && strings.Contains(text, name) {
+			return 1
+		}
+		return 0
+	}
+
+	found := 0
+	for _, word := range words {
+		if strings.Contains(text, word) {
+			found++
+		}
+	}
+	return float64(found) / float64(len(words))
+}
+
+func significantWords(value string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len([]rune(field)) >= 3 {
+			words = append(words, field)
+		}
+	}
+	return words
+}
+
+func compact(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, value)
+}
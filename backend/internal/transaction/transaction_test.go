@@ -0,0 +1,61 @@
+package transaction
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAssignFingerprints(t *testing.T) {
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	coffee := Transaction{BookingDate: day, AmountCents: -350, Purpose: "Kartenzahlung Café"}
+
+	first := []Transaction{coffee, coffee, {BookingDate: day, AmountCents: -1200}}
+	AssignFingerprints(first)
+
+	if first[0].Fingerprint == first[1].Fingerprint {
+		t.Error("Expected equal transactions within a statement to get different fingerprints")
+	}
+	if len(first[0].Fingerprint) != 64 {
+		t.Errorf("Expected a sha256 hex fingerprint, got %q", first[0].Fingerprint)
+	}
+
+	// The same payments wrapped differently by another export
+	wrapped := coffee
+	wrapped.Purpose = "Kartenzahlung\n Café"
+	wrapped.Counterparty = "Café am Markt"
+	second := []Transaction{wrapped, wrapped}
+	AssignFingerprints(second)
+
+	if second[0].Fingerprint != first[0].Fingerprint || second[1].Fingerprint != first[1].Fingerprint {
+		t.Error("Expected the same fingerprints on a re-import")
+	}
+}
+
+func TestAmountAndMonth(t *testing.T) {
+	tx := Transaction{BookingDate: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), AmountCents: -120050}
+
+	if tx.Amount() != -1201 {
+		t.Errorf("Expected -1201, got %d", tx.Amount())
+	}
+	if month := tx.Month(); month.Year != 2025 || month.Month != 3 {
+		t.Errorf("Unexpected month %v", month)
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		name, text string
+		want       float64
+	}{
+		{"Netflix", "NETFLIX.COM subscription", 1},
+		{"Car insurance", "HUK Coburg insurance 2025", 0.5},
+		{"Rent", "Supermarket", 0},
+		{"TV", "tv licence", 1}, // short names are compared as a whole
+	}
+
+	for _, tt := range tests {
+		if got := NameSimilarity(tt.name, tt.text); got != tt.want {
+			t.Errorf("NameSimilarity(%q, %q) = %v, want %v", tt.name, tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"wondee/finance-app-backend/internal/audit"
+	cost_api "wondee/finance-app-backend/internal/cost/api"
+	"wondee/finance-app-backend/internal/platform/blob"
+	"wondee/finance-app-backend/internal/trash"
+	"wondee/finance-app-backend/internal/trash/repository"
+)
+
+// Handler handles HTTP requests for the trash of deleted costs
+type Handler struct {
+	repo      repository.Repository
+	store     blob.Store
+	retention time.Duration
+	audit     *audit.Log
+	balance   cost_api.BalanceMonitor
+	now       func() time.Time
+}
+
+// NewHandler creates a new Handler instance. Deleted items are purged once
+// they are older than retention, the files of their attachments are removed
+// from store. Restores and purges are recorded in auditLog, restored costs
+// are watched by balance; both may be nil.
+func NewHandler(repo repository.Repository, store blob.Store, retention time.Duration, auditLog *audit.Log, balance cost_api.BalanceMonitor) *Handler {
+	return &Handler{
+		repo:      repo,
+		store:     store,
+		retention: retention,
+		audit:     auditLog,
+		balance:   balance,
+		now:       time.Now,
+	}
+}
+
+// Response types
+
+type ItemDTO struct {
+	Type      string    `json:"type"`
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Amount    int       `json:"amount"`
+	Currency  string    `json:"currency"`
+	DeletedAt time.Time `json:"deletedAt"`
+	PurgeAt   time.Time `json:"purgeAt"`
+}
+
+// GetTrash lists the deleted costs of the workspace, most recent first.
+func (h *Handler) GetTrash(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	items, err := h.repo.ListItems(workspaceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load trash"})
+		return
+	}
+
+	result := make([]ItemDTO, 0, len(items))
+	for _, item := range items {
+		result = append(result, ItemDTO{
+			Type:      item.Type,
+			ID:        item.ID,
+			Name:      item.Name,
+			Amount:    item.Amount,
+			Currency:  item.Currency,
+			DeletedAt: item.DeletedAt,
+			PurgeAt:   item.DeletedAt.Add(h.retention),
+		})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RestoreItem undoes the deletion of the item with path parameters "type"
+// and "id".
+func (h *Handler) RestoreItem(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	itemType, id, ok := h.parseItem(c)
+	if !ok {
+		return
+	}
+
+	name := h.itemName(workspaceID, itemType, id)
+	notify := cost_api.WatchBalance(h.balance, workspaceID)
+	if err := h.repo.Restore(workspaceID, itemType, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found in trash"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore item"})
+		return
+	}
+
+	notify(h.getUserID(c), name)
+	h.record(c, itemType, id, audit.ActionRestore)
+
+	c.Status(http.StatusNoContent)
+}
+
+// PurgeItem deletes the item with path parameters "type" and "id"
+// permanently, without waiting for the retention period.
+func (h *Handler) PurgeItem(c *gin.Context) {
+	workspaceID := h.getWorkspaceID(c)
+
+	itemType, id, ok := h.parseItem(c)
+	if !ok {
+		return
+	}
+
+	storageKeys, err := h.repo.Purge(workspaceID, itemType, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found in trash"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to purge item"})
+		return
+	}
+
+	h.deleteBlobs(storageKeys)
+	h.record(c, itemType, id, audit.ActionPurge)
+
+	c.Status(http.StatusNoContent)
+}
+
+// PurgeExpired permanently deletes the items of all workspaces that are past
+// the retention period and returns their number.
+func (h *Handler) PurgeExpired() (int64, error) {
+	purged, storageKeys, err := h.repo.PurgeExpired(h.now().Add(-h.retention))
+	if err != nil {
+		return 0, err
+	}
+
+	h.deleteBlobs(storageKeys)
+	return purged, nil
+}
+
+// StartPurging runs PurgeExpired now and then once per interval in the
+// background.
+func (h *Handler) StartPurging(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := h.PurgeExpired(); err != nil {
+				fmt.Printf("Warning: Failed to purge expired trash items: %v\n", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// Helper functions
+
+// deleteBlobs removes the files of purged attachments. The records are gone,
+// so a file left behind is only wasted space.
+func (h *Handler) deleteBlobs(storageKeys []string) {
+	for _, key := range storageKeys {
+		if err := h.store.Delete(key); err != nil {
+			fmt.Printf("Warning: Failed to delete blob %s: %v\n", key, err)
+		}
+	}
+}
+
+// parseItem reads type and ID of an item from the path; otherwise it
+// responds with an error.
+func (h *Handler) parseItem(c *gin.Context) (string, uint, bool) {
+	itemType := c.Param("type")
+	if !trash.IsValidType(itemType) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown item type"})
+		return "", 0, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return "", 0, false
+	}
+
+	return itemType, uint(id), true
+}
+
+// itemName returns the name of an item in the trash for the balance
+// monitor; empty without monitor.
+func (h *Handler) itemName(workspaceID uint, itemType string, id uint) string {
+	if h.balance == nil {
+		return ""
+	}
+
+	items, err := h.repo.ListItems(workspaceID)
+	if err != nil {
+		return ""
+	}
+	for _, item := range items {
+		if item.Type == itemType && item.ID == id {
+			return item.Name
+		}
+	}
+	return ""
+}
+
+// record adds the action on an item to the audit log; the item types are
+// named like the audited cost entities.
+func (h *Handler) record(c *gin.Context, itemType string, id uint, action string) {
+	h.audit.Record(audit.Change{
+		WorkspaceID: h.getWorkspaceID(c),
+		Actor:       h.getUserID(c),
+		EntityType:  itemType,
+		EntityID:    id,
+		Action:      action,
+	})
+}
+
+func (h *Handler) getUserID(c *gin.Context) uint {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return 0
+	}
+	return userID.(uint)
+}
+
+func (h *Handler) getWorkspaceID(c *gin.Context) uint {
+	workspaceID, exists := c.Get("workspace_id")
+	if !exists {
+		return 0
+	}
+	return workspaceID.(uint)
+}
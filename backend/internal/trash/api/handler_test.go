@@ -0,0 +1,160 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+	"wondee/finance-app-backend/internal/platform/blob"
+	"wondee/finance-app-backend/internal/trash"
+)
+
+// MockTrashRepository implements trash repository.Repository
+type MockTrashRepository struct {
+	mock.Mock
+}
+
+func (m *MockTrashRepository) ListItems(workspaceID uint) ([]trash.Item, error) {
+	args := m.Called(workspaceID)
+	return args.Get(0).([]trash.Item), args.Error(1)
+}
+
+func (m *MockTrashRepository) Restore(workspaceID uint, itemType string, id uint) error {
+	args := m.Called(workspaceID, itemType, id)
+	return args.Error(0)
+}
+
+func (m *MockTrashRepository) Purge(workspaceID uint, itemType string, id uint) ([]string, error) {
+	args := m.Called(workspaceID, itemType, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTrashRepository) PurgeExpired(deletedBefore time.Time) (int64, []string, error) {
+	args := m.Called(deletedBefore)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil, args.Error(2)
+	}
+	return args.Get(0).(int64), args.Get(1).([]string), args.Error(2)
+}
+
+// MockStore implements blob.Store and records the deleted keys
+type MockStore struct {
+	deleted []string
+}
+
+func (s *MockStore) Put(key string, content io.Reader) error {
+	return nil
+}
+
+func (s *MockStore) Get(key string) (io.ReadCloser, error) {
+	return nil, blob.ErrNotFound
+}
+
+func (s *MockStore) Delete(key string) error {
+	s.deleted = append(s.deleted, key)
+	return nil
+}
+
+var now = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func newTestHandler(mockRepo *MockTrashRepository, store *MockStore) *Handler {
+	handler := NewHandler(mockRepo, store, trash.DefaultRetention, nil, nil)
+	handler.now = func() time.Time { return now }
+	return handler
+}
+
+func setupTestRouter(mockRepo *MockTrashRepository, store *MockStore) *gin.Engine {
+	handler := newTestHandler(mockRepo, store)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+	router.GET("/trash", handler.GetTrash)
+	router.POST("/trash/:type/:id/restore", handler.RestoreItem)
+	router.DELETE("/trash/:type/:id", handler.PurgeItem)
+	return router
+}
+
+func serve(router *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetTrash(t *testing.T) {
+	mockRepo := new(MockTrashRepository)
+	router := setupTestRouter(mockRepo, &MockStore{})
+
+	deletedAt := now.Add(-48 * time.Hour)
+	mockRepo.On("ListItems", uint(1)).Return([]trash.Item{
+		{Type: trash.TypeFixedCost, ID: 3, Name: "Rent", Amount: -900, DeletedAt: deletedAt},
+	}, nil)
+
+	w := serve(router, http.MethodGet, "/trash")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []ItemDTO
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 1)
+	assert.Equal(t, "Rent", response[0].Name)
+	assert.True(t, response[0].PurgeAt.Equal(deletedAt.Add(trash.DefaultRetention)))
+	mockRepo.AssertExpectations(t)
+	// Listing the trash never purges
+	mockRepo.AssertNotCalled(t, "PurgeExpired", mock.Anything)
+}
+
+func TestRestoreItem(t *testing.T) {
+	mockRepo := new(MockTrashRepository)
+	router := setupTestRouter(mockRepo, &MockStore{})
+
+	mockRepo.On("Restore", uint(1), trash.TypeSpecialCost, uint(5)).Return(nil)
+	mockRepo.On("Restore", uint(1), trash.TypeSpecialCost, uint(6)).Return(gorm.ErrRecordNotFound)
+
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodPost, "/trash/specialCost/5/restore").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodPost, "/trash/specialCost/6/restore").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPost, "/trash/category/5/restore").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPost, "/trash/specialCost/abc/restore").Code)
+}
+
+func TestPurgeItem(t *testing.T) {
+	mockRepo := new(MockTrashRepository)
+	store := &MockStore{}
+	router := setupTestRouter(mockRepo, store)
+
+	mockRepo.On("Purge", uint(1), trash.TypeOneTimeCost, uint(2)).Return([]string{"1/receipt"}, nil)
+	mockRepo.On("Purge", uint(1), trash.TypeFixedCost, uint(2)).Return(nil, gorm.ErrRecordNotFound)
+
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodDelete, "/trash/oneTimeCost/2").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodDelete, "/trash/fixedCost/2").Code)
+	mockRepo.AssertExpectations(t)
+	assert.Equal(t, []string{"1/receipt"}, store.deleted)
+}
+
+func TestPurgeExpired(t *testing.T) {
+	mockRepo := new(MockTrashRepository)
+	store := &MockStore{}
+	handler := newTestHandler(mockRepo, store)
+
+	mockRepo.On("PurgeExpired", now.Add(-trash.DefaultRetention)).Return(int64(2), []string{"1/a", "3/b"}, nil)
+
+	purged, err := handler.PurgeExpired()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+	assert.Equal(t, []string{"1/a", "3/b"}, store.deleted)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"wondee/finance-app-backend/internal/attachment"
+	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/settlement"
+	"wondee/finance-app-backend/internal/spend"
+	"wondee/finance-app-backend/internal/trash"
+
+	"gorm.io/gorm"
+)
+
+// trashedItem holds the columns shared by all costs that can be deleted.
+type trashedItem struct {
+	ID        uint
+	Name      string
+	Amount    int
+	Currency  string
+	DeletedAt time.Time
+}
+
+func (r *PostgresRepository) ListItems(workspaceID uint) ([]trash.Item, error) {
+	items := make([]trash.Item, 0)
+	for _, itemType := range trash.Types {
+		var rows []trashedItem
+		if err := r.DB.Unscoped().Model(modelOf(itemType)).
+			Where("workspace_id = ? AND deleted_at IS NOT NULL", workspaceID).
+			Find(&rows).Error; err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			items = append(items, trash.Item{
+				Type:      itemType,
+				ID:        row.ID,
+				Name:      row.Name,
+				Amount:    row.Amount,
+				Currency:  row.Currency,
+				DeletedAt: row.DeletedAt,
+			})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+	return items, nil
+}
+
+func (r *PostgresRepository) Restore(workspaceID uint, itemType string, id uint) error {
+	if !trash.IsValidType(itemType) {
+		return fmt.Errorf("unknown item type %q", itemType)
+	}
+
+	result := r.DB.Unscoped().Model(modelOf(itemType)).
+		Where("id = ? AND workspace_id = ? AND deleted_at IS NOT NULL", id, workspaceID).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) Purge(workspaceID uint, itemType string, id uint) ([]string, error) {
+	if !trash.IsValidType(itemType) {
+		return nil, fmt.Errorf("unknown item type %q", itemType)
+	}
+
+	var storageKeys []string
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		purged, keys, err := purge(tx, itemType, "id = ? AND workspace_id = ? AND deleted_at IS NOT NULL", id, workspaceID)
+		if err != nil {
+			return err
+		}
+		if purged == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		storageKeys = keys
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return storageKeys, nil
+}
+
+func (r *PostgresRepository) PurgeExpired(deletedBefore time.Time) (int64, []string, error) {
+	var total int64
+	var storageKeys []string
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		for _, itemType := range trash.Types {
+			purged, keys, err := purge(tx, itemType, "deleted_at < ?", deletedBefore)
+			if err != nil {
+				return err
+			}
+			total += purged
+			storageKeys = append(storageKeys, keys...)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return total, storageKeys, nil
+}
+
+// purge permanently deletes the trashed items matching the query together
+// with their attachments, whose storage keys it returns. Settlement entries
+// linked to the items are kept without the link. For fixed costs, the
+// amount revisions and save-to-spend payment statuses go with them.
+func purge(tx *gorm.DB, itemType string, query string, args ...interface{}) (int64, []string, error) {
+	model := modelOf(itemType)
+	trashed := func() *gorm.DB {
+		return tx.Unscoped().Model(model).Select("id").Where(query, args...)
+	}
+
+	// The item types are named like the owners of attachments
+	attachments := func() *gorm.DB {
+		return tx.Model(&attachment.Attachment{}).Where("owner_type = ? AND owner_id IN (?)", itemType, trashed())
+	}
+	var storageKeys []string
+	if err := attachments().Pluck("storage_key", &storageKeys).Error; err != nil {
+		return 0, nil, err
+	}
+	if err := attachments().Delete(&attachment.Attachment{}).Error; err != nil {
+		return 0, nil, err
+	}
+
+	// Settlement entries keep their description, but lose the link to the
+	// cost; like attachments, they name the cost by its item type
+	if err := tx.Model(&settlement.Entry{}).
+		Where("cost_type = ? AND cost_id IN (?)", itemType, trashed()).
+		Updates(map[string]interface{}{"cost_type": "", "cost_id": nil}).Error; err != nil {
+		return 0, nil, err
+	}
+
+	if itemType == trash.TypeFixedCost {
+		if err := tx.Where("fixed_cost_id IN (?)", trashed()).Delete(&cost.AmountRevision{}).Error; err != nil {
+			return 0, nil, err
+		}
+		if err := tx.Where("fixed_cost_id IN (?)", trashed()).Delete(&spend.MonthlyPaymentStatus{}).Error; err != nil {
+			return 0, nil, err
+		}
+	}
+
+	result := tx.Unscoped().Where(query, args...).Delete(model)
+	if result.Error != nil {
+		return 0, nil, result.Error
+	}
+	return result.RowsAffected, storageKeys, nil
+}
+
+func modelOf(itemType string) interface{} {
+	switch itemType {
+	case trash.TypeFixedCost:
+		return &cost.FixedCost{}
+	case trash.TypeSpecialCost:
+		return &cost.SpecialCost{}
+	default:
+		return &spend.OneTimePendingCost{}
+	}
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"time"
+
+	"wondee/finance-app-backend/internal/trash"
+
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for access to deleted costs
+type Repository interface {
+	ListItems(workspaceID uint) ([]trash.Item, error)
+	Restore(workspaceID uint, itemType string, id uint) error
+
+	// Purge deletes an item in the trash permanently, together with the
+	// records depending on it. It returns the storage keys of the deleted
+	// attachments, whose blobs are left to the caller.
+	Purge(workspaceID uint, itemType string, id uint) ([]string, error)
+	// PurgeExpired purges the items of all workspaces deleted before the
+	// given time. It returns their number and the storage keys of their
+	// deleted attachments.
+	PurgeExpired(deletedBefore time.Time) (int64, []string, error)
+}
+
+// PostgresRepository implements Repository using GORM
+type PostgresRepository struct {
+	DB *gorm.DB
+}
@@ -0,0 +1,35 @@
+package trash
+
+import (
+	"slices"
+	"time"
+)
+
+// Kinds of deleted items, named like the owners of attachments.
+const (
+	TypeFixedCost   = "fixedCost"
+	TypeSpecialCost = "specialCost"
+	TypeOneTimeCost = "oneTimeCost"
+)
+
+// Types lists all kinds of items that can be in the trash.
+var Types = []string{TypeFixedCost, TypeSpecialCost, TypeOneTimeCost}
+
+// DefaultRetention is how long deleted items are kept before they are
+// purged.
+const DefaultRetention = 30 * 24 * time.Hour
+
+// Item is a deleted cost that can still be restored.
+type Item struct {
+	Type      string
+	ID        uint
+	Name      string
+	Amount    int
+	Currency  string
+	DeletedAt time.Time
+}
+
+// IsValidType reports whether items of the given type can be in the trash.
+func IsValidType(itemType string) bool {
+	return slices.Contains(Types, itemType)
+}
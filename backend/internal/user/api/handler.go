@@ -4,11 +4,13 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/audit"
 	"wondee/finance-app-backend/internal/storage"
 )
 
 type Handler struct {
-	Repo storage.Repository
+	Repo  storage.Repository
+	Audit *audit.Log
 }
 
 type UpdateUserRequest struct {
@@ -49,12 +51,27 @@ func (h *Handler) UpdateCurrentAmount(c *gin.Context) {
 		return
 	}
 
+	var before interface{}
+	if workspace, err := h.Repo.GetWorkspaceByID(workspaceID); err == nil {
+		before = gin.H{"amount": workspace.CurrentAmount}
+	}
+
 	err := h.Repo.UpdateWorkspaceCurrentAmount(workspaceID, *req.Amount)
 	if err != nil {
 		c.Status(http.StatusInternalServerError)
 		return
 	}
 
+	h.Audit.Record(audit.Change{
+		WorkspaceID: workspaceID,
+		Actor:       h.getUserID(c),
+		EntityType:  audit.EntityCurrentAmount,
+		EntityID:    workspaceID,
+		Action:      audit.ActionUpdate,
+		Before:      before,
+		After:       gin.H{"amount": *req.Amount},
+	})
+
 	c.JSON(http.StatusOK, gin.H{"amount": *req.Amount})
 }
 
@@ -72,6 +89,14 @@ func (h *Handler) DeleteCurrentUser(c *gin.Context) {
 		return
 	}
 
+	h.Audit.Record(audit.Change{
+		WorkspaceID: h.getWorkspaceID(c),
+		Actor:       userID,
+		EntityType:  audit.EntityMember,
+		EntityID:    userID,
+		Action:      audit.ActionRemove,
+	})
+
 	c.Status(http.StatusNoContent)
 }
 
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/audit"
 	"wondee/finance-app-backend/internal/storage"
 	"wondee/finance-app-backend/internal/user"
 	"wondee/finance-app-backend/internal/workspace"
@@ -190,3 +191,45 @@ func TestUpdateOnboardingStatus_InvalidRequest(t *testing.T) {
 		t.Errorf("Expected error message about completed field, got %s", response["error"])
 	}
 }
+
+type auditWriter struct {
+	entries []audit.Entry
+}
+
+func (w *auditWriter) CreateEntry(entry *audit.Entry) error {
+	w.entries = append(w.entries, *entry)
+	return nil
+}
+
+func TestUpdateCurrentAmount_RecordsAudit(t *testing.T) {
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: 1, CurrentAmount: 500}},
+	}
+	writer := &auditWriter{}
+	handler := &Handler{Repo: mockRepo, Audit: audit.NewLog(writer)}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(2))
+		c.Set("workspace_id", uint(1))
+		c.Next()
+	})
+	r.PUT("/user/current-amount", handler.UpdateCurrentAmount)
+
+	reqBody, _ := json.Marshal(map[string]int{"amount": 1000})
+	req, _ := http.NewRequest(http.MethodPut, "/user/current-amount", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if len(writer.entries) != 1 {
+		t.Fatalf("Expected 1 audit entry, got %d", len(writer.entries))
+	}
+	entry := writer.entries[0]
+	if entry.UserID != 2 || entry.EntityType != audit.EntityCurrentAmount || entry.Action != audit.ActionUpdate {
+		t.Errorf("Unexpected audit entry %+v", entry)
+	}
+	if entry.Before != `{"amount":500}` || entry.After != `{"amount":1000}` {
+		t.Errorf("Expected balance change from 500 to 1000, got %s -> %s", entry.Before, entry.After)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/platform/export"
 	"wondee/finance-app-backend/internal/wealth/service"
 )
 
@@ -43,3 +44,30 @@ func (h *ForecastHandler) GetWealthForecast(c *gin.Context) {
 
 	c.JSON(http.StatusOK, forecast)
 }
+
+// ExportWealthForecast downloads the yearly forecast points.
+func (h *ForecastHandler) ExportWealthForecast(c *gin.Context) {
+	userID := h.getUserID(c)
+	workspaceID := h.getWorkspaceID(c)
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	forecast, err := h.Service.CalculateForecast(userID, workspaceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	table := &export.Table{
+		Name:    "wealth-forecast",
+		Columns: []string{"year", "invested", "worst", "average", "best"},
+	}
+
+	for _, point := range forecast.Points {
+		table.Append(point.Year, point.Invested, point.Worst, point.Average, point.Best)
+	}
+
+	export.Respond(c, table)
+}
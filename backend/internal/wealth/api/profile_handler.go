@@ -4,12 +4,14 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/audit"
 	"wondee/finance-app-backend/internal/wealth"
 	"wondee/finance-app-backend/internal/wealth/service"
 )
 
 type ProfileHandler struct {
 	Service *service.ProfileService
+	Audit   *audit.Log
 }
 
 func (h *ProfileHandler) getWorkspaceID(c *gin.Context) uint {
@@ -50,6 +52,11 @@ func (h *ProfileHandler) UpsertWealthProfile(c *gin.Context) {
 		return
 	}
 
+	var before *wealth.WealthProfile
+	if h.Audit.Enabled() {
+		before, _ = h.Service.GetProfile(workspaceID)
+	}
+
 	profile.UserID = userID
 	profile.WorkspaceID = workspaceID
 	if err := h.Service.UpdateProfile(&profile); err != nil {
@@ -57,5 +64,19 @@ func (h *ProfileHandler) UpsertWealthProfile(c *gin.Context) {
 		return
 	}
 
+	change := audit.Change{
+		WorkspaceID: workspaceID,
+		Actor:       userID,
+		EntityType:  audit.EntityWealthProfile,
+		EntityID:    profile.ID,
+		Action:      audit.ActionCreate,
+		After:       profile,
+	}
+	if before != nil && before.ID != 0 {
+		change.Action = audit.ActionUpdate
+		change.Before = before
+	}
+	h.Audit.Record(change)
+
 	c.JSON(http.StatusOK, profile)
 }
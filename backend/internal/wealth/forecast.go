@@ -15,4 +15,5 @@ type ForecastResponse struct {
 	StartCapital  float64         `json:"start_capital"`
 	MonthlySaving float64         `json:"monthly_saving"`
 	DurationYears int             `json:"duration_years"`
+	MissingRates  []string        `json:"missing_rates,omitempty"`
 }
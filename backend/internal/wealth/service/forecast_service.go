@@ -1,10 +1,10 @@
 package service
 
 import (
-	"fmt"
 	"math"
 	"wondee/finance-app-backend/internal/cost"
 	cost_repo "wondee/finance-app-backend/internal/cost/repository"
+	"wondee/finance-app-backend/internal/currency"
 	"wondee/finance-app-backend/internal/platform/types"
 	"wondee/finance-app-backend/internal/storage"
 	"wondee/finance-app-backend/internal/wealth"
@@ -27,40 +27,48 @@ func (s *ForecastService) CalculateForecast(userID uint, workspaceID uint) (*wea
 	}
 
 	// 2. Get Saving Fixed Costs
+	// Each cost is expanded through its recurrence rule during the simulation;
+	// monthlySaving only reports the average of the open-ended ones.
+	// All flows are converted into the base currency of the workspace.
+	// Transfers between accounts leave the wealth unchanged.
+	converter, err := currency.LoadConverter(s.Repo, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
 	fixedCosts := s.CostRepo.LoadFixedCosts(workspaceID)
 	monthlySaving := 0.0
-	var fixedCostsWithDependency []cost.FixedCost
+	var savingCosts []cost.FixedCost
 	if fixedCosts != nil {
 		for _, cost := range *fixedCosts {
-			if cost.IsSaving {
+			if cost.IsSaving && !cost.IsTransfer() {
+				savingCosts = append(savingCosts, cost)
 				if cost.From == nil && cost.To == nil {
-					monthlySaving -= float64(cost.Amount)
-				} else {
-					fixedCostsWithDependency = append(fixedCostsWithDependency, cost) //
+					current := types.CurrentYearMonth()
+					monthlySaving -= converter.ConvertFloat(cost.MonthlyAverage(current), cost.Currency, current)
 				}
 			}
 		}
 	}
 
-	fmt.Println("--- monthly saving", monthlySaving)
-	fmt.Println("--- fixed costs with dependency", fixedCostsWithDependency)
-
 	// 3. Get Special Costs (Savings)
 	specialCosts := s.CostRepo.LoadSpecialCosts(workspaceID)
 	specialSavingsMap := make(map[types.YearMonth]float64)
 	if specialCosts != nil {
 		for _, cost := range *specialCosts {
-			if cost.IsSaving && cost.DueDate != nil {
+			if !cost.IsSaving || cost.IsTransfer() {
+				continue
+			}
+			// Installment plans are saved in several payments
+			for _, payment := range cost.Payments() {
 				// Accumulate in case multiple events happen in the same month
 				// Subtract amount to correctly handle savings (negative amount -> positive addition)
 				// and extractions (positive amount -> negative deduction)
-				specialSavingsMap[*cost.DueDate] -= float64(cost.Amount)
+				specialSavingsMap[payment.DueDate] -= converter.ConvertFloat(float64(payment.Amount), cost.Currency, &payment.DueDate)
 			}
 		}
 	}
 
-	fmt.Println("--- special savings", specialSavingsMap)
-
 	// 4. Calculate
 	startCapital := profile.CurrentWealth
 	durationYears := profile.ForecastDurationYears
@@ -85,28 +93,16 @@ func (s *ForecastService) CalculateForecast(userID uint, workspaceID uint) (*wea
 		for m := 0; m < 12; m++ {
 			simDate = types.NextYearMonth(simDate)
 
-			// Add monthly savings
-			simWorst += monthlySaving
-			simAvg += monthlySaving
-			simBest += monthlySaving
-			simInvested += monthlySaving
-
-			// Add special savings if any for this month
-			if amount, ok := specialSavingsMap[*simDate]; ok {
-				simWorst += amount
-				simAvg += amount
-				simBest += amount
-				simInvested += amount
+			// Savings due this month, including special savings
+			flow := specialSavingsMap[*simDate]
+			for _, cost := range savingCosts {
+				flow -= converter.ConvertFloat(float64(cost.DueAmount(simDate)), cost.Currency, simDate)
 			}
 
-			for _, cost := range fixedCostsWithDependency {
-				if types.IsRelevant(simDate, cost.From, cost.To) {
-					simWorst -= float64(cost.Amount)
-					simAvg -= float64(cost.Amount)
-					simBest -= float64(cost.Amount)
-					simInvested -= float64(cost.Amount)
-				}
-			}
+			simWorst += flow
+			simAvg += flow
+			simBest += flow
+			simInvested += flow
 
 			// Apply interest
 			simWorst *= (1 + rateWorstMonthly)
@@ -128,5 +124,6 @@ func (s *ForecastService) CalculateForecast(userID uint, workspaceID uint) (*wea
 		StartCapital:  startCapital,
 		MonthlySaving: monthlySaving,
 		DurationYears: durationYears,
+		MissingRates:  converter.Missing(),
 	}, nil
 }
@@ -3,9 +3,11 @@ package service
 import (
 	"testing"
 	"wondee/finance-app-backend/internal/cost"
+	"wondee/finance-app-backend/internal/currency"
 	"wondee/finance-app-backend/internal/platform/types"
 	"wondee/finance-app-backend/internal/storage"
 	"wondee/finance-app-backend/internal/wealth"
+	"wondee/finance-app-backend/internal/workspace"
 )
 
 func TestCalculateForecast(t *testing.T) {
@@ -98,7 +100,7 @@ func TestCalculateForecast_WithSpecialSavingsAndFromSavings(t *testing.T) {
 				Name:        "Saving",
 				Amount:      -100,
 				IsSaving:    true,
-				From:        types.AddMonths(types.CurrentYearMonth(), 12),
+				From:        types.AddMonths(types.CurrentYearMonth(), 13),
 			},
 		}, // No monthly savings
 		SpecialCosts: []cost.SpecialCost{
@@ -161,3 +163,137 @@ func TestCalculateForecast_NoProfile(t *testing.T) {
 		t.Error("Expected error for missing profile")
 	}
 }
+
+func TestCalculateForecast_WithEscalatingSaving(t *testing.T) {
+	var workspaceID uint = 3
+	// The forecast starts with the month after the current one
+	start := types.AddMonths(types.CurrentYearMonth(), 1)
+
+	mockRepo := &storage.MockRepository{
+		WealthProfiles: []wealth.WealthProfile{
+			{
+				UserID:                1,
+				WorkspaceID:           workspaceID,
+				CurrentWealth:         0,
+				ForecastDurationYears: 2,
+			},
+		},
+		FixedCosts: []cost.FixedCost{
+			{
+				UserID:          1,
+				WorkspaceID:     workspaceID,
+				Name:            "Saving plan",
+				Amount:          -100,
+				IsSaving:        true,
+				From:            start,
+				EscalationRate:  10,
+				EscalationMonth: start.Month,
+			},
+		},
+	}
+
+	service := NewForecastService(mockRepo, mockRepo)
+
+	forecast, err := service.CalculateForecast(1, workspaceID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// First year 12 * 100, second year 12 * 110
+	AssertInvestedPoint(t, forecast, 0, 1200.0)
+	AssertInvestedPoint(t, forecast, 1, 2520.0)
+}
+
+func TestCalculateForecast_ConvertsForeignSavings(t *testing.T) {
+	var workspaceID uint = 4
+	current := types.CurrentYearMonth()
+
+	mockRepo := &storage.MockRepository{
+		Workspaces: []workspace.Workspace{{ID: workspaceID, BaseCurrency: "EUR"}},
+		WealthProfiles: []wealth.WealthProfile{
+			{
+				UserID:                1,
+				WorkspaceID:           workspaceID,
+				CurrentWealth:         0,
+				ForecastDurationYears: 1,
+			},
+		},
+		FixedCosts: []cost.FixedCost{
+			{UserID: 1, WorkspaceID: workspaceID, Name: "ETF USD", Amount: -100, Currency: "USD", IsSaving: true},
+		},
+		ExchangeRates: []currency.ExchangeRate{
+			{WorkspaceID: workspaceID, Currency: "USD", Rate: 0.9, ValidFrom: *current},
+		},
+	}
+
+	service := NewForecastService(mockRepo, mockRepo)
+
+	forecast, err := service.CalculateForecast(1, workspaceID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if forecast.MonthlySaving != 90 {
+		t.Errorf("Expected monthly saving of 90, got %f", forecast.MonthlySaving)
+	}
+	AssertInvestedPoint(t, forecast, 0, 1080.0)
+}
+
+func TestCalculateForecast_WithInstallmentSavings(t *testing.T) {
+	var workspaceID uint = 1
+
+	mockRepo := &storage.MockRepository{
+		WealthProfiles: []wealth.WealthProfile{
+			{UserID: 1, WorkspaceID: workspaceID, CurrentWealth: 1000, ForecastDurationYears: 2},
+		},
+		SpecialCosts: []cost.SpecialCost{
+			{
+				UserID:       1,
+				WorkspaceID:  workspaceID,
+				Name:         "Gold coins",
+				Amount:       -2400,
+				IsSaving:     true,
+				DueDate:      types.AddMonths(types.CurrentYearMonth(), 7),
+				Installments: 12,
+			},
+		},
+	}
+
+	service := NewForecastService(mockRepo, mockRepo)
+
+	forecast, err := service.CalculateForecast(1, workspaceID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Six installments of 200 in the first year, six in the second
+	AssertInvestedPoint(t, forecast, 0, 2200.0)
+	AssertInvestedPoint(t, forecast, 1, 3400.0)
+}
+
+func TestCalculateForecast_IgnoresTransfers(t *testing.T) {
+	var workspaceID uint = 1
+	savings := uint(2)
+
+	mockRepo := &storage.MockRepository{
+		WealthProfiles: []wealth.WealthProfile{
+			{WorkspaceID: workspaceID, CurrentWealth: 1000, ForecastDurationYears: 1},
+		},
+		FixedCosts: []cost.FixedCost{
+			{WorkspaceID: workspaceID, Name: "ETF", Amount: -100, IsSaving: true},
+			{WorkspaceID: workspaceID, Name: "Tagesgeld", Amount: -300, IsSaving: true, TargetAccountID: &savings},
+		},
+		SpecialCosts: []cost.SpecialCost{
+			{WorkspaceID: workspaceID, Name: "Steuerrücklage", Amount: -500, IsSaving: true, DueDate: types.CurrentYearMonth(), TargetAccountID: &savings},
+		},
+	}
+
+	forecast, err := NewForecastService(mockRepo, mockRepo).CalculateForecast(1, workspaceID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if forecast.MonthlySaving != 100 || forecast.Points[0].Invested != 1000+12*100 {
+		t.Errorf("Expected transfers to leave the forecast unchanged, got %f and %f", forecast.MonthlySaving, forecast.Points[0].Invested)
+	}
+}
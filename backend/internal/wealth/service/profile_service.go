@@ -36,7 +36,15 @@ func (s *ProfileService) GetProfile(workspaceID uint) (*wealth.WealthProfile, er
 }
 
 func (s *ProfileService) UpdateProfile(profile *wealth.WealthProfile) error {
-	// Validation
+	if err := ValidateProfile(profile); err != nil {
+		return err
+	}
+
+	return s.repo.UpsertWealthProfile(profile)
+}
+
+// ValidateProfile checks the parameters of a profile before it is stored.
+func ValidateProfile(profile *wealth.WealthProfile) error {
 	if profile.CurrentWealth < 0 {
 		return errors.New("current wealth must be non-negative")
 	}
@@ -50,7 +58,7 @@ func (s *ProfileService) UpdateProfile(profile *wealth.WealthProfile) error {
 		return errors.New("rates consistency error: worst <= average <= best")
 	}
 
-	return s.repo.UpsertWealthProfile(profile)
+	return nil
 }
 
 func isValidRate(rate float64) bool {
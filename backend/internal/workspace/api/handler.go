@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"wondee/finance-app-backend/internal/audit"
 	"wondee/finance-app-backend/internal/storage"
 	user_service "wondee/finance-app-backend/internal/user/service"
 	"wondee/finance-app-backend/internal/workspace/service"
@@ -15,6 +16,7 @@ type Handler struct {
 	WorkspaceService *service.WorkspaceService
 	InviteService    *service.InviteService
 	UserService      *user_service.UserService
+	Audit            *audit.Log
 }
 
 func (h *Handler) getUserID(c *gin.Context) uint {
@@ -131,6 +133,15 @@ func (h *Handler) JoinWorkspace(c *gin.Context) {
 		// Log error but don't fail request as user is already joined
 	}
 
+	h.Audit.Record(audit.Change{
+		WorkspaceID: invite.WorkspaceID,
+		Actor:       userID,
+		EntityType:  audit.EntityMember,
+		EntityID:    userID,
+		Action:      audit.ActionJoin,
+		After:       gin.H{"userId": userID, "name": user.Name, "invitedBy": invite.InvitedBy},
+	})
+
 	c.JSON(http.StatusOK, gin.H{"message": "Joined workspace successfully", "workspace_id": invite.WorkspaceID})
 }
 
@@ -183,5 +194,13 @@ func (h *Handler) RemoveMember(c *gin.Context) {
 		return
 	}
 
+	h.Audit.Record(audit.Change{
+		WorkspaceID: workspaceID,
+		Actor:       requestingUserID,
+		EntityType:  audit.EntityMember,
+		EntityID:    uint(memberID),
+		Action:      audit.ActionRemove,
+	})
+
 	c.Status(http.StatusOK)
 }
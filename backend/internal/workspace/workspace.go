@@ -10,10 +10,14 @@ type Workspace struct {
 	ID                 uint   `gorm:"primaryKey"`
 	Name               string `gorm:"not null"`
 	CurrentAmount      int    `gorm:"default:0"`
-	SaveToSpendBalance int    `gorm:"default:0"` // Checking account balance for Save-to-Spend
+	SaveToSpendBalance int    `gorm:"default:0"`                     // Checking account balance for Save-to-Spend
+	BaseCurrency       string `gorm:"size:3;not null;default:'EUR'"` // All amounts are converted into this currency
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 
+	// Projected balances below the threshold are reported as risk
+	LowBalanceThreshold int `gorm:"default:0"`
+
 	// Relationships
 	Users      []user.User      `gorm:"foreignKey:WorkspaceID"`
 	FixedCosts []cost.FixedCost `gorm:"foreignKey:WorkspaceID"`